@@ -63,5 +63,6 @@ func (b *Builder) writeAlias(alias, canonical string) error {
 	defer f.Close()
 
 	fmt.Fprintf(f, redirectHTML, canonical, canonical, canonical, canonical)
+	b.recordOutput(outPath)
 	return nil
 }
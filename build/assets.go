@@ -19,15 +19,15 @@ var embeddedAssets embed.FS
 // writeAssets copies static CSS and JS files into pubDir. If assetsDir is
 // non-empty it is used as the source (must contain css/ and js/ subdirectories
 // mirroring the embedded layout); otherwise the files embedded in the binary
-// are written.
-func writeAssets(pubDir, assetsDir string) error {
+// are written. It returns the paths of the files written.
+func writeAssets(pubDir, assetsDir string) ([]string, error) {
 	var src fs.FS
 	if assetsDir != "" {
 		src = os.DirFS(assetsDir)
 	} else {
 		sub, err := fs.Sub(embeddedAssets, "assets")
 		if err != nil {
-			return fmt.Errorf("assets sub-fs: %w", err)
+			return nil, fmt.Errorf("assets sub-fs: %w", err)
 		}
 		src = sub
 	}
@@ -35,9 +35,10 @@ func writeAssets(pubDir, assetsDir string) error {
 }
 
 // copyFS walks src and writes every file into dstDir, preserving the relative
-// path structure.
-func copyFS(dstDir string, src fs.FS) error {
-	return fs.WalkDir(src, ".", func(path string, d fs.DirEntry, err error) error {
+// path structure. It returns the paths of the files written.
+func copyFS(dstDir string, src fs.FS) ([]string, error) {
+	var written []string
+	err := fs.WalkDir(src, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -55,6 +56,8 @@ func copyFS(dstDir string, src fs.FS) error {
 		if err := os.WriteFile(dst, data, 0o644); err != nil {
 			return fmt.Errorf("write asset %s: %w", dst, err)
 		}
+		written = append(written, dst)
 		return nil
 	})
+	return written, err
 }
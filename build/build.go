@@ -132,6 +132,12 @@ type NavEntry struct {
 // PageData is passed to each page template during rendering. Embedding
 // FrontMatter lets templates access fields like {{.Title}}, {{.Layout}}, etc.
 // directly alongside {{.Body}} and {{.Tree}}.
+//
+// PageData is the contract between the builder and the templates of a theme:
+// every layout, whether embedded or supplied by a theme, is executed with a
+// PageData value, and a theme may only refer to the fields declared here and
+// in FrontMatter. Fields are added but not renamed or removed so that themes
+// keep working across releases.
 type PageData struct {
 	FrontMatter
 	Body       template.HTML
@@ -152,11 +158,15 @@ type Builder struct {
 	// AssetsDir, if non-empty, is a directory of static assets (css/, js/)
 	// to copy into PubDir. When empty the assets embedded in the binary are used.
 	AssetsDir string
+	// ThemeDir, if non-empty, is a directory of templates that override
+	// individual layouts and partials of the embedded templates.
+	ThemeDir string
 	// Debug, when true, adds a debug footer to every rendered page.
 	Debug bool
-	// BaseURL, if non-empty, is the scheme+host used to build absolute <loc>
-	// URLs in sitemap.xml (e.g. "https://example.com"). When empty, no
-	// sitemap.xml is written.
+	// BaseURL, if non-empty, is the URL of the site used to build absolute
+	// <loc> URLs in sitemap.xml (e.g. "https://example.com" or
+	// "https://example.org/family/"). Root-relative links in pages include its
+	// path. When empty, no sitemap.xml is written.
 	BaseURL string
 	// IncludeDrafts, when true, publishes pages with draft: true in their
 	// front-matter instead of skipping them.
@@ -165,6 +175,15 @@ type Builder struct {
 	// private: yes in their front-matter. When false, the body is hidden
 	// and a placeholder message is shown instead.
 	IncludePrivate bool
+	// StrictLinks, when true, fails the build if any rendered page contains an
+	// internal link that does not resolve to a page or file in the output.
+	// Dangling links are always reported as warnings.
+	StrictLinks bool
+	// Family, if non-nil, describes a family-only site that is built before
+	// this one. This Builder then builds the public site, which must not
+	// include private pages, and removes any links to pages or files that
+	// only exist in the family site.
+	Family *FamilyTier
 
 	// sitemapEntries accumulates pages for sitemap.xml during the build.
 	sitemapEntries []sitemapEntry
@@ -176,6 +195,30 @@ type Builder struct {
 	// templates is the per-build template set, created at the start of Build()
 	// with image-selection functions that close over ContentDir.
 	templates *template.Template
+
+	// aliasIndex maps alias paths (e.g. "/r/I0021") to the page they redirect to.
+	aliasIndex map[string]childPage
+
+	// outputs holds the site path of every file written during the build,
+	// used to resolve internal links.
+	outputs map[string]bool
+
+	// links accumulates the internal links found in rendered pages, checked
+	// once every page has been written.
+	links []pageLink
+
+	// privateDirs holds the content-relative, slash-separated directories of
+	// pages marked private. When private content is excluded from the build
+	// the files in these directories are not copied to the output.
+	privateDirs map[string]bool
+
+	// feeds accumulates the feeds derived from rendered pages, such as each
+	// tree's change list, written once every page has been rendered.
+	feeds []feed
+
+	// family is the completed build of the family site, set when building
+	// the public site of a two-tier build.
+	family *Builder
 }
 
 // Build walks ContentDir and processes every file into PubDir. Markdown files
@@ -186,17 +229,38 @@ type Builder struct {
 // Build uses a two-pass strategy: the first pass collects child pages for
 // every section so that section index files with empty bodies can have a
 // generated child listing injected before rendering.
+//
+// When Family is set the family site is built first and then the public site.
 func (b *Builder) Build() error {
-	if err := writeAssets(b.PubDir, b.AssetsDir); err != nil {
-		return fmt.Errorf("write assets: %w", err)
+	if b.Family != nil {
+		return b.buildTiers()
 	}
+	return b.build()
+}
 
+func (b *Builder) build() error {
 	children, sectionTitles, tagIndex, draftDirs, aliasIndex, err := collectChildren(b.ContentDir, b.IncludeDrafts)
 	if err != nil {
 		return fmt.Errorf("collect children: %w", err)
 	}
+	b.templates, err = b.loadTemplates(aliasIndex, draftDirs)
+	if err != nil {
+		return err
+	}
+
+	assets, err := writeAssets(b.PubDir, b.AssetsDir)
+	if err != nil {
+		return fmt.Errorf("write assets: %w", err)
+	}
+	for _, a := range assets {
+		b.recordOutput(a)
+	}
+
+	if !b.IncludePrivate {
+		b.privateDirs = withholdPrivatePages(children, aliasIndex, b.family != nil)
+	}
+	b.aliasIndex = aliasIndex
 	b.diaryNav = buildDiaryNav(children)
-	b.templates = buildSiteTemplates(filepath.Join(b.ContentDir, "images"), aliasIndex)
 
 	if err := filepath.WalkDir(b.ContentDir, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
@@ -229,11 +293,21 @@ func (b *Builder) Build() error {
 		if strings.HasSuffix(d.Name(), ".md") {
 			return b.renderMarkdown(path, rel, children, sectionTitles)
 		}
+		if withinDirs(filepath.ToSlash(filepath.Dir(rel)), b.privateDirs) {
+			// images and other files belonging to a private page
+			return nil
+		}
 		return b.copyFile(path, rel)
 	}); err != nil {
 		return err
 	}
 
+	if b.family != nil {
+		if err := b.withholdFamilyLinks(); err != nil {
+			return fmt.Errorf("withhold family links: %w", err)
+		}
+	}
+
 	if err := b.writeTags(tagIndex); err != nil {
 		return fmt.Errorf("write tags: %w", err)
 	}
@@ -242,11 +316,43 @@ func (b *Builder) Build() error {
 		if err := writeSitemap(b.PubDir, b.BaseURL, b.sitemapEntries); err != nil {
 			return fmt.Errorf("write sitemap: %w", err)
 		}
+
+		feeds := append(b.feeds, b.diaryFeed(children, draftDirs), b.storiesFeed(children, draftDirs))
+		for _, f := range feeds {
+			written, err := writeFeed(b.PubDir, b.BaseURL, f)
+			if err != nil {
+				return fmt.Errorf("write feed for %s: %w", f.URL, err)
+			}
+			for _, w := range written {
+				b.recordOutput(w)
+			}
+		}
+	}
+
+	if n := b.checkLinks(); n > 0 {
+		logging.Warn("found dangling links", "count", n)
+		if b.StrictLinks {
+			return fmt.Errorf("found %d dangling links", n)
+		}
 	}
 
 	return nil
 }
 
+// pageURLFor returns the canonical URL of the page rendered from the markdown
+// file at the content-relative path rel.
+func pageURLFor(rel string) string {
+	stem := strings.TrimSuffix(filepath.Base(rel), ".md")
+	relDir := filepath.ToSlash(filepath.Dir(rel))
+	if stem == "_index" || stem == "index" {
+		if relDir == "." {
+			return "/"
+		}
+		return "/" + relDir + "/"
+	}
+	return "/" + relDir + "/" + stem + "/"
+}
+
 func (b *Builder) renderMarkdown(srcPath, rel string, children map[string][]childPage, sectionTitles map[string]string) error {
 	data, err := os.ReadFile(srcPath)
 	if err != nil {
@@ -267,6 +373,11 @@ func (b *Builder) renderMarkdown(srcPath, rel string, children map[string][]chil
 	if bool(fm.Draft) && !b.IncludeDrafts {
 		return nil
 	}
+	if bool(fm.Private) && !b.IncludePrivate && b.family != nil {
+		// the page is only published in the family site
+		return nil
+	}
+	b.checkSubjectAliases(srcPath, fm)
 
 	// Normalise basepath to always have a trailing slash so template links
 	// like {{.BasePath}}list/people/ produce correct URLs regardless of how
@@ -298,16 +409,7 @@ func (b *Builder) renderMarkdown(srcPath, rel string, children map[string][]chil
 	relDir := filepath.ToSlash(filepath.Dir(rel))
 
 	// Compute the canonical URL early so it can drive layout resolution.
-	var pageURL string
-	if stem == "_index" || stem == "index" {
-		if relDir == "." {
-			pageURL = "/"
-		} else {
-			pageURL = "/" + relDir + "/"
-		}
-	} else {
-		pageURL = "/" + relDir + "/" + stem + "/"
-	}
+	pageURL := pageURLFor(rel)
 
 	layout := resolveLayout(pageURL, fm.Layout)
 	if layout == "" {
@@ -323,6 +425,9 @@ func (b *Builder) renderMarkdown(srcPath, rel string, children map[string][]chil
 			recent = recent[:10]
 		}
 		for i := range recent {
+			if bool(recent[i].FM.Private) && !b.IncludePrivate {
+				continue
+			}
 			if err := loadDiaryEntryBody(b.ContentDir, &recent[i]); err != nil {
 				logging.Warn("failed to load diary entry body", "url", recent[i].URL, "err", err)
 			}
@@ -359,6 +464,7 @@ func (b *Builder) renderMarkdown(srcPath, rel string, children map[string][]chil
 	rendered := htmlCommentRE.ReplaceAll(buf.Bytes(), nil)
 	if bool(fm.Private) && !b.IncludePrivate {
 		rendered = nil
+		withholdPrivateFrontMatter(&fm)
 	}
 
 	tmpl, err := selectTemplate(b.templates, layout, srcPath)
@@ -404,6 +510,17 @@ func (b *Builder) renderMarkdown(srcPath, rel string, children map[string][]chil
 	if err := writePageFile(tmpl, outPath, PageData{FrontMatter: fm, Body: template.HTML(rendered), Tree: tree, Section: section, PrevEntry: prevEntry, NextEntry: nextEntry, Children: listingChildren, DiaryYears: diaryYears, Debug: b.Debug, PageLayout: layout}); err != nil {
 		return fmt.Errorf("render %s: %w", srcPath, err)
 	}
+	b.recordOutput(outPath)
+
+	page, err := os.ReadFile(outPath)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", outPath, err)
+	}
+	b.collectLinks(srcPath, data, outPath, page, pageURL)
+
+	if layout == "listchanges" && b.BaseURL != "" && (!bool(fm.Private) || b.IncludePrivate) {
+		b.feeds = append(b.feeds, changesFeed(tree.Title, pageURL, rendered, b.baseOrigin()))
+	}
 
 	if len(fm.Aliases) > 0 {
 		if err := b.writeAliases(fm.Aliases, b.canonicalURL(outPath)); err != nil {
@@ -446,6 +563,7 @@ func (b *Builder) copyFile(srcPath, rel string) error {
 	if _, err := io.Copy(dst, src); err != nil {
 		return fmt.Errorf("copy %s -> %s: %w", srcPath, outPath, err)
 	}
+	b.recordOutput(outPath)
 	return nil
 }
 
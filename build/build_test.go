@@ -228,6 +228,29 @@ func TestBuildStripsHTMLComments(t *testing.T) {
 	}
 }
 
+func TestBuildEmbedsJSONLD(t *testing.T) {
+	contentDir := t.TempDir()
+	pubDir := t.TempDir()
+
+	mdContent := "---\ntitle: Test\nlayout: single\n" +
+		`jsonld: '{"@context":"https://schema.org","@type":"Person","name":"</script>John"}'` + "\n---\n\nBody.\n"
+	writeFile(t, filepath.Join(contentDir, "test", "index.md"), mdContent)
+
+	b := &Builder{ContentDir: contentDir, PubDir: pubDir}
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	out, err := os.ReadFile(filepath.Join(pubDir, "test", "index.html"))
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	want := `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Person","name":"\u003c/script\u003eJohn"}</script>`
+	if !strings.Contains(string(out), want) {
+		t.Errorf("output does not contain %s:\n%s", want, out)
+	}
+}
+
 func TestBuildUnknownLayoutErrors(t *testing.T) {
 	contentDir := t.TempDir()
 	pubDir := t.TempDir()
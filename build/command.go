@@ -3,6 +3,8 @@ package build
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/iand/genster/logging"
 	"github.com/urfave/cli/v3"
@@ -33,6 +35,17 @@ var Command = &cli.Command{
 			Usage:       "Path to static assets directory (CSS, JS); embedded defaults used if not set",
 			Destination: &buildOpts.assetsDir,
 		},
+		&cli.StringFlag{
+			Name:        "theme",
+			Aliases:     []string{"t"},
+			Usage:       "Path to a theme directory of templates that override individual layouts and partials; embedded templates used for the rest",
+			Destination: &buildOpts.themeDir,
+		},
+		&cli.BoolFlag{
+			Name:        "check",
+			Usage:       "Validate the templates, including any theme, and the layouts used by the content without building the site",
+			Destination: &buildOpts.check,
+		},
 		&cli.StringFlag{
 			Name:        "base-url",
 			Usage:       "Base URL of the site (e.g. https://example.com) used to build absolute URLs in sitemap.xml; sitemap is omitted if not set",
@@ -48,6 +61,41 @@ var Command = &cli.Command{
 			Usage:       "Include body content of pages marked private: yes in the output",
 			Destination: &buildOpts.includePrivate,
 		},
+		&cli.BoolFlag{
+			Name:        "strict-links",
+			Usage:       "Fail the build if any page links to a page or file that is not in the output",
+			Destination: &buildOpts.strictLinks,
+		},
+		&cli.StringFlag{
+			Name:        "family-pub",
+			Usage:       "Path to the output directory of a family-only site that includes private pages; the site written to --pub then omits them",
+			Destination: &buildOpts.familyPubDir,
+		},
+		&cli.StringFlag{
+			Name:        "family-input",
+			Usage:       "Path to the input directory of the family-only site; the --input directory is used if not set",
+			Destination: &buildOpts.familyContentDir,
+		},
+		&cli.StringFlag{
+			Name:        "family-base-url",
+			Usage:       "Base URL of the family-only site used to build absolute URLs in its sitemap and feeds",
+			Destination: &buildOpts.familyBaseURL,
+		},
+		&cli.StringFlag{
+			Name:        "family-auth-file",
+			Usage:       "Restrict the family-only site with basic authentication using the user:password held in this file; writes an .htaccess and password file for Apache. The " + familyAuthEnv + " environment variable may be used instead",
+			Destination: &buildOpts.familyAuthFile,
+		},
+		&cli.StringFlag{
+			Name:        "family-passwd-file",
+			Usage:       "Path to write the password file for basic authentication, which must be outside --family-pub; written next to --family-pub if not set",
+			Destination: &buildOpts.familyPasswdFile,
+		},
+		&cli.StringFlag{
+			Name:        "family-auth-user-file",
+			Usage:       "Path of the password file on the web server, written to the AuthUserFile directive of the .htaccess; the absolute path of the written file is used if not set",
+			Destination: &buildOpts.familyAuthUserFile,
+		},
 		&cli.BoolFlag{
 			Name:        "debug",
 			Usage:       "Add a debug footer to every rendered page",
@@ -60,10 +108,21 @@ var buildOpts struct {
 	contentDir     string
 	pubDir         string
 	assetsDir      string
+	themeDir       string
+	check          bool
 	baseURL        string
 	includeDrafts  bool
 	includePrivate bool
 	debug          bool
+	strictLinks    bool
+
+	familyPubDir     string
+	familyContentDir string
+	familyBaseURL    string
+	familyAuthFile   string
+
+	familyPasswdFile   string
+	familyAuthUserFile string
 }
 
 func buildAction(ctx context.Context, cc *cli.Command) error {
@@ -73,10 +132,46 @@ func buildAction(ctx context.Context, cc *cli.Command) error {
 		ContentDir:     buildOpts.contentDir,
 		PubDir:         buildOpts.pubDir,
 		AssetsDir:      buildOpts.assetsDir,
+		ThemeDir:       buildOpts.themeDir,
 		BaseURL:        buildOpts.baseURL,
 		IncludeDrafts:  buildOpts.includeDrafts,
 		IncludePrivate: buildOpts.includePrivate,
 		Debug:          buildOpts.debug,
+		StrictLinks:    buildOpts.strictLinks,
+	}
+
+	if buildOpts.familyPubDir != "" {
+		b.Family = &FamilyTier{
+			ContentDir: buildOpts.familyContentDir,
+			PubDir:     buildOpts.familyPubDir,
+			BaseURL:    buildOpts.familyBaseURL,
+		}
+		auth, err := familyAuth(buildOpts.familyAuthFile)
+		if err != nil {
+			return err
+		}
+		if auth != "" {
+			user, password, ok := strings.Cut(auth, ":")
+			if !ok || user == "" || password == "" {
+				return fmt.Errorf("family auth must be given as user:password")
+			}
+			b.Family.AuthUser = user
+			b.Family.AuthPassword = password
+			b.Family.PasswdFile = buildOpts.familyPasswdFile
+			b.Family.AuthUserFile = buildOpts.familyAuthUserFile
+		} else if buildOpts.familyPasswdFile != "" || buildOpts.familyAuthUserFile != "" {
+			return fmt.Errorf("--family-auth-file or %s is required when giving a password file", familyAuthEnv)
+		}
+	} else if buildOpts.familyContentDir != "" || buildOpts.familyAuthFile != "" {
+		return fmt.Errorf("--family-pub is required when building a family site")
+	}
+
+	if buildOpts.check {
+		if err := b.Validate(); err != nil {
+			return err
+		}
+		fmt.Println("Templates and layouts are valid")
+		return nil
 	}
 
 	if err := b.Build(); err != nil {
@@ -84,5 +179,28 @@ func buildAction(ctx context.Context, cc *cli.Command) error {
 	}
 
 	fmt.Printf("Site built in %s\n", buildOpts.pubDir)
+	if b.Family != nil {
+		fmt.Printf("Family site built in %s\n", buildOpts.familyPubDir)
+	}
 	return nil
 }
+
+// familyAuthEnv is the environment variable that may hold the user:password
+// for the family site. The password is never accepted on the command line,
+// where it would be visible to other users and kept in shell history.
+const familyAuthEnv = "GENSTER_FAMILY_AUTH"
+
+// familyAuth returns the user:password for the family site, read from the
+// first line of fname if it is given or from the environment otherwise. An
+// empty string is returned when neither is set.
+func familyAuth(fname string) (string, error) {
+	if fname == "" {
+		return os.Getenv(familyAuthEnv), nil
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		return "", fmt.Errorf("read family auth file: %w", err)
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimSpace(line), nil
+}
@@ -47,6 +47,9 @@ var layoutRules = []struct {
 	{"/trees/*/place/*/", "place"},
 	{"/trees/*/citation/*/", "citation"},
 	{"/trees/*/source/*/", "source"},
+	{"/trees/*/person/*/gallery/", layout.PageLayoutGallery},
+	{"/trees/*/family/*/gallery/", layout.PageLayoutGallery},
+	{"/trees/*/source/*/gallery/", layout.PageLayoutGallery},
 
 	// Tree calendar pages — one per month (e.g. /trees/cg/calendar/january/).
 	{"/trees/*/calendar/*/", "calendar"},
@@ -55,6 +58,10 @@ var layoutRules = []struct {
 	{"/trees/*/chart/ancestors/*/", "chartancestors"},
 	{"/trees/*/chart/trees/*/", "charttrees"},
 
+	// Tree statistics pages: the section index and one page per topic.
+	{"/trees/*/statistics/", layout.PageLayoutStatistics},
+	{"/trees/*/statistics/*/", layout.PageLayoutStatistics},
+
 	// Tree list pages.  Each type has two patterns because some types have
 	// both a top-level index page and paginated sub-pages; others have only
 	// one form.  Having both patterns is harmless for types that use only one.
@@ -67,6 +74,8 @@ var layoutRules = []struct {
 	{"/trees/*/list/inferences/", "listinferences"},
 	{"/trees/*/list/inferences/*/", "listinferences"},
 	{"/trees/*/list/todo/*/", "listtodo"},
+	{"/trees/*/list/negative/", "listnegative"},
+	{"/trees/*/list/negative/*/", "listnegative"},
 	{"/trees/*/list/families/*/", "listfamilies"},
 	{"/trees/*/list/familylines/", "listfamilylines"},
 	{"/trees/*/list/familylines/*/", "listfamilylines"},
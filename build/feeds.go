@@ -0,0 +1,313 @@
+package build
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxFeedItems is the maximum number of entries included in a feed.
+const maxFeedItems = 20
+
+// A feed is a list of recent entries for a section of the site, written as
+// both atom.xml and rss.xml in the directory of the page it describes.
+type feed struct {
+	Title       string
+	Description string
+	URL         string // site-root-relative URL of the page the feed describes, e.g. "/diary/"
+	Items       []feedItem
+}
+
+// feedItem is one entry in a feed.
+type feedItem struct {
+	Title   string
+	URL     string // site-root-relative URL of the entry
+	Date    time.Time
+	Summary string // plain text summary
+	Content string // HTML content; used in preference to Summary when set
+}
+
+// changesHeadingRE matches the date headings that divide a rendered change
+// list into one section per day.
+var changesHeadingRE = regexp.MustCompile(`(?s)<h3[^>]*?\bid="([^"]*)"[^>]*>(.*?)</h3>`)
+
+// rootRelativeRE matches href and src attributes whose value is a
+// site-root-relative path.
+var rootRelativeRE = regexp.MustCompile(`(href|src)="(/[^/"][^"]*|/)"`)
+
+// diaryFeed returns a feed of the most recent diary entries, following the
+// diary navigation order.
+func (b *Builder) diaryFeed(children map[string][]childPage, draftDirs map[string]bool) feed {
+	f := feed{
+		Title:       "Research Diary",
+		Description: "Recent entries in the research diary",
+		URL:         "/diary/",
+	}
+	entries := diaryEntries(children)
+	slices.Reverse(entries)
+	for _, cp := range entries {
+		if it, ok := b.childFeedItem(cp, draftDirs); ok {
+			f.Items = append(f.Items, it)
+		}
+	}
+	return f
+}
+
+// storiesFeed returns a feed of the most recently written or updated stories.
+func (b *Builder) storiesFeed(children map[string][]childPage, draftDirs map[string]bool) feed {
+	f := feed{
+		Title:       "Stories",
+		Description: "Recently written or updated stories",
+		URL:         "/stories/",
+	}
+	for _, cp := range children["stories"] {
+		if it, ok := b.childFeedItem(cp, draftDirs); ok {
+			f.Items = append(f.Items, it)
+		}
+	}
+	slices.SortStableFunc(f.Items, func(a, b feedItem) int {
+		return b.Date.Compare(a.Date)
+	})
+	return f
+}
+
+// childFeedItem converts a child page to a feed item. It reports false for
+// pages that should not appear in a feed: drafts, private pages when private
+// content is excluded from the build, and pages without any date.
+func (b *Builder) childFeedItem(cp childPage, draftDirs map[string]bool) (feedItem, bool) {
+	if (bool(cp.FM.Draft) || withinDirs(cp.URL, draftDirs)) && !b.IncludeDrafts {
+		return feedItem{}, false
+	}
+	if bool(cp.FM.Private) && !b.IncludePrivate {
+		return feedItem{}, false
+	}
+
+	var date time.Time
+	for _, s := range []string{cp.FM.Updated, cp.FM.Started, cp.Date, cp.FM.LastMod} {
+		if d, ok := parseFeedDate(s); ok {
+			date = d
+			break
+		}
+	}
+	if date.IsZero() {
+		return feedItem{}, false
+	}
+
+	return feedItem{
+		Title:   cp.Title,
+		URL:     cp.URL,
+		Date:    date,
+		Summary: cp.Summary,
+	}, true
+}
+
+// changesFeed returns a feed for a tree's list of recent changes with one
+// entry per day, taken from the date headings of the rendered page. origin is
+// the scheme+host that root-relative links in the page are made absolute with.
+func changesFeed(title, pageURL string, rendered []byte, origin string) feed {
+	f := feed{
+		Title:       title,
+		Description: "Recent updates to the tree",
+		URL:         pageURL,
+	}
+	if tt := strings.TrimSpace(title); tt != "" {
+		f.Title = tt + ": Recent updates"
+	}
+
+	headings := changesHeadingRE.FindAllSubmatchIndex(rendered, -1)
+	for i, m := range headings {
+		heading := strings.TrimSpace(string(rendered[m[4]:m[5]]))
+		date, err := time.Parse("2 January 2006", heading)
+		if err != nil {
+			continue
+		}
+		end := len(rendered)
+		if i < len(headings)-1 {
+			end = headings[i+1][0]
+		}
+		f.Items = append(f.Items, feedItem{
+			Title:   "Updates on " + heading,
+			URL:     pageURL + "#" + string(rendered[m[2]:m[3]]),
+			Date:    date,
+			Content: absoluteLinks(strings.TrimSpace(string(rendered[m[1]:end])), origin),
+		})
+	}
+	return f
+}
+
+// absoluteLinks rewrites site-root-relative links in h to absolute URLs so
+// they work when the content is read in a feed reader.
+func absoluteLinks(h, baseURL string) string {
+	return rootRelativeRE.ReplaceAllString(h, `$1="`+baseURL+`$2"`)
+}
+
+// parseFeedDate parses a YYYY-MM-DD date or an RFC 3339 timestamp.
+func parseFeedDate(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), true
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// writeFeed writes the Atom and RSS versions of f to the directory of the page
+// it describes and returns the paths written. baseURL must be the scheme+host
+// of the site (e.g. "https://example.com"). Feeds without any items are not
+// written.
+func writeFeed(pubDir, baseURL string, f feed) ([]string, error) {
+	if len(f.Items) == 0 {
+		return nil, nil
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+	if len(f.Items) > maxFeedItems {
+		f.Items = f.Items[:maxFeedItems]
+	}
+
+	dir := filepath.Join(pubDir, filepath.FromSlash(strings.Trim(f.URL, "/")))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("mkdir %s: %w", dir, err)
+	}
+
+	var written []string
+	for _, format := range []struct {
+		name    string
+		marshal func(feed, string) any
+	}{
+		{"atom.xml", atomFeed},
+		{"rss.xml", rssFeed},
+	} {
+		data, err := xml.MarshalIndent(format.marshal(f, baseURL), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s: %w", format.name, err)
+		}
+		outPath := filepath.Join(dir, format.name)
+		if err := os.WriteFile(outPath, append([]byte(xml.Header), data...), 0o644); err != nil {
+			return nil, fmt.Errorf("write %s: %w", outPath, err)
+		}
+		written = append(written, outPath)
+	}
+	return written, nil
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+// atomFeed returns f as an Atom 1.0 document.
+func atomFeed(f feed, baseURL string) any {
+	type entry struct {
+		Title   string    `xml:"title"`
+		Link    atomLink  `xml:"link"`
+		ID      string    `xml:"id"`
+		Updated string    `xml:"updated"`
+		Summary *atomText `xml:"summary,omitempty"`
+		Content *atomText `xml:"content,omitempty"`
+	}
+	type document struct {
+		XMLName  xml.Name   `xml:"feed"`
+		XMLNS    string     `xml:"xmlns,attr"`
+		Title    string     `xml:"title"`
+		Subtitle string     `xml:"subtitle,omitempty"`
+		Links    []atomLink `xml:"link"`
+		ID       string     `xml:"id"`
+		Updated  string     `xml:"updated"`
+		Entries  []entry    `xml:"entry"`
+	}
+
+	doc := document{
+		XMLNS:    "http://www.w3.org/2005/Atom",
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Links: []atomLink{
+			{Href: baseURL + f.URL},
+			{Href: baseURL + f.URL + "atom.xml", Rel: "self", Type: "application/atom+xml"},
+		},
+		ID:      baseURL + f.URL,
+		Updated: f.Items[0].Date.Format(time.RFC3339),
+	}
+	for _, it := range f.Items {
+		e := entry{
+			Title:   it.Title,
+			Link:    atomLink{Href: baseURL + it.URL},
+			ID:      baseURL + it.URL,
+			Updated: it.Date.Format(time.RFC3339),
+		}
+		if it.Content != "" {
+			e.Content = &atomText{Type: "html", Body: it.Content}
+		} else if it.Summary != "" {
+			e.Summary = &atomText{Body: it.Summary}
+		}
+		doc.Entries = append(doc.Entries, e)
+	}
+	return doc
+}
+
+// rssFeed returns f as an RSS 2.0 document.
+func rssFeed(f feed, baseURL string) any {
+	type guid struct {
+		IsPermaLink string `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	}
+	type item struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		GUID        guid   `xml:"guid"`
+		PubDate     string `xml:"pubDate"`
+		Description string `xml:"description,omitempty"`
+	}
+	type channel struct {
+		Title         string   `xml:"title"`
+		Link          string   `xml:"link"`
+		Description   string   `xml:"description"`
+		AtomLink      atomLink `xml:"http://www.w3.org/2005/Atom link"`
+		LastBuildDate string   `xml:"lastBuildDate"`
+		Items         []item   `xml:"item"`
+	}
+	type document struct {
+		XMLName xml.Name `xml:"rss"`
+		Version string   `xml:"version,attr"`
+		Channel channel  `xml:"channel"`
+	}
+
+	doc := document{
+		Version: "2.0",
+		Channel: channel{
+			Title:         f.Title,
+			Link:          baseURL + f.URL,
+			Description:   f.Description,
+			AtomLink:      atomLink{Href: baseURL + f.URL + "rss.xml", Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: f.Items[0].Date.Format(time.RFC1123Z),
+		},
+	}
+	for _, it := range f.Items {
+		desc := it.Content
+		if desc == "" {
+			desc = it.Summary
+		}
+		doc.Channel.Items = append(doc.Channel.Items, item{
+			Title:       it.Title,
+			Link:        baseURL + it.URL,
+			GUID:        guid{IsPermaLink: "true", Value: baseURL + it.URL},
+			PubDate:     it.Date.Format(time.RFC1123Z),
+			Description: desc,
+		})
+	}
+	return doc
+}
@@ -0,0 +1,95 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildFeeds(t *testing.T) {
+	contentDir := t.TempDir()
+	pubDir := t.TempDir()
+
+	writeFile(t, filepath.Join(contentDir, "diary", "2024", "2024-01-05.md"),
+		"---\ntitle: Found the baptism\nsummary: Baptism of John in Fressingfield\n---\n\n<p>entry</p>\n")
+	writeFile(t, filepath.Join(contentDir, "diary", "2024", "2024-02-09.md"),
+		"---\ntitle: Census search\n---\n\n<p>entry</p>\n")
+	writeFile(t, filepath.Join(contentDir, "diary", "2024", "2024-03-01.md"),
+		"---\ntitle: Private notes\nprivate: true\n---\n\n<p>entry</p>\n")
+	writeFile(t, filepath.Join(contentDir, "diary", "2024", "2024-03-02.md"),
+		"---\ntitle: Unfinished\ndraft: true\n---\n\n<p>entry</p>\n")
+	writeFile(t, filepath.Join(contentDir, "stories", "suffolk.md"),
+		"---\ntitle: Suffolk Story\nstarted: \"2023-06-01\"\nupdated: \"2024-04-01\"\n---\n\n<p>content</p>\n")
+	writeFile(t, filepath.Join(contentDir, "trees", "at", "index.md"),
+		"---\ntitle: Alcock Tree\nlayout: treeoverview\nbasepath: /trees/at/\n---\n\n<p>overview</p>\n")
+	writeFile(t, filepath.Join(contentDir, "trees", "at", "list", "changes", "index.md"),
+		"---\ntitle: Recent updates\nlayout: listchanges\nbasepath: /trees/at/\n---\n\n"+
+			"### 7 March 2024\n\nAdded birth for [John Alcock](/trees/at/person/I1/).\n\n"+
+			"### 2 March 2024\n\nAdded a new place: [Fressingfield](/trees/at/place/P1/).\n")
+
+	b := &Builder{ContentDir: contentDir, PubDir: pubDir, BaseURL: "https://example.com/"}
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	read := func(rel string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(pubDir, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatalf("read %s: %v", rel, err)
+		}
+		return string(data)
+	}
+
+	diary := read("diary/atom.xml")
+	if !strings.Contains(diary, `<link href="https://example.com/diary/2024/2024-01-05/"></link>`) {
+		t.Errorf("diary atom feed missing absolute entry link:\n%s", diary)
+	}
+	if !strings.Contains(diary, "<summary>Baptism of John in Fressingfield</summary>") {
+		t.Errorf("diary atom feed missing entry summary")
+	}
+	if strings.Index(diary, "Census search") > strings.Index(diary, "Found the baptism") {
+		t.Errorf("diary atom feed entries should be newest first")
+	}
+	if strings.Contains(diary, "Private notes") || strings.Contains(diary, "Unfinished") {
+		t.Errorf("diary atom feed should exclude private and draft entries")
+	}
+	if rss := read("diary/rss.xml"); !strings.Contains(rss, "<pubDate>Fri, 09 Feb 2024 00:00:00 +0000</pubDate>") {
+		t.Errorf("diary rss feed missing entry date:\n%s", rss)
+	}
+
+	if stories := read("stories/rss.xml"); !strings.Contains(stories, "<title>Suffolk Story</title>") || !strings.Contains(stories, "01 Apr 2024") {
+		t.Errorf("stories rss feed missing updated story:\n%s", stories)
+	}
+
+	changes := read("trees/at/list/changes/atom.xml")
+	if !strings.Contains(changes, "<title>Alcock Tree: Recent updates</title>") {
+		t.Errorf("changes feed missing tree title:\n%s", changes)
+	}
+	if got := strings.Count(changes, "<entry>"); got != 2 {
+		t.Errorf("got %d change feed entries, wanted one per day", got)
+	}
+	if !strings.Contains(changes, "<title>Updates on 7 March 2024</title>") || !strings.Contains(changes, "2024-03-07T00:00:00Z") {
+		t.Errorf("changes feed missing dated entry:\n%s", changes)
+	}
+	if !strings.Contains(changes, "href=&#34;https://example.com/trees/at/person/I1/&#34;") {
+		t.Errorf("changes feed content links should be absolute:\n%s", changes)
+	}
+}
+
+func TestBuildFeedsOmittedWithoutBaseURL(t *testing.T) {
+	contentDir := t.TempDir()
+	pubDir := t.TempDir()
+
+	writeFile(t, filepath.Join(contentDir, "diary", "2024", "2024-01-05.md"),
+		"---\ntitle: Found the baptism\n---\n\n<p>entry</p>\n")
+
+	b := &Builder{ContentDir: contentDir, PubDir: pubDir}
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(pubDir, "diary", "atom.xml")); err == nil {
+		t.Errorf("atom.xml should not be written without a base URL")
+	}
+}
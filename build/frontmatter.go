@@ -99,8 +99,14 @@ type FrontMatter struct {
 	// Calendar-specific
 	Month string `yaml:"month"`
 
+	// JSONLD is a schema.org description of the page subject, encoded as
+	// JSON-LD by gen for person and place pages.
+	JSONLD string `yaml:"jsonld"`
+
 	// Question/story-specific
 	People     flexStrings         `yaml:"people"`
+	Places     flexStrings         `yaml:"places"`
+	Sources    flexStrings         `yaml:"sources"`
 	Author     string              `yaml:"author"`
 	Started    string              `yaml:"started"`
 	Updated    string              `yaml:"updated"`
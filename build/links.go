@@ -0,0 +1,244 @@
+package build
+
+import (
+	"bytes"
+	"html"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/iand/genster/logging"
+)
+
+// linkAttrRE matches the href attribute of anchors and the src attribute of
+// images in rendered HTML.
+var linkAttrRE = regexp.MustCompile(`(?is)<(?:a\s[^>]*?\bhref|img\s[^>]*?\bsrc)\s*=\s*"([^"]*)"`)
+
+// A pageLink is an internal link found in a rendered page.
+type pageLink struct {
+	File   string // file the link was found in: the content source if the link appears there, otherwise the rendered page
+	Line   int    // line of File containing the link
+	Href   string // link as written
+	Target string // site path the link resolves to
+	Page   string // rendered page containing the link
+	raw    string // attribute value as it appears in the rendered page
+
+	// Reason, if not empty, says why the link can never resolve whatever is
+	// written during the build.
+	Reason string
+
+	// Template is true if the link was added by the layout template rather
+	// than written in the content source.
+	Template bool
+}
+
+// recordOutput notes that a file has been written to outPath so that links to
+// it can be resolved.
+func (b *Builder) recordOutput(outPath string) {
+	if b.outputs == nil {
+		b.outputs = make(map[string]bool)
+	}
+	rel, err := filepath.Rel(b.PubDir, outPath)
+	if err != nil {
+		return
+	}
+	b.outputs["/"+filepath.ToSlash(rel)] = true
+}
+
+// collectLinks finds the anchors and image sources in a rendered page and
+// records each internal link for checking once the build is complete. src is
+// the content of the source file the page was rendered from and is used to
+// locate the line on which a link was written.
+func (b *Builder) collectLinks(srcPath string, src []byte, outPath string, rendered []byte, pageURL string) {
+	srcLines := bytes.Split(src, []byte("\n"))
+	seen := make(map[string]bool)
+	for _, m := range linkAttrRE.FindAllSubmatchIndex(rendered, -1) {
+		href := html.UnescapeString(string(rendered[m[2]:m[3]]))
+		target, reason, ok := b.resolveLink(pageURL, href)
+		if !ok || seen[href] {
+			continue
+		}
+		seen[href] = true
+
+		pl := pageLink{Href: href, Target: target, Page: outPath, raw: string(rendered[m[2]:m[3]]), Reason: reason}
+		if line := findLine(srcLines, href); line > 0 {
+			pl.File = srcPath
+			pl.Line = line
+		} else {
+			pl.Template = true
+			pl.File = outPath
+			pl.Line = bytes.Count(rendered[:m[0]], []byte("\n")) + 1
+		}
+		b.links = append(b.links, pl)
+	}
+}
+
+// resolveLink converts a link found on the page at pageURL into the site path
+// it refers to. Root-relative links are taken to include the path of BaseURL,
+// which is removed, so "/family/person/I1/" is the site path "/person/I1/" of
+// a site at https://example.org/family/. A root-relative link outside the
+// path of BaseURL cannot reach a page of the site, so it is returned unchanged
+// with the reason it will dangle. It reports false for links that are
+// external, including absolute links to other paths on the same host, and for
+// links that only refer to a fragment of the current page.
+func (b *Builder) resolveLink(pageURL, href string) (target string, reason string, ok bool) {
+	href = strings.TrimSpace(href)
+	absolute := false
+	if origin := b.baseOrigin(); origin != "" {
+		if rest, ok := strings.CutPrefix(href, origin); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			href = "/" + strings.TrimPrefix(rest, "/")
+			absolute = true
+		}
+	}
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "//") {
+		return "", "", false
+	}
+
+	u, err := url.Parse(href)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return "", "", false
+	}
+
+	p := u.Path
+	if strings.HasPrefix(p, "/") {
+		sp, ok := b.sitePath(p)
+		if !ok {
+			if absolute {
+				return "", "", false
+			}
+			return p, "outside the site path", true
+		}
+		return sp, "", true
+	}
+	p = path.Join(pageURL, p)
+	if strings.HasSuffix(u.Path, "/") {
+		p += "/"
+	}
+	return p, "", true
+}
+
+// baseOrigin returns the scheme and host of BaseURL, such as
+// "https://example.org", or an empty string if there is no BaseURL.
+func (b *Builder) baseOrigin() string {
+	u, err := url.Parse(b.BaseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// sitePath returns the site path of the root-relative path p on the web
+// server by removing the path of BaseURL. It reports false if p lies outside
+// the path of BaseURL.
+func (b *Builder) sitePath(p string) (string, bool) {
+	u, err := url.Parse(b.BaseURL)
+	if err != nil {
+		return p, true
+	}
+	base := strings.TrimSuffix(u.Path, "/")
+	if base == "" {
+		return p, true
+	}
+	if p == base {
+		return "/", true
+	}
+	rest, ok := strings.CutPrefix(p, base+"/")
+	if !ok {
+		return "", false
+	}
+	return "/" + rest, true
+}
+
+// linkResolves reports whether target names a file written during the build
+// or an alias of a page.
+func (b *Builder) linkResolves(target string) bool {
+	if _, ok := b.aliasIndex[strings.TrimSuffix(target, "/")]; ok {
+		return true
+	}
+	if strings.HasSuffix(target, "/") {
+		return b.outputs[target+"index.html"]
+	}
+	return b.outputs[target] || b.outputs[target+"/index.html"]
+}
+
+// checkSubjectAliases warns about entries in the people and places
+// front-matter fields of the page at srcPath that are not the alias of any
+// page. When building a public site an alias of a family-only page is not
+// reported. Sources are not published as pages so the sources field is checked
+// by gen instead.
+func (b *Builder) checkSubjectAliases(srcPath string, fm FrontMatter) {
+	for _, f := range []struct {
+		name    string
+		aliases flexStrings
+	}{
+		{"people", fm.People},
+		{"places", fm.Places},
+	} {
+		for _, alias := range f.aliases {
+			alias = strings.TrimSuffix(alias, "/")
+			if _, ok := b.aliasIndex[alias]; ok {
+				continue
+			}
+			if b.family != nil {
+				if _, ok := b.family.aliasIndex[alias]; ok {
+					continue
+				}
+			}
+			logging.Warn("unknown alias in front matter", "file", srcPath, "field", f.name, "alias", alias)
+		}
+	}
+}
+
+// checkLinks reports every internal link that does not resolve to a page or
+// file in the output and returns the number found. Links added by a layout
+// template are usually repeated on many pages so each target is only reported
+// once, against the first page it was found on.
+func (b *Builder) checkLinks() int {
+	dangling := 0
+	reported := make(map[string]bool)
+	for _, pl := range b.links {
+		if pl.Reason == "" && b.linkResolves(pl.Target) {
+			continue
+		}
+		dangling++
+		if pl.Template {
+			if reported[pl.Target] {
+				continue
+			}
+			reported[pl.Target] = true
+		}
+		if pl.Reason != "" {
+			logging.Warn("dangling link", "file", pl.File, "line", pl.Line, "href", pl.Href, "reason", pl.Reason)
+			continue
+		}
+		logging.Warn("dangling link", "file", pl.File, "line", pl.Line, "href", pl.Href)
+	}
+	return dangling
+}
+
+// findLine returns the 1-based number of the first line containing href as a
+// markdown or HTML link destination or as an unquoted front-matter value, or 0
+// if no line contains it.
+func findLine(lines [][]byte, href string) int {
+	forms := [][]byte{
+		[]byte("(" + href + ")"),
+		[]byte("(" + href + " "),
+		[]byte("<" + href + ">"),
+		[]byte(`"` + href + `"`),
+		[]byte("'" + href + "'"),
+	}
+	value := []byte(" " + href)
+	for i, l := range lines {
+		if bytes.HasSuffix(bytes.TrimRight(l, " \r"), value) {
+			return i + 1
+		}
+		for _, f := range forms {
+			if bytes.Contains(l, f) {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+package build
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildDanglingLinks(t *testing.T) {
+	contentDir := t.TempDir()
+	pubDir := t.TempDir()
+
+	writeFile(t, filepath.Join(contentDir, "person", "I1", "index.md"),
+		"---\ntitle: Person I1\nlayout: single\naliases:\n  - /r/john\n---\n\n"+
+			"See [himself](/r/john) and [his photo](/media/photo.jpg).\n\n"+
+			"His father was [unknown](/person/I2/).\n\n"+
+			"![A missing image](scan.jpg)\n")
+	writeFile(t, filepath.Join(contentDir, "person", "I3", "index.md"),
+		"---\ntitle: Person I3\nlayout: single\n---\n\n"+
+			"Related to [John](/r/john/), [Jane](/r/jane) and [I1](../I1/#family).\n")
+	writeFile(t, filepath.Join(contentDir, "media", "photo.jpg"), "fake jpeg data")
+
+	b := &Builder{ContentDir: contentDir, PubDir: pubDir, BaseURL: "https://example.com"}
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	var got []string
+	for _, pl := range b.links {
+		if pl.Template || b.linkResolves(pl.Target) {
+			continue
+		}
+		rel, _ := filepath.Rel(contentDir, pl.File)
+		got = append(got, fmt.Sprintf("%s:%d %s", filepath.ToSlash(rel), pl.Line, pl.Href))
+	}
+	want := []string{
+		"person/I1/index.md:10 /person/I2/",
+		"person/I1/index.md:12 scan.jpg",
+		"person/I3/index.md:6 /r/jane",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got dangling links:\n%s\nwanted:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	b = &Builder{ContentDir: contentDir, PubDir: t.TempDir(), StrictLinks: true}
+	if err := b.Build(); err == nil || !strings.Contains(err.Error(), "dangling links") {
+		t.Errorf("got error %v, wanted dangling links error with strict links", err)
+	}
+}
+
+func TestResolveLink(t *testing.T) {
+	b := &Builder{BaseURL: "https://example.com/"}
+	for _, tt := range []struct {
+		href string
+		want string // empty if the link should not be checked
+	}{
+		{"/r/I0021", "/r/I0021"},
+		{"/person/I1/#family", "/person/I1/"},
+		{"../I2/", "/trees/t/person/I2/"},
+		{"scan.jpg", "/trees/t/person/I1/scan.jpg"},
+		{"https://example.com/diary/", "/diary/"},
+		{"https://example.org/diary/", ""},
+		{"mailto:someone@example.com", ""},
+		{"#notes", ""},
+		{"//cdn.example.com/x.js", ""},
+	} {
+		got, reason, ok := b.resolveLink("/trees/t/person/I1/", tt.href)
+		if !ok {
+			got = ""
+		}
+		if got != tt.want {
+			t.Errorf("resolveLink(%q): got %q, wanted %q", tt.href, got, tt.want)
+		}
+		if reason != "" {
+			t.Errorf("resolveLink(%q): got reason %q, wanted none", tt.href, reason)
+		}
+	}
+}
+
+func TestResolveLinkBasePath(t *testing.T) {
+	b := &Builder{BaseURL: "https://example.org/family/"}
+	for _, tt := range []struct {
+		href       string
+		want       string // empty if the link should not be checked
+		wantReason string // empty if the link may resolve
+	}{
+		{href: "/family/r/I0021", want: "/r/I0021"},
+		{href: "/family/person/I1/#family", want: "/person/I1/"},
+		{href: "/family/", want: "/"},
+		{href: "/family", want: "/"},
+		{href: "../I2/", want: "/person/I2/"},
+		{href: "https://example.org/family/diary/", want: "/diary/"},
+		{href: "https://example.org/family", want: "/"},
+		{href: "https://example.org/diary/", want: ""},
+		{href: "https://example.org/familyhistory/", want: ""},
+		{href: "/diary/", want: "/diary/", wantReason: "outside the site path"},
+		{href: "/familyhistory/", want: "/familyhistory/", wantReason: "outside the site path"},
+	} {
+		got, reason, ok := b.resolveLink("/person/I1/", tt.href)
+		if !ok {
+			got = ""
+		}
+		if got != tt.want {
+			t.Errorf("resolveLink(%q): got %q, wanted %q", tt.href, got, tt.want)
+		}
+		if reason != tt.wantReason {
+			t.Errorf("resolveLink(%q): got reason %q, wanted %q", tt.href, reason, tt.wantReason)
+		}
+	}
+}
+
+func TestBuildLinksOutsideSitePath(t *testing.T) {
+	contentDir := t.TempDir()
+	writeFile(t, filepath.Join(contentDir, "diary", "index.md"),
+		"---\ntitle: Diary\nlayout: single\n---\n\n"+
+			"See [the diary](/family/diary/) and [the old diary](/diary/).\n")
+
+	b := &Builder{ContentDir: contentDir, PubDir: t.TempDir(), BaseURL: "https://example.org/family/", StrictLinks: true}
+	if err := b.Build(); err == nil || !strings.Contains(err.Error(), "dangling links") {
+		t.Errorf("got error %v, wanted dangling links error", err)
+	}
+
+	var got []string
+	for _, pl := range b.links {
+		if !pl.Template && pl.Reason != "" {
+			got = append(got, pl.Href+": "+pl.Reason)
+		}
+	}
+	want := []string{"/diary/: outside the site path"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got links outside the site path:\n%s\nwanted:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -230,11 +230,46 @@ func dateFromStem(stem string) string {
 	return ""
 }
 
-// buildDiaryNav collects all diary leaf entries (children of diary/YYYY
-// directories), sorts them chronologically ascending, and returns a map from
-// each entry's canonical URL to its [prev, next] NavEntry pair.  Entries with
-// no date sort last.  A zero NavEntry means no adjacent entry exists.
+// withinDirs reports whether the page or file at url lies within one of dirs,
+// which are content-relative and slash-separated.
+func withinDirs(url string, dirs map[string]bool) bool {
+	dir := strings.Trim(url, "/")
+	for dir != "." && dir != "" {
+		if dirs[dir] {
+			return true
+		}
+		dir = filepath.ToSlash(filepath.Dir(dir))
+	}
+	return false
+}
+
+// buildDiaryNav returns a map from each diary entry's canonical URL to its
+// [prev, next] NavEntry pair, following the chronological order given by
+// diaryEntries.  A zero NavEntry means no adjacent entry exists.
 func buildDiaryNav(children map[string][]childPage) map[string][2]NavEntry {
+	entries := diaryEntries(children)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	nav := make(map[string][2]NavEntry, len(entries))
+	for i, e := range entries {
+		var prev, next NavEntry
+		if i > 0 {
+			prev = NavEntry{URL: entries[i-1].URL, Title: entries[i-1].Title}
+		}
+		if i < len(entries)-1 {
+			next = NavEntry{URL: entries[i+1].URL, Title: entries[i+1].Title}
+		}
+		nav[e.URL] = [2]NavEntry{prev, next}
+	}
+	return nav
+}
+
+// diaryEntries collects all diary leaf entries (children of diary/YYYY
+// directories) and sorts them chronologically ascending.  Entries with no
+// date sort last.
+func diaryEntries(children map[string][]childPage) []childPage {
 	var entries []childPage
 	for key, pages := range children {
 		parts := strings.Split(key, "/")
@@ -242,11 +277,7 @@ func buildDiaryNav(children map[string][]childPage) map[string][2]NavEntry {
 			entries = append(entries, pages...)
 		}
 	}
-	if len(entries) == 0 {
-		return nil
-	}
 
-	// Sort ascending by date so prev=older, next=newer.
 	slices.SortFunc(entries, func(a, b childPage) int {
 		if a.Date == b.Date {
 			return strings.Compare(a.Title, b.Title)
@@ -259,19 +290,7 @@ func buildDiaryNav(children map[string][]childPage) map[string][2]NavEntry {
 		}
 		return strings.Compare(a.Date, b.Date)
 	})
-
-	nav := make(map[string][2]NavEntry, len(entries))
-	for i, e := range entries {
-		var prev, next NavEntry
-		if i > 0 {
-			prev = NavEntry{URL: entries[i-1].URL, Title: entries[i-1].Title}
-		}
-		if i < len(entries)-1 {
-			next = NavEntry{URL: entries[i+1].URL, Title: entries[i+1].Title}
-		}
-		nav[e.URL] = [2]NavEntry{prev, next}
-	}
-	return nav
+	return entries
 }
 
 // recentDiaryEntries collects all diary leaf entries across all diary year
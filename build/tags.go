@@ -55,16 +55,19 @@ func (b *Builder) writeTags(tagIndex map[string][]pageRef) error {
 		}); err != nil {
 			return fmt.Errorf("tag page %q: %w", tag, err)
 		}
+		b.recordOutput(outPath)
 	}
 
 	// Write the tags index using the dedicated tagsindex template which
 	// includes sidebar text explaining how tags work.
-	if err := writePageFile(indexTmpl, filepath.Join(b.PubDir, "tags", "index.html"), PageData{
+	indexPath := filepath.Join(b.PubDir, "tags", "index.html")
+	if err := writePageFile(indexTmpl, indexPath, PageData{
 		FrontMatter: FrontMatter{Title: "Tags"},
 		Body:        tagIndexBody(tags, tagIndex),
 	}); err != nil {
 		return fmt.Errorf("tags index: %w", err)
 	}
+	b.recordOutput(indexPath)
 
 	return nil
 }
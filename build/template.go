@@ -1,7 +1,9 @@
 package build
 
 import (
+	"bytes"
 	"embed"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"strings"
@@ -28,7 +30,9 @@ func urlize(s string) string {
 // SelectFeatureImage to resolve the best available image for each page.
 // aliasIndex maps redirect paths (e.g. "/r/I0021") to the childPage for the
 // page that declares that alias; it is closed over by the personByAlias func.
-func buildSiteTemplates(imageDir string, aliasIndex map[string]childPage) *template.Template {
+// themeDir, if non-empty, is a directory of templates that override the
+// embedded ones (see parseTheme).
+func buildSiteTemplates(imageDir string, aliasIndex map[string]childPage, themeDir string) (*template.Template, error) {
 	funcs := template.FuncMap{
 		"urlize": urlize,
 		// list returns its arguments as a []any slice, allowing templates to
@@ -44,6 +48,17 @@ func buildSiteTemplates(imageDir string, aliasIndex map[string]childPage) *templ
 			}
 			return s
 		},
+		// jsonld returns a JSON-LD front-matter value for embedding in a
+		// script element, escaping characters that could end the element
+		// early. Invalid JSON is dropped rather than emitted.
+		"jsonld": func(s string) template.JS {
+			if !json.Valid([]byte(s)) {
+				return ""
+			}
+			var buf bytes.Buffer
+			json.HTMLEscape(&buf, []byte(s))
+			return template.JS(buf.String())
+		},
 		// featureImageSrc resolves the best available feature image URL for a
 		// page based on its front-matter. It returns an empty string when no
 		// suitable image is found so the template can suppress the <img> tag.
@@ -94,7 +109,14 @@ func buildSiteTemplates(imageDir string, aliasIndex map[string]childPage) *templ
 			}
 		},
 	}
-	return template.Must(template.New("").Funcs(funcs).ParseFS(templateFS, "templates/*.html"))
+	tmpls, err := template.New("").Funcs(funcs).ParseFS(templateFS, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("parse templates: %w", err)
+	}
+	if themeDir != "" {
+		return parseTheme(tmpls, themeDir)
+	}
+	return tmpls, nil
 }
 
 // knownLayouts is the set of layout values that genster and manual content
@@ -114,6 +136,8 @@ var knownLayouts = map[string]bool{
 	layout.PageLayoutListAnomalies.String():   true,
 	layout.PageLayoutListInferences.String():  true,
 	layout.PageLayoutListTodo.String():        true,
+	layout.PageLayoutListNegative.String():    true,
+	layout.PageLayoutGallery.String():         true,
 	layout.PageLayoutListFamilies.String():    true,
 	layout.PageLayoutListFamilyLines.String(): true,
 	layout.PageLayoutListTrees.String():       true,
@@ -122,6 +146,7 @@ var knownLayouts = map[string]bool{
 	layout.PageLayoutChartAncestors.String():  true,
 	layout.PageLayoutTreeOverview.String():    true,
 	layout.PageLayoutChartTrees.String():      true,
+	layout.PageLayoutStatistics.String():      true,
 	// Manual content layouts (not generated by the site package).
 	"home":          true,
 	"diaryhome":     true,
@@ -146,17 +171,21 @@ func selectTemplate(tmpls *template.Template, layout, srcPath string) (*template
 		return nil, fmt.Errorf("unknown layout %q in %s", layout, srcPath)
 	}
 
-	// Map the empty layout to the "plain" template.
-	name := layout
-	if name == "" {
-		name = "plain"
-	}
-
-	tmpl := tmpls.Lookup(name)
+	tmpl := tmpls.Lookup(templateName(layout))
 	if tmpl == nil {
-		// All knownLayouts should have matching templates; this indicates a
-		// programming error (template file missing or define block misspelled).
+		// All knownLayouts should have matching templates and validateTemplates
+		// checks this before a build starts; this indicates a programming error
+		// (template file missing or define block misspelled).
 		return nil, fmt.Errorf("no template found for layout %q in %s", layout, srcPath)
 	}
 	return tmpl, nil
 }
+
+// templateName returns the name of the template that renders layout. Pages
+// without a layout use the "plain" template.
+func templateName(layout string) string {
+	if layout == "" {
+		return "plain"
+	}
+	return layout
+}
@@ -0,0 +1,185 @@
+package build
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template/parse"
+)
+
+// parseTheme adds the templates of the theme in themeDir to tmpls. A theme is
+// a directory of .html files that use the same {{define "name"}} blocks as the
+// embedded templates. A block defined by a theme replaces the embedded block
+// of the same name and every other block falls back to the embedded one, so a
+// theme may override a whole layout, such as "person" or "place", or only a
+// partial, such as "head", "footer" or "featureimage". Every layout template
+// is executed with a PageData value.
+func parseTheme(tmpls *template.Template, themeDir string) (*template.Template, error) {
+	fsys := os.DirFS(themeDir)
+	files, err := fs.Glob(fsys, "*.html")
+	if err != nil {
+		return nil, fmt.Errorf("list theme templates: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no templates found in theme %s", themeDir)
+	}
+	tmpls, err = tmpls.ParseFS(fsys, "*.html")
+	if err != nil {
+		return nil, fmt.Errorf("parse theme %s: %w", themeDir, err)
+	}
+	return tmpls, nil
+}
+
+// loadTemplates creates the template set for a build, applying the theme if
+// one is set, and checks it together with the layouts used by the content
+// pages. Every problem found is reported in the returned error so that a
+// theme or content directory can be fixed in one pass rather than one build
+// failure at a time.
+func (b *Builder) loadTemplates(aliasIndex map[string]childPage, draftDirs map[string]bool) (*template.Template, error) {
+	tmpls, err := buildSiteTemplates(filepath.Join(b.ContentDir, "images"), aliasIndex, b.ThemeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := validateTemplates(tmpls)
+	errs = append(errs, b.validateLayouts(tmpls, draftDirs)...)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("found %d template problems: %w", len(errs), errors.Join(errs...))
+	}
+	return tmpls, nil
+}
+
+// Validate checks the templates, including any theme, and the layouts used by
+// the content pages without building the site.
+func (b *Builder) Validate() error {
+	_, _, _, draftDirs, aliasIndex, err := collectChildren(b.ContentDir, b.IncludeDrafts)
+	if err != nil {
+		return fmt.Errorf("collect children: %w", err)
+	}
+	_, err = b.loadTemplates(aliasIndex, draftDirs)
+	return err
+}
+
+// validateTemplates checks that every known layout has a template, that every
+// template invoked with {{template}} is defined and that every layout can be
+// executed with an empty PageData, which catches references to fields that
+// PageData does not have.
+func validateTemplates(tmpls *template.Template) []error {
+	var errs []error
+
+	// tagsindex is not a page layout but is rendered by writeTags
+	layouts := []string{"tagsindex"}
+	for l := range knownLayouts {
+		layouts = append(layouts, templateName(l))
+	}
+	sort.Strings(layouts)
+	for _, name := range layouts {
+		if tmpls.Lookup(name) == nil {
+			errs = append(errs, fmt.Errorf("no template defined for layout %q", name))
+		}
+	}
+
+	var names []string
+	for _, t := range tmpls.Templates() {
+		names = append(names, t.Name())
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t := tmpls.Lookup(name)
+		if t.Tree == nil {
+			continue
+		}
+		for _, ref := range templateRefs(t.Tree.Root) {
+			if tmpls.Lookup(ref) == nil {
+				errs = append(errs, fmt.Errorf("template %q uses undefined template %q", name, ref))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for _, name := range layouts {
+		if err := tmpls.ExecuteTemplate(io.Discard, name, PageData{}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// templateRefs returns the names of the templates invoked within n.
+func templateRefs(n parse.Node) []string {
+	var refs []string
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, c := range n.Nodes {
+			refs = append(refs, templateRefs(c)...)
+		}
+	case *parse.TemplateNode:
+		refs = append(refs, n.Name)
+	case *parse.IfNode:
+		refs = append(refs, templateRefs(n.List)...)
+		refs = append(refs, templateRefs(n.ElseList)...)
+	case *parse.RangeNode:
+		refs = append(refs, templateRefs(n.List)...)
+		refs = append(refs, templateRefs(n.ElseList)...)
+	case *parse.WithNode:
+		refs = append(refs, templateRefs(n.List)...)
+		refs = append(refs, templateRefs(n.ElseList)...)
+	}
+	return refs
+}
+
+// validateLayouts checks the layout of every content page that would be
+// rendered, returning an error for each page whose layout is unknown.
+func (b *Builder) validateLayouts(tmpls *template.Template, draftDirs map[string]bool) []error {
+	var errs []error
+	err := filepath.WalkDir(b.ContentDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(b.ContentDir, path)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") || draftDirs[filepath.ToSlash(rel)] {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		fm, _, err := ParseDocument(string(data))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("parse %s: %w", path, err))
+			return nil
+		}
+		if bool(fm.Draft) && !b.IncludeDrafts {
+			return nil
+		}
+		if _, err := selectTemplate(tmpls, resolveLayout(pageURLFor(rel), fm.Layout), path); err != nil {
+			errs = append(errs, err)
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,130 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildThemeOverridesPartial(t *testing.T) {
+	contentDir := t.TempDir()
+	themeDir := t.TempDir()
+	pubDir := t.TempDir()
+
+	writeFile(t, filepath.Join(contentDir, "about", "index.md"),
+		"---\ntitle: About\nlayout: single\n---\n\nA story.\n")
+	writeFile(t, filepath.Join(themeDir, "footer.html"),
+		`{{define "footer"}}<footer class="themed">{{.Title}} from the theme</footer>{{end}}`)
+
+	b := &Builder{ContentDir: contentDir, PubDir: pubDir, ThemeDir: themeDir}
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(pubDir, "about", "index.html"))
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if !strings.Contains(string(got), `<footer class="themed">About from the theme</footer>`) {
+		t.Errorf("page does not use the theme footer:\n%s", got)
+	}
+	if !strings.Contains(string(got), `<h1 data-pagefind-meta="title">About</h1>`) {
+		t.Errorf("page does not use the embedded single layout:\n%s", got)
+	}
+}
+
+func TestBuildThemeOverridesLayout(t *testing.T) {
+	contentDir := t.TempDir()
+	themeDir := t.TempDir()
+	pubDir := t.TempDir()
+
+	writeFile(t, filepath.Join(contentDir, "about", "index.md"),
+		"---\ntitle: About\nlayout: single\n---\n\nA story.\n")
+	writeFile(t, filepath.Join(themeDir, "single.html"),
+		`{{define "single"}}<article>{{.Title}}: {{.Body}}</article>{{end}}`)
+
+	b := &Builder{ContentDir: contentDir, PubDir: pubDir, ThemeDir: themeDir}
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(pubDir, "about", "index.html"))
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if want := "<article>About: <p>A story.</p>\n</article>"; string(got) != want {
+		t.Errorf("got page %q, wanted %q", got, want)
+	}
+}
+
+func TestValidateTheme(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		theme string
+		want  []string // substrings expected in the error
+	}{
+		{
+			name:  "parse error",
+			theme: `{{define "footer"}}{{if .Title}}<footer>{{end}}`,
+			want:  []string{"theme.html"},
+		},
+		{
+			name:  "undefined template",
+			theme: `{{define "footer"}}{{template "copyright" .}}{{end}}`,
+			want:  []string{`template "footer" uses undefined template "copyright"`},
+		},
+		{
+			name:  "unknown field",
+			theme: `{{define "footer"}}{{.Copyright}}{{end}}`,
+			want:  []string{"Copyright"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			themeDir := t.TempDir()
+			writeFile(t, filepath.Join(themeDir, "theme.html"), tt.theme)
+
+			b := &Builder{ContentDir: t.TempDir(), PubDir: t.TempDir(), ThemeDir: themeDir}
+			err := b.Validate()
+			if err == nil {
+				t.Fatalf("got no error, wanted one containing %q", tt.want)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Errorf("error %q does not contain %q", err, w)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateReportsAllUnknownLayouts(t *testing.T) {
+	contentDir := t.TempDir()
+	pubDir := t.TempDir()
+
+	writeFile(t, filepath.Join(contentDir, "odd", "index.md"), "---\ntitle: Odd\nlayout: oddlayout\n---\n")
+	writeFile(t, filepath.Join(contentDir, "strange", "index.md"), "---\ntitle: Strange\nlayout: strangelayout\n---\n")
+	writeFile(t, filepath.Join(contentDir, "unfinished", "index.md"), "---\ntitle: Unfinished\nlayout: nolayout\ndraft: true\n---\n")
+
+	b := &Builder{ContentDir: contentDir, PubDir: pubDir}
+	err := b.Build()
+	if err == nil {
+		t.Fatal("expected error for unknown layouts, got nil")
+	}
+	for _, w := range []string{"oddlayout", "strangelayout"} {
+		if !strings.Contains(err.Error(), w) {
+			t.Errorf("error %q does not mention %q", err, w)
+		}
+	}
+	if strings.Contains(err.Error(), "nolayout") {
+		t.Errorf("error %q mentions the layout of a draft page", err)
+	}
+
+	entries, err := os.ReadDir(pubDir)
+	if err != nil {
+		t.Fatalf("read pub dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("got %d files in pub dir, wanted nothing written before validation", len(entries))
+	}
+}
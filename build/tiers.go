@@ -0,0 +1,312 @@
+package build
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iand/genster/logging"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// A FamilyTier describes a second, family-only site built alongside the
+// public one. The family site includes private pages and is published to its
+// own directory, typically served behind basic authentication. Both sites
+// share the same URL structure so links between them stay consistent.
+type FamilyTier struct {
+	// ContentDir is the content of the family site, usually generated with
+	// living people included. When empty the public ContentDir is used.
+	ContentDir string
+	PubDir     string
+	// BaseURL is the URL of the family site used for its sitemap and feeds,
+	// which may include a path such as https://example.org/family/. When empty neither is written for the family site.
+	BaseURL string
+	// AuthUser and AuthPassword, when set, are used to write a .htaccess to
+	// PubDir that restricts access with basic authentication, together with
+	// the password file it refers to.
+	AuthUser     string
+	AuthPassword string
+	// PasswdFile is where the password file is written. It must be outside
+	// PubDir so that it is never published. When empty it is written next to
+	// PubDir, with the name of PubDir followed by .htpasswd.
+	PasswdFile string
+	// AuthUserFile is the path of the password file on the web server, as
+	// written to the .htaccess. When empty the absolute path of PasswdFile is
+	// used, which suits a site served from the directory it was built in.
+	AuthUserFile string
+}
+
+// buildTiers builds the family site followed by the public site. Links in the
+// public site to pages or files that only exist in the family site are removed
+// and the public site is then scanned to make sure no family-only path
+// remains in it.
+func (b *Builder) buildTiers() error {
+	if b.IncludePrivate {
+		return fmt.Errorf("the public site cannot include private pages when a family site is built")
+	}
+	if filepath.Clean(b.Family.PubDir) == filepath.Clean(b.PubDir) {
+		return fmt.Errorf("the family site must be written to a different directory to the public site")
+	}
+
+	fam := &Builder{
+		ContentDir:     b.Family.ContentDir,
+		PubDir:         b.Family.PubDir,
+		AssetsDir:      b.AssetsDir,
+		ThemeDir:       b.ThemeDir,
+		Debug:          b.Debug,
+		BaseURL:        b.Family.BaseURL,
+		IncludeDrafts:  b.IncludeDrafts,
+		IncludePrivate: true,
+		StrictLinks:    b.StrictLinks,
+	}
+	if fam.ContentDir == "" {
+		fam.ContentDir = b.ContentDir
+	}
+	if err := fam.build(); err != nil {
+		return fmt.Errorf("build family site: %w", err)
+	}
+	if b.Family.AuthUser != "" {
+		if err := writeBasicAuth(b.Family); err != nil {
+			return fmt.Errorf("write basic auth: %w", err)
+		}
+	}
+
+	b.family = fam
+	if err := b.build(); err != nil {
+		return err
+	}
+
+	leaks, err := b.findLeaks()
+	if err != nil {
+		return fmt.Errorf("scan public site: %w", err)
+	}
+	for _, l := range leaks {
+		logging.Warn("family-only path found in public site", "file", l.file, "path", l.path)
+	}
+	if len(leaks) > 0 {
+		return fmt.Errorf("found %d family-only paths in the public site", len(leaks))
+	}
+	return nil
+}
+
+// withholdPrivatePages removes the aliases of private pages from aliasIndex,
+// so no redirect reveals them, and returns the content-relative directories
+// of the private pages. When omit is true the private pages are also removed
+// from children so that they are left out of section listings.
+func withholdPrivatePages(children map[string][]childPage, aliasIndex map[string]childPage, omit bool) map[string]bool {
+	dirs := make(map[string]bool)
+	for section, pages := range children {
+		kept := pages[:0]
+		for _, cp := range pages {
+			if bool(cp.FM.Private) {
+				if dir := strings.Trim(cp.URL, "/"); dir != "" {
+					dirs[dir] = true
+				}
+				if omit {
+					continue
+				}
+			}
+			kept = append(kept, cp)
+		}
+		children[section] = kept
+	}
+	for alias, cp := range aliasIndex {
+		if bool(cp.FM.Private) {
+			delete(aliasIndex, alias)
+		}
+	}
+	return dirs
+}
+
+// withholdPrivateFrontMatter clears the front-matter fields of a private page
+// that identify people or link to images and other pages, leaving only what
+// is needed to show a placeholder.
+func withholdPrivateFrontMatter(fm *FrontMatter) {
+	fm.Image = ""
+	fm.Aliases = nil
+	fm.Slug = ""
+	fm.GrampsID = ""
+	fm.WikiTreeID = ""
+	fm.People = nil
+	fm.Places = nil
+	fm.Sources = nil
+	fm.StoryParts = nil
+	fm.Links = nil
+	fm.Descendants = nil
+	fm.JSONLD = ""
+}
+
+// withholdFamilyLinks removes links from the rendered public pages whose
+// targets are only found in the family site. Anchors are replaced by their
+// text and images are dropped, including in the content of any feeds taken
+// from the pages. The links are removed from b.links so they are not reported
+// as dangling.
+func (b *Builder) withholdFamilyLinks() error {
+	byPage := make(map[string][]pageLink)
+	kept := b.links[:0]
+	for _, pl := range b.links {
+		if pl.Reason == "" && !b.linkResolves(pl.Target) && b.family.linkResolves(pl.Target) {
+			byPage[pl.Page] = append(byPage[pl.Page], pl)
+			continue
+		}
+		kept = append(kept, pl)
+	}
+	b.links = kept
+
+	for page, pls := range byPage {
+		data, err := os.ReadFile(page)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", page, err)
+		}
+		for _, pl := range pls {
+			data = unlink(data, pl.raw)
+		}
+		if err := os.WriteFile(page, data, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", page, err)
+		}
+		logging.Info("removed family-only links", "file", page, "count", len(pls))
+
+		for i := range b.feeds {
+			for j := range b.feeds[i].Items {
+				it := &b.feeds[i].Items[j]
+				for _, pl := range pls {
+					it.Content = string(unlink([]byte(it.Content), pl.raw))
+					if strings.HasPrefix(pl.raw, "/") {
+						it.Content = string(unlink([]byte(it.Content), b.baseOrigin()+pl.raw))
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// unlink replaces every anchor in h whose href is raw with its text and
+// removes every image whose src is raw.
+func unlink(h []byte, raw string) []byte {
+	q := regexp.QuoteMeta(raw)
+	anchorRE := regexp.MustCompile(`(?is)<a\s[^>]*?\bhref\s*=\s*"` + q + `"[^>]*>(.*?)</a>`)
+	imgRE := regexp.MustCompile(`(?is)<img\s[^>]*?\bsrc\s*=\s*"` + q + `"[^>]*>`)
+	h = anchorRE.ReplaceAll(h, []byte("$1"))
+	return imgRE.ReplaceAll(h, nil)
+}
+
+// sitePathRE matches site-root-relative paths in rendered output.
+var sitePathRE = regexp.MustCompile(`/[A-Za-z0-9][A-Za-z0-9._~%/-]*`)
+
+type leak struct {
+	file string
+	path string
+}
+
+// findLeaks scans the HTML and XML files of the public site for paths that
+// only exist in the family site.
+func (b *Builder) findLeaks() ([]leak, error) {
+	familyOnly := make(map[string]bool)
+	for out := range b.family.outputs {
+		if b.outputs[out] {
+			continue
+		}
+		p := strings.TrimSuffix(out, "index.html")
+		if _, ok := b.aliasIndex[strings.TrimSuffix(p, "/")]; ok {
+			continue
+		}
+		familyOnly[p] = true
+	}
+	for alias := range b.family.aliasIndex {
+		if _, ok := b.aliasIndex[alias]; !ok && !b.outputs[alias+"/index.html"] {
+			familyOnly[alias] = true
+		}
+	}
+	if len(familyOnly) == 0 {
+		return nil, nil
+	}
+
+	files := make([]string, 0, len(b.outputs))
+	for out := range b.outputs {
+		if ext := filepath.Ext(out); ext == ".html" || ext == ".xml" {
+			files = append(files, out)
+		}
+	}
+	sort.Strings(files)
+
+	var leaks []leak
+	for _, f := range files {
+		fname := filepath.Join(b.PubDir, filepath.FromSlash(f))
+		data, err := os.ReadFile(fname)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", fname, err)
+		}
+		if origin := b.baseOrigin(); origin != "" {
+			data = bytes.ReplaceAll(data, []byte(origin), nil)
+		}
+		seen := make(map[string]bool)
+		for _, m := range sitePathRE.FindAll(data, -1) {
+			p, ok := b.sitePath(strings.TrimRight(string(m), "."))
+			if !ok || seen[p] {
+				continue
+			}
+			seen[p] = true
+			if familyOnly[p] || familyOnly[strings.TrimSuffix(p, "/")] || familyOnly[p+"/"] {
+				leaks = append(leaks, leak{file: fname, path: p})
+			}
+		}
+	}
+	return leaks, nil
+}
+
+// writeBasicAuth writes an Apache .htaccess file to the PubDir of ft that
+// restricts the site to a single user, together with the password file it
+// refers to. The password is stored as a salted bcrypt hash.
+func writeBasicAuth(ft *FamilyTier) error {
+	absDir, err := filepath.Abs(ft.PubDir)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", ft.PubDir, err)
+	}
+	passwdPath := ft.PasswdFile
+	if passwdPath == "" {
+		passwdPath = absDir + ".htpasswd"
+	}
+	if passwdPath, err = filepath.Abs(passwdPath); err != nil {
+		return fmt.Errorf("resolve %s: %w", ft.PasswdFile, err)
+	}
+	if withinDir(passwdPath, absDir) {
+		return fmt.Errorf("the password file %s must be outside the family site directory", passwdPath)
+	}
+	authUserFile := ft.AuthUserFile
+	if authUserFile == "" {
+		authUserFile = passwdPath
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(ft.AuthPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	passwd := ft.AuthUser + ":" + string(hash) + "\n"
+	if err := os.WriteFile(passwdPath, []byte(passwd), 0o600); err != nil {
+		return fmt.Errorf("write %s: %w", passwdPath, err)
+	}
+
+	htaccess := "AuthType Basic\n" +
+		"AuthName \"Family site\"\n" +
+		"AuthUserFile \"" + authUserFile + "\"\n" +
+		"Require valid-user\n\n" +
+		"<Files \".ht*\">\n" +
+		"\tRequire all denied\n" +
+		"</Files>\n"
+	accessPath := filepath.Join(absDir, ".htaccess")
+	if err := os.WriteFile(accessPath, []byte(htaccess), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", accessPath, err)
+	}
+	return nil
+}
+
+// withinDir reports whether the absolute path name is dir or lies inside it.
+func withinDir(name, dir string) bool {
+	rel, err := filepath.Rel(dir, name)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
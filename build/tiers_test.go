@@ -0,0 +1,186 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBuildFamilyTier(t *testing.T) {
+	publicDir := t.TempDir()
+	familyDir := t.TempDir()
+
+	// the public content was generated with living people redacted
+	writeFile(t, filepath.Join(publicDir, "person", "I1", "index.md"),
+		"---\ntitle: John Smith\nlayout: single\naliases:\n  - /r/john\n---\n\n"+
+			"His daughter is [Jane](/person/I2/), shown in [this photo](/stories/secret/photo.jpg).\n")
+	writeFile(t, filepath.Join(familyDir, "person", "I1", "index.md"),
+		"---\ntitle: John Smith\nlayout: single\naliases:\n  - /r/john\n---\n\n"+
+			"His daughter is [Jane](/person/I2/), shown in [this photo](/stories/secret/photo.jpg).\n")
+	writeFile(t, filepath.Join(familyDir, "person", "I2", "index.md"),
+		"---\ntitle: Jane Smith\nlayout: single\naliases:\n  - /r/jane\n---\n\nStill living.\n")
+
+	for _, dir := range []string{publicDir, familyDir} {
+		writeFile(t, filepath.Join(dir, "stories", "secret", "index.md"),
+			"---\ntitle: A Family Secret\nlayout: single\nprivate: yes\nimage: photo.jpg\naliases:\n  - /r/secret\n---\n\n"+
+				"![Jane as a baby](photo.jpg)\n")
+		writeFile(t, filepath.Join(dir, "stories", "secret", "photo.jpg"), "fake jpeg data")
+	}
+
+	pubDir := t.TempDir()
+	familyPubDir := t.TempDir()
+	b := &Builder{
+		ContentDir: publicDir,
+		PubDir:     pubDir,
+		Family: &FamilyTier{
+			ContentDir:   familyDir,
+			PubDir:       familyPubDir,
+			AuthUser:     "family",
+			AuthPassword: "secret",
+		},
+	}
+	if err := b.Build(); err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	for _, f := range []string{"person/I2/index.html", "r/jane/index.html", "r/secret/index.html", "stories/secret/photo.jpg", ".htaccess"} {
+		if _, err := os.Stat(filepath.Join(familyPubDir, f)); err != nil {
+			t.Errorf("family site is missing %s", f)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(familyPubDir, ".htpasswd")); err == nil {
+		t.Errorf("family site publishes its password file")
+	}
+	for _, f := range []string{"person/I2/index.html", "r/jane/index.html", "r/secret/index.html", "stories/secret/index.html", "stories/secret/photo.jpg"} {
+		if _, err := os.Stat(filepath.Join(pubDir, f)); err == nil {
+			t.Errorf("public site contains %s", f)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(pubDir, "person", "I1", "index.html"))
+	if err != nil {
+		t.Fatalf("read public page: %v", err)
+	}
+	if strings.Contains(string(page), "/person/I2/") || strings.Contains(string(page), "photo.jpg") {
+		t.Errorf("public page links to family-only pages:\n%s", page)
+	}
+	if !strings.Contains(string(page), "His daughter is Jane, shown in this photo.") {
+		t.Errorf("public page lost the text of family-only links:\n%s", page)
+	}
+
+	for _, pl := range b.links {
+		if pl.Target == "/person/I2/" {
+			t.Errorf("family-only link %s was not withheld", pl.Href)
+		}
+	}
+
+	passwdPath := familyPubDir + ".htpasswd"
+	passwd, err := os.ReadFile(passwdPath)
+	if err != nil {
+		t.Fatalf("read password file: %v", err)
+	}
+	user, hash, _ := strings.Cut(strings.TrimSpace(string(passwd)), ":")
+	if user != "family" || !strings.HasPrefix(hash, "$2a$") {
+		t.Fatalf("got password file %q, wanted a bcrypt hash for family", passwd)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("secret")); err != nil {
+		t.Errorf("password file hash %q does not match the password", hash)
+	}
+
+	htaccess, err := os.ReadFile(filepath.Join(familyPubDir, ".htaccess"))
+	if err != nil {
+		t.Fatalf("read .htaccess: %v", err)
+	}
+	if want := `AuthUserFile "` + passwdPath + `"`; !strings.Contains(string(htaccess), want) {
+		t.Errorf("got .htaccess %q, wanted it to contain %q", htaccess, want)
+	}
+}
+
+func TestWriteBasicAuth(t *testing.T) {
+	pubDir := t.TempDir()
+	ft := &FamilyTier{
+		PubDir:       pubDir,
+		AuthUser:     "family",
+		AuthPassword: "secret",
+		PasswdFile:   filepath.Join(t.TempDir(), "passwords"),
+		AuthUserFile: "/etc/apache2/family.htpasswd",
+	}
+	if err := writeBasicAuth(ft); err != nil {
+		t.Fatalf("writeBasicAuth: %v", err)
+	}
+	if _, err := os.Stat(ft.PasswdFile); err != nil {
+		t.Errorf("password file not written: %v", err)
+	}
+	htaccess, err := os.ReadFile(filepath.Join(pubDir, ".htaccess"))
+	if err != nil {
+		t.Fatalf("read .htaccess: %v", err)
+	}
+	if want := `AuthUserFile "/etc/apache2/family.htpasswd"`; !strings.Contains(string(htaccess), want) {
+		t.Errorf("got .htaccess %q, wanted it to contain %q", htaccess, want)
+	}
+
+	ft.PasswdFile = filepath.Join(pubDir, "private", ".htpasswd")
+	if err := writeBasicAuth(ft); err == nil {
+		t.Errorf("got no error writing the password file inside the published site")
+	}
+}
+
+func TestBuildFamilyTierLeak(t *testing.T) {
+	publicDir := t.TempDir()
+	familyDir := t.TempDir()
+
+	writeFile(t, filepath.Join(publicDir, "person", "I1", "index.md"),
+		"---\ntitle: John Smith\nlayout: single\n---\n\nSee `/person/I2/` for his daughter.\n")
+	writeFile(t, filepath.Join(familyDir, "person", "I2", "index.md"),
+		"---\ntitle: Jane Smith\nlayout: single\n---\n\nStill living.\n")
+
+	b := &Builder{
+		ContentDir: publicDir,
+		PubDir:     t.TempDir(),
+		Family:     &FamilyTier{ContentDir: familyDir, PubDir: t.TempDir()},
+	}
+	if err := b.Build(); err == nil || !strings.Contains(err.Error(), "family-only paths") {
+		t.Errorf("got error %v, wanted family-only paths error", err)
+	}
+}
+
+func TestBuildFamilyTierRejectsPrivatePublicSite(t *testing.T) {
+	b := &Builder{
+		ContentDir:     t.TempDir(),
+		PubDir:         t.TempDir(),
+		IncludePrivate: true,
+		Family:         &FamilyTier{PubDir: t.TempDir()},
+	}
+	if err := b.Build(); err == nil {
+		t.Errorf("got no error when including private pages in the public site")
+	}
+}
+
+func TestFamilyAuth(t *testing.T) {
+	t.Setenv(familyAuthEnv, "env:fromenv")
+
+	got, err := familyAuth("")
+	if err != nil {
+		t.Fatalf("familyAuth: %v", err)
+	}
+	if got != "env:fromenv" {
+		t.Errorf("got %q from the environment, wanted %q", got, "env:fromenv")
+	}
+
+	fname := filepath.Join(t.TempDir(), "auth")
+	writeFile(t, fname, "family:secret\n")
+	got, err = familyAuth(fname)
+	if err != nil {
+		t.Fatalf("familyAuth: %v", err)
+	}
+	if got != "family:secret" {
+		t.Errorf("got %q from the file, wanted %q", got, "family:secret")
+	}
+
+	if _, err := familyAuth(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("got no error for a missing file")
+	}
+}
@@ -0,0 +1,143 @@
+package chart
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	"github.com/iand/gtree"
+)
+
+// connectorWidth is the stroke width used for connecting lines, matching the SVG renderer.
+const connectorWidth = 2.375
+
+// hangingOffset approximates the distance from the top of a line of text to its baseline
+// as a proportion of the font size. SVG renders blurb text with a hanging baseline.
+const hangingOffset = 0.8
+
+// boxPadding is the space left between the text of a blurb and the edge of its fill.
+const boxPadding = 4
+
+// Sizes used when drawing the legend of a coloured chart, in layout pixels.
+const (
+	legendFontSize   = 14
+	legendLineHeight = 22
+	legendSwatchSize = 14
+)
+
+// textAnchor controls the horizontal alignment of text relative to its position.
+type textAnchor int
+
+const (
+	anchorStart textAnchor = iota
+	anchorMiddle
+)
+
+// A canvas is a drawing surface for rendering chart layouts. All coordinates
+// are in layout pixels, measured from the top left of the chart.
+type canvas interface {
+	// Polyline draws a line through the supplied points.
+	Polyline(pts []gtree.Point, width float64)
+
+	// Text draws s with its baseline at y and aligned to x according to anchor.
+	Text(x, y float64, size float64, col color.Color, anchor textAnchor, s string)
+
+	// Rect fills the rectangle with its top left corner at x, y.
+	Rect(x, y, w, h float64, fill color.Color)
+}
+
+// drawLayout draws the elements of lay onto c, following the same geometry as the
+// SVG renderer so that all output formats present the chart identically. Layouts
+// produced by ColourLayout also have their boxes filled and a legend drawn.
+func drawLayout(c canvas, lay gtree.Layout) {
+	cl, _ := lay.(*colouredLayout)
+	if cl != nil {
+		drawFills(c, cl)
+	}
+
+	var y gtree.Pixel
+	margin := float64(lay.Margin())
+
+	title := lay.Title()
+	if title.Text != "" {
+		c.Text(margin, margin+float64(title.Style.LineHeight), float64(title.Style.FontSize), parseColor(title.Style.Color), anchorStart, title.Text)
+		y += title.Style.LineHeight
+	}
+
+	for _, n := range lay.Notes() {
+		c.Text(margin, margin+float64(n.Style.LineHeight+y), float64(n.Style.FontSize), parseColor(n.Style.Color), anchorStart, n.Text)
+		y += n.Style.LineHeight
+	}
+
+	for _, b := range lay.Blurbs() {
+		anchor := anchorStart
+		x := float64(b.Left())
+		if b.CentreText {
+			anchor = anchorMiddle
+			x = float64(b.X())
+		}
+
+		top := b.TopPos
+		for _, sec := range []gtree.TextSection{b.HeadingTexts, b.DetailTexts} {
+			col := parseColor(sec.Style.Color)
+			for _, line := range sec.Lines {
+				top += sec.Style.LineHeight
+				c.Text(x, float64(top)+hangingOffset*float64(sec.Style.FontSize), float64(sec.Style.FontSize), col, anchor, line)
+			}
+		}
+	}
+
+	for _, cn := range lay.Connectors() {
+		if len(cn.Points) < 2 {
+			continue
+		}
+		c.Polyline(cn.Points, connectorWidth)
+	}
+
+	if cl != nil {
+		drawLegend(c, cl)
+	}
+}
+
+// drawFills fills the boxes of a coloured layout. It must be drawn before the text of
+// the boxes so that the text is not hidden.
+func drawFills(c canvas, cl *colouredLayout) {
+	for _, b := range cl.Blurbs() {
+		fill, ok := cl.colouring.Fills[b.ID]
+		if !ok {
+			continue
+		}
+		c.Rect(float64(b.Left()-boxPadding), float64(b.TopPos-boxPadding), float64(b.Width+2*boxPadding), float64(b.Height+2*boxPadding), parseColor(fill))
+	}
+}
+
+// drawLegend draws the key to a colouring in the space below the chart.
+func drawLegend(c canvas, cl *colouredLayout) {
+	x := float64(cl.Margin())
+	y := float64(cl.Layout.Height())
+
+	y += legendLineHeight
+	c.Text(x, y, legendFontSize, color.Black, anchorStart, cl.colouring.Title)
+	for _, e := range cl.colouring.Legend {
+		c.Rect(x, y+legendLineHeight-legendSwatchSize, legendSwatchSize, legendSwatchSize, parseColor(e.Fill))
+		y += legendLineHeight
+		c.Text(x+legendSwatchSize+legendFontSize/2, y, legendFontSize, color.Black, anchorStart, e.Label)
+	}
+}
+
+// parseColor converts a CSS hex colour such as #336699 or #369 into a color.
+// Black is returned for empty or unparseable values.
+func parseColor(s string) color.Color {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return color.Black
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.Black
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
@@ -0,0 +1,294 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/text"
+	"github.com/iand/genster/tree"
+	"github.com/iand/gtree"
+)
+
+// ChartPeople maps the identifiers of the boxes in a chart to the people they represent.
+type ChartPeople map[int]*model.Person
+
+// ColourBy is a scheme for choosing the fill colour of each box in a chart.
+type ColourBy string
+
+const (
+	ColourByNone             ColourBy = ""
+	ColourBySurnameGroup     ColourBy = "surname-group"
+	ColourByBirthCountry     ColourBy = "birth-country"
+	ColourByBirthRegion      ColourBy = "birth-region"
+	ColourByOccupationGroup  ColourBy = "occupation-group"
+	ColourByFamilyLine       ColourBy = "family-line"
+	ColourByDataCompleteness ColourBy = "data-completeness"
+)
+
+// ColourBySchemes lists the supported colouring schemes.
+var ColourBySchemes = []ColourBy{
+	ColourBySurnameGroup,
+	ColourByBirthCountry,
+	ColourByBirthRegion,
+	ColourByOccupationGroup,
+	ColourByFamilyLine,
+	ColourByDataCompleteness,
+}
+
+// palette holds pale colours that keep black text legible when used as box fills.
+var palette = []string{
+	"#8dd3c7", "#ffffb3", "#bebada", "#fb8072", "#80b1d3", "#fdb462",
+	"#b3de69", "#fccde5", "#bc80bd", "#ccebc5", "#ffed6f",
+}
+
+const (
+	colourUnknown = "#eeeeee" // fill used for people without a value for the scheme
+	colourOther   = "#d9d9d9" // fill used once the palette has been exhausted
+)
+
+// Research bands used by the data-completeness scheme, in order from best to worst.
+const (
+	researchBandGood    = "Well researched"
+	researchBandPartial = "Partly researched"
+	researchBandSparse  = "Little researched"
+)
+
+var researchBandColours = map[string]string{
+	researchBandGood:    "#b3de69",
+	researchBandPartial: "#ffffb3",
+	researchBandSparse:  "#fb8072",
+}
+
+// A Colouring assigns fill colours to the boxes of a chart and describes them in a legend.
+type Colouring struct {
+	Title  string
+	Fills  map[int]string // fill colour of each box, keyed by box identifier
+	Legend []LegendEntry
+}
+
+// A LegendEntry describes the meaning of a fill colour.
+type LegendEntry struct {
+	Label string
+	Fill  string
+}
+
+// NewColouring assigns colours to the people in a chart using the given scheme. The most
+// frequent values are assigned colours first; any values left over once the palette is
+// exhausted share a single colour labelled as other.
+func NewColouring(by ColourBy, t *tree.Tree, people ChartPeople) (*Colouring, error) {
+	var keyFn func(*model.Person) string
+	var title string
+
+	switch by {
+	case ColourBySurnameGroup:
+		title = "Surname"
+		keyFn = func(p *model.Person) string {
+			if p.Unidentified || p.Redacted {
+				return ""
+			}
+			if p.FamilyNameGrouping != "" {
+				return p.FamilyNameGrouping
+			}
+			return p.PreferredFamilyName
+		}
+	case ColourByBirthCountry:
+		title = "Country of birth"
+		keyFn = func(p *model.Person) string {
+			if pl := birthPlace(p); !pl.IsUnknown() && !pl.Country.IsUnknown() {
+				return pl.Country.Name
+			}
+			return ""
+		}
+	case ColourByBirthRegion:
+		title = "Region of birth"
+		keyFn = func(p *model.Person) string {
+			if pl := birthPlace(p); !pl.IsUnknown() && !pl.Region.IsUnknown() {
+				return pl.Region.Name
+			}
+			return ""
+		}
+	case ColourByOccupationGroup:
+		title = "Occupation"
+		keyFn = func(p *model.Person) string {
+			return text.UpperFirst(string(p.OccupationGroup))
+		}
+	case ColourByFamilyLine:
+		if t.KeyPerson.IsUnknown() {
+			return nil, fmt.Errorf("colouring by family line requires a key person")
+		}
+		title = "Family line"
+		keyFn = familyLineKeyFunc(tree.WalkFamilyLines(t.KeyPerson))
+	case ColourByDataCompleteness:
+		title = "Research"
+		keyFn = researchBand
+	default:
+		return nil, fmt.Errorf("unsupported colour scheme: %s", by)
+	}
+
+	keys := make(map[int]string, len(people))
+	counts := make(map[string]int)
+	for id, p := range people {
+		if p.IsUnknown() {
+			continue
+		}
+		k := keyFn(p)
+		keys[id] = k
+		if k != "" {
+			counts[k]++
+		}
+	}
+
+	ranked := make([]string, 0, len(counts))
+	for k := range counts {
+		ranked = append(ranked, k)
+	}
+
+	colours := make(map[string]string, len(ranked))
+	if by == ColourByDataCompleteness {
+		// bands have fixed colours and a natural order
+		ranked = ranked[:0]
+		for _, b := range []string{researchBandGood, researchBandPartial, researchBandSparse} {
+			if counts[b] > 0 {
+				ranked = append(ranked, b)
+				colours[b] = researchBandColours[b]
+			}
+		}
+	} else {
+		sort.Slice(ranked, func(i, j int) bool {
+			if counts[ranked[i]] != counts[ranked[j]] {
+				return counts[ranked[i]] > counts[ranked[j]]
+			}
+			return ranked[i] < ranked[j]
+		})
+		for i, k := range ranked {
+			if i < len(palette) {
+				colours[k] = palette[i]
+			} else {
+				colours[k] = colourOther
+			}
+		}
+	}
+
+	c := &Colouring{
+		Title: title,
+		Fills: make(map[int]string, len(keys)),
+	}
+
+	var hasUnknown, hasOther bool
+	for id, k := range keys {
+		if k == "" {
+			c.Fills[id] = colourUnknown
+			hasUnknown = true
+			continue
+		}
+		c.Fills[id] = colours[k]
+		if colours[k] == colourOther {
+			hasOther = true
+		}
+	}
+
+	for _, k := range ranked {
+		if colours[k] == colourOther {
+			break
+		}
+		c.Legend = append(c.Legend, LegendEntry{Label: k, Fill: colours[k]})
+	}
+	if hasOther {
+		c.Legend = append(c.Legend, LegendEntry{Label: "Other", Fill: colourOther})
+	}
+	if hasUnknown {
+		c.Legend = append(c.Legend, LegendEntry{Label: "Not known", Fill: colourUnknown})
+	}
+
+	return c, nil
+}
+
+func birthPlace(p *model.Person) *model.Place {
+	if p.BestBirthlikeEvent == nil {
+		return nil
+	}
+	return p.BestBirthlikeEvent.GetPlace()
+}
+
+// familyLineKeyFunc returns a function that finds the family line a person belongs to.
+// People who are not part of the lineage of a family line are assigned to the line of
+// their nearest ancestor within one, searching the paternal side first.
+func familyLineKeyFunc(lines []*model.FamilyLine) func(*model.Person) string {
+	lineage := make(map[string]string)
+	for _, fl := range lines {
+		for _, p := range fl.Lineage {
+			if _, ok := lineage[p.ID]; !ok {
+				lineage[p.ID] = fl.Name
+			}
+		}
+	}
+
+	return func(p *model.Person) string {
+		queue := []*model.Person{p}
+		seen := make(map[string]bool)
+		for len(queue) > 0 {
+			a := queue[0]
+			queue = queue[1:]
+			if a.IsUnknown() || seen[a.ID] {
+				continue
+			}
+			seen[a.ID] = true
+			if name, ok := lineage[a.ID]; ok {
+				return name
+			}
+			queue = append(queue, a.Father, a.Mother)
+		}
+		return ""
+	}
+}
+
+// researchBand places a person into a broad band according to their research
+// completeness score.
+func researchBand(p *model.Person) string {
+	c := p.Completeness
+	if c == nil {
+		c = model.EvaluateCompleteness(p)
+	}
+
+	switch score := c.Score(); {
+	case score >= 75:
+		return researchBandGood
+	case score >= 40:
+		return researchBandPartial
+	default:
+		return researchBandSparse
+	}
+}
+
+// ColourLayout returns a layout that draws lay with the boxes filled according to
+// colouring and a legend added beneath the chart. Only the renderers in this package
+// draw the colouring, so the result should be rendered using SVG, PNG or PDF.
+func ColourLayout(lay gtree.Layout, colouring *Colouring) gtree.Layout {
+	if colouring == nil {
+		return lay
+	}
+	return &colouredLayout{Layout: lay, colouring: colouring}
+}
+
+type colouredLayout struct {
+	gtree.Layout
+	colouring *Colouring
+}
+
+// Width widens the chart if needed so that the longest line of the legend fits. Text
+// widths are estimated using Helvetica metrics.
+func (l *colouredLayout) Width() gtree.Pixel {
+	margin := float64(l.Margin())
+	w := 2*margin + helveticaWidth(l.colouring.Title)*legendFontSize
+	for _, e := range l.colouring.Legend {
+		ew := 2*margin + legendSwatchSize + legendFontSize/2 + helveticaWidth(e.Label)*legendFontSize
+		w = max(w, ew)
+	}
+	return max(l.Layout.Width(), gtree.Pixel(math.Ceil(w)))
+}
+
+func (l *colouredLayout) Height() gtree.Pixel {
+	return l.Layout.Height() + gtree.Pixel(legendLineHeight*(len(l.colouring.Legend)+1)) + l.Margin()
+}
@@ -0,0 +1,110 @@
+package chart
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/gtree"
+)
+
+func TestNewColouring(t *testing.T) {
+	person := func(surname string) *model.Person {
+		return &model.Person{ID: surname, PreferredFamilyName: surname}
+	}
+
+	many := make(ChartPeople)
+	for i := 0; i < len(palette)+2; i++ {
+		many[i] = person(fmt.Sprintf("Surname%02d", i))
+	}
+	many[100] = person("Surname00")
+
+	for _, tt := range []struct {
+		name   string
+		people ChartPeople
+		fills  map[int]string
+		legend []string
+	}{
+		{
+			name: "by_frequency",
+			people: ChartPeople{
+				0: person("Smith"),
+				1: person("Jones"),
+				2: person("Jones"),
+				3: {ID: "x", Unidentified: true},
+			},
+			fills:  map[int]string{0: palette[1], 1: palette[0], 2: palette[0], 3: colourUnknown},
+			legend: []string{"Jones", "Smith", "Not known"},
+		},
+		{
+			name:   "palette_exhausted",
+			people: many,
+			fills:  map[int]string{0: palette[0], 100: palette[0], len(palette): colourOther, len(palette) + 1: colourOther},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewColouring(ColourBySurnameGroup, nil, tt.people)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for id, want := range tt.fills {
+				if got := c.Fills[id]; got != want {
+					t.Errorf("fill for %d: got %q, wanted %q", id, got, want)
+				}
+			}
+			if tt.legend != nil {
+				var labels []string
+				for _, e := range c.Legend {
+					labels = append(labels, e.Label)
+				}
+				if strings.Join(labels, ",") != strings.Join(tt.legend, ",") {
+					t.Errorf("got legend %v, wanted %v", labels, tt.legend)
+				}
+			}
+		})
+	}
+}
+
+func TestColourLayout(t *testing.T) {
+	c := &Colouring{
+		Title:  "Surname",
+		Fills:  map[int]string{0: "#8dd3c7"},
+		Legend: []LegendEntry{{Label: "Smith", Fill: "#8dd3c7"}},
+	}
+	base := &testLayout{width: 400, height: 300}
+	lay := ColourLayout(base, c)
+	if lay.Height() <= base.Height() {
+		t.Errorf("got height %d, wanted more than %d to fit legend", lay.Height(), base.Height())
+	}
+
+	if lay.Width() != base.Width() {
+		t.Errorf("got width %d, wanted %d when legend fits", lay.Width(), base.Width())
+	}
+
+	svg, err := SVG(lay, &gtree.PaperSizeA3Landscape)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fill, text := strings.Index(svg, `fill="#8dd3c7"`), strings.Index(svg, "<text"); fill > text {
+		t.Errorf("box fill drawn after text")
+	}
+	if got := strings.Count(svg, `fill="#8dd3c7"`); got != 2 {
+		t.Errorf("got %d shapes filled with palette colour, wanted 2 (box and legend swatch)", got)
+	}
+	if !strings.Contains(svg, ">Smith</text>") {
+		t.Errorf("legend label not found in svg")
+	}
+}
+
+func TestColourLayoutWidth(t *testing.T) {
+	c := &Colouring{
+		Title:  "Birth country",
+		Legend: []LegendEntry{{Label: "United Kingdom of Great Britain and Northern Ireland", Fill: "#8dd3c7"}},
+	}
+	base := &testLayout{width: 100, height: 300}
+	lay := ColourLayout(base, c)
+	if lay.Width() <= base.Width() {
+		t.Errorf("got width %d, wanted more than %d to fit legend", lay.Width(), base.Width())
+	}
+}
@@ -8,7 +8,12 @@ package chart
 import (
 	"context"
 	"fmt"
+	"image/color"
 	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/urfave/cli/v3"
@@ -16,6 +21,7 @@ import (
 	gegedcom "github.com/iand/genster/gedcom"
 	"github.com/iand/genster/gramps"
 	"github.com/iand/genster/logging"
+	"github.com/iand/genster/model"
 	"github.com/iand/genster/tree"
 	"github.com/iand/gtree"
 )
@@ -38,12 +44,50 @@ func checkFlags(cc *cli.Command) error {
 		return fmt.Errorf("unsupported page size: %s", chartopts.target)
 	}
 
+	if chartopts.outputFormat == "" {
+		// infer the format from the output filename, defaulting to svg
+		chartopts.outputFormat = strings.TrimPrefix(strings.ToLower(filepath.Ext(chartopts.outputFilename)), ".")
+		if chartopts.outputFormat == "" {
+			chartopts.outputFormat = "svg"
+		}
+	}
+
+	switch chartopts.outputFormat {
+	case "svg":
+	case "png":
+		if chartopts.dpi <= 0 {
+			return fmt.Errorf("dpi must be greater than zero")
+		}
+	case "pdf":
+		if _, ok := LookupPaper(chartopts.paper); !ok {
+			return fmt.Errorf("unsupported paper size: %s", chartopts.paper)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", chartopts.outputFormat)
+	}
+
+	if chartopts.colourBy != "" {
+		switch chartopts.chartType {
+		case "descendant", "ancestor", "focus":
+		default:
+			return fmt.Errorf("colour-by is not supported for %s charts", chartopts.chartType)
+		}
+		if !slices.Contains(ColourBySchemes, ColourBy(chartopts.colourBy)) {
+			return fmt.Errorf("unsupported colour-by scheme: %s", chartopts.colourBy)
+		}
+	}
+
+	if chartopts.background != "" && chartopts.background != "transparent" && !hexColourRE.MatchString(chartopts.background) {
+		return fmt.Errorf("unsupported background: %s", chartopts.background)
+	}
+
 	if chartopts.directOnly {
 		chartopts.children = "direct"
 	}
 
 	switch chartopts.children {
 	case "all":
+	case "everyone":
 	case "direct":
 	case "none":
 	default:
@@ -64,9 +108,13 @@ var chartopts struct {
 	title              string
 	fontScale          float64
 	target             string
+	background         string
 
 	outputFilename  string
 	outputFormat    string
+	paper           string
+	overlap         float64
+	dpi             float64
 	descendantId    string
 	generations     int
 	detail          int
@@ -76,6 +124,7 @@ var chartopts struct {
 	compact         bool
 	minimalSurnames bool
 	nodecoration    bool
+	colourBy        string
 	debug           bool
 }
 
@@ -117,6 +166,29 @@ var Command = &cli.Command{
 			Usage:       "output image filename",
 			Destination: &chartopts.outputFilename,
 		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "output format: svg, png or pdf. Defaults to the extension of the output filename, or svg if there is none",
+			Destination: &chartopts.outputFormat,
+		},
+		&cli.StringFlag{
+			Name:        "paper",
+			Usage:       "paper size for pdf output, A0 to A4 with optional Portrait or Landscape suffix such as 'A4' or 'A2Landscape'. Charts larger than the paper are split across pages",
+			Value:       "A4",
+			Destination: &chartopts.paper,
+		},
+		&cli.Float64Flag{
+			Name:        "overlap",
+			Usage:       "amount in millimetres that neighbouring pages overlap when a pdf chart is split across pages",
+			Value:       15,
+			Destination: &chartopts.overlap,
+		},
+		&cli.Float64Flag{
+			Name:        "dpi",
+			Usage:       "resolution of png output in dots per inch",
+			Value:       150,
+			Destination: &chartopts.dpi,
+		},
 		&cli.StringFlag{
 			Name:        "person",
 			Usage:       "identifier of person to build tree from",
@@ -159,7 +231,7 @@ var Command = &cli.Command{
 		},
 		&cli.StringFlag{
 			Name:        "children",
-			Usage:       "which children to show in the descendant chart (all, direct=children of direct ancestors, none=no children",
+			Usage:       "which children to show in the descendant chart (all, direct=children of direct ancestors, everyone=every descendant and all of their children, none=no children",
 			Value:       "all",
 			Destination: &chartopts.children,
 		},
@@ -198,10 +270,20 @@ var Command = &cli.Command{
 		},
 		&cli.StringFlag{
 			Name:        "target",
-			Usage:       "target of output, either a paper size ('A3Landscape') or 'web' which sizes to fit and makes background transparent",
+			Usage:       "target of output, either a paper size ('A3Landscape') or 'web' which sizes to fit",
 			Value:       "A3Landscape",
 			Destination: &chartopts.target,
 		},
+		&cli.StringFlag{
+			Name:        "background",
+			Usage:       "background colour of the chart as a hex colour such as '#ffffff', or 'transparent'; svg charts use the layout's default and png charts are white if not set",
+			Destination: &chartopts.background,
+		},
+		&cli.StringFlag{
+			Name:        "colour-by",
+			Usage:       "colour the boxes of descendant, ancestor and focus charts by one of surname-group, birth-country, birth-region, occupation-group, family-line or data-completeness",
+			Destination: &chartopts.colourBy,
+		},
 	}, logging.Flags...),
 }
 
@@ -276,11 +358,12 @@ func chartCmd(ctx context.Context, cc *cli.Command) error {
 		return nil
 	}
 
-	var output string
+	var output []byte
 	var lay gtree.Layout
+	var people ChartPeople
 	switch chartopts.chartType {
 	case "descendant":
-		ch, err := BuildDescendantChart(t, startPerson, chartopts.detail, chartopts.generations, chartopts.compact, chartopts.children, chartopts.parents, chartopts.minimalSurnames, !chartopts.nodecoration)
+		ch, chartPeople, err := BuildDescendantChart(t, startPerson, chartopts.detail, chartopts.generations, chartopts.compact, chartopts.children, chartopts.parents, chartopts.minimalSurnames, !chartopts.nodecoration)
 		if err != nil {
 			return fmt.Errorf("build descendant chart: %w", err)
 		}
@@ -300,21 +383,18 @@ func chartCmd(ctx context.Context, cc *cli.Command) error {
 
 		opts := gtree.DefaultLayoutOptions()
 		opts.Debug = chartopts.debug
-		if chartopts.target == "web" {
-			opts.BackgroundColor = ""
+		if bg, ok := svgBackground(); ok {
+			opts.BackgroundColor = bg
 		}
 
 		lay, err = ch.Layout(opts)
 		if err != nil {
 			return fmt.Errorf("layout chart: %w", err)
 		}
-		output, err = gtree.SVG(lay, pageSize(chartopts.target))
-		if err != nil {
-			return fmt.Errorf("render SVG: %w", err)
-		}
+		people = chartPeople
 
 	case "ancestor":
-		ch, err := BuildAncestorChart(t, startPerson, chartopts.detail, chartopts.generations, chartopts.compact)
+		ch, chartPeople, err := BuildAncestorChart(t, startPerson, chartopts.detail, chartopts.generations, chartopts.compact)
 		if err != nil {
 			return fmt.Errorf("build ancestor chart: %w", err)
 		}
@@ -344,10 +424,7 @@ func chartCmd(ctx context.Context, cc *cli.Command) error {
 		if err != nil {
 			return fmt.Errorf("layout chart: %w", err)
 		}
-		output, err = gtree.SVG(lay, pageSize(chartopts.target))
-		if err != nil {
-			return fmt.Errorf("render SVG: %w", err)
-		}
+		people = chartPeople
 
 	case "butterfly":
 		ch, err := BuildButterflyChart(t, startPerson)
@@ -371,10 +448,14 @@ func chartCmd(ctx context.Context, cc *cli.Command) error {
 		opts := gtree.DefaultButterflyLayoutOptions()
 		opts.Debug = chartopts.debug
 
-		output, err = ch.RenderSVG(opts)
+		if chartopts.outputFormat != "svg" {
+			return fmt.Errorf("butterfly charts can only be rendered as svg")
+		}
+		svg, err := ch.RenderSVG(opts)
 		if err != nil {
 			return fmt.Errorf("render SVG: %w", err)
 		}
+		output = []byte(svg)
 
 	case "fan":
 		ch, err := BuildFanChart(t, startPerson, chartopts.generations+1)
@@ -389,19 +470,14 @@ func chartCmd(ctx context.Context, cc *cli.Command) error {
 		opts := gtree.DefaultFanLayoutOptions()
 		opts.Debug = chartopts.debug
 		opts.MaxGenerations = chartopts.generations + 1
-		lay, err := gtree.GenerateFanLayout(ch, opts)
+		lay, err = gtree.GenerateFanLayout(ch, opts)
 		if err != nil {
 			return fmt.Errorf("generate fan layout: %w", err)
 		}
 
-		output, err = gtree.SVG(lay, pageSize(chartopts.target))
-		if err != nil {
-			return fmt.Errorf("render SVG: %w", err)
-		}
-
 	case "focus":
 		// A focus chart includes the start person and their immediate family: parents, siblings, spouses and children
-		ch, err := BuildFocusChart(t, startPerson, chartopts.detail, chartopts.minimalSurnames, !chartopts.nodecoration)
+		ch, chartPeople, err := BuildFocusChart(t, startPerson, chartopts.detail, chartopts.minimalSurnames, !chartopts.nodecoration)
 		if err != nil {
 			return fmt.Errorf("build focus chart: %w", err)
 		}
@@ -421,37 +497,70 @@ func chartCmd(ctx context.Context, cc *cli.Command) error {
 
 		opts := gtree.DefaultLayoutOptions()
 		opts.Debug = chartopts.debug
-		if chartopts.target == "web" {
-			opts.BackgroundColor = ""
+		if bg, ok := svgBackground(); ok {
+			opts.BackgroundColor = bg
 		}
 		lay, err = ch.Layout(opts)
 		if err != nil {
 			return fmt.Errorf("layout chart: %w", err)
 		}
-		output, err = gtree.SVG(lay, pageSize(chartopts.target))
-		if err != nil {
-			return fmt.Errorf("render SVG: %w", err)
-		}
+		people = chartPeople
 
 	default:
 		return fmt.Errorf("unsupported chart type: %s", chartopts.chartType)
 
 	}
 
+	var colouring *Colouring
+	if chartopts.colourBy != "" {
+		colouring, err = NewColouring(ColourBy(chartopts.colourBy), t, people)
+		if err != nil {
+			return fmt.Errorf("colour chart: %w", err)
+		}
+		lay = ColourLayout(lay, colouring)
+	}
+
+	if lay != nil {
+		switch chartopts.outputFormat {
+		case "svg":
+			svg, err := SVG(lay, pageSize(chartopts.target))
+			if err != nil {
+				return fmt.Errorf("render SVG: %w", err)
+			}
+			output = []byte(svg)
+		case "png":
+			output, err = PNG(lay, chartopts.dpi, pngBackground())
+			if err != nil {
+				return fmt.Errorf("render PNG: %w", err)
+			}
+		case "pdf":
+			paper, _ := LookupPaper(chartopts.paper)
+			output, err = PDF(lay, paper, chartopts.overlap)
+			if err != nil {
+				return fmt.Errorf("render PDF: %w", err)
+			}
+		}
+	}
+
 	if chartopts.outputFilename != "" {
-		err = os.WriteFile(chartopts.outputFilename, []byte(output), 0o666)
+		err = os.WriteFile(chartopts.outputFilename, output, 0o666)
 		if err != nil {
 			return fmt.Errorf("failed writing output file: %w", err)
 		}
+	} else if chartopts.outputFormat == "svg" {
+		fmt.Println(string(output))
 	} else {
-		fmt.Println(output)
+		if _, err := os.Stdout.Write(output); err != nil {
+			return fmt.Errorf("write output: %w", err)
+		}
 	}
 
 	return nil
 }
 
 type sequence struct {
-	n int
+	n      int
+	people ChartPeople // people assigned identifiers by nextFor
 }
 
 func (s *sequence) next() int {
@@ -460,7 +569,49 @@ func (s *sequence) next() int {
 	return n
 }
 
+// nextFor returns the next identifier in the sequence and records that it represents p.
+func (s *sequence) nextFor(p *model.Person) int {
+	n := s.next()
+	if p.IsUnknown() {
+		return n
+	}
+	if s.people == nil {
+		s.people = make(ChartPeople)
+	}
+	s.people[n] = p
+	return n
+}
+
 func scaleFont(v gtree.Pixel, factor float64) gtree.Pixel {
 	v = max(gtree.Pixel(float64(v)*factor), 6)
 	return v
 }
+
+// hexColourRE matches a CSS hex colour such as #336699 or #369.
+var hexColourRE = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// svgBackground returns the background colour of an SVG layout given by the
+// background flag, or false if the layout's default should be kept.
+func svgBackground() (string, bool) {
+	switch chartopts.background {
+	case "":
+		return "", false
+	case "transparent":
+		return "", true
+	default:
+		return chartopts.background, true
+	}
+}
+
+// pngBackground returns the background colour of a PNG chart given by the
+// background flag, or nil for a transparent background.
+func pngBackground() color.Color {
+	switch chartopts.background {
+	case "":
+		return color.White
+	case "transparent":
+		return nil
+	default:
+		return parseColor(chartopts.background)
+	}
+}
@@ -135,7 +135,7 @@ func appendAbbrevWhatWhenWhere(details []string, ev model.TimelineEvent, compact
 	return details
 }
 
-func BuildDescendantChart(t *tree.Tree, startPerson *model.Person, detail int, depth int, compact bool, children string, parents bool, minimalSurnames bool, showStars bool) (*gtree.DescendantChart, error) {
+func BuildDescendantChart(t *tree.Tree, startPerson *model.Person, detail int, depth int, compact bool, children string, parents bool, minimalSurnames bool, showStars bool) (*gtree.DescendantChart, ChartPeople, error) {
 	var personDetailFn personDetailFunc
 	var familyDetailFn familyDetailFunc
 
@@ -220,25 +220,27 @@ func BuildDescendantChart(t *tree.Tree, startPerson *model.Person, detail int, d
 			familyDetailFn = familyWhereDetails
 		}
 	default:
-		return nil, fmt.Errorf("unsupported detail level: %d", detail)
+		return nil, nil, fmt.Errorf("unsupported detail level: %d", detail)
 	}
 
 	seq := new(sequence)
 	ch := new(gtree.DescendantChart)
 
 	if parents && (!startPerson.Father.IsUnknown() || !startPerson.Mother.IsUnknown()) {
-		f := &gtree.DescendantPerson{ID: seq.next()}
+		f := &gtree.DescendantPerson{}
 		tf := &gtree.DescendantFamily{}
 		if !startPerson.Father.IsUnknown() {
+			f.ID = seq.nextFor(startPerson.Father)
 			headings, details := personDetailFn(startPerson.Father, true, compact, excludeSingleSpouse(startPerson.Mother))
 			f.Headings = headings
 			f.Details = details
 
 			if !startPerson.Mother.IsUnknown() {
 				oh, od := personDetailFn(startPerson.Mother, true, compact, excludeSingleSpouse(startPerson.Father))
-				tf.Other = &gtree.DescendantPerson{ID: seq.next(), Headings: oh, Details: od}
+				tf.Other = &gtree.DescendantPerson{ID: seq.nextFor(startPerson.Mother), Headings: oh, Details: od}
 			}
 		} else {
+			f.ID = seq.nextFor(startPerson.Mother)
 			headings, details := personDetailFn(startPerson.Mother, true, compact, includeAllSpouses())
 			f.Headings = headings
 			f.Details = details
@@ -252,10 +254,10 @@ func BuildDescendantChart(t *tree.Tree, startPerson *model.Person, detail int, d
 		ch.Root = descendants(startPerson, seq, depth, children, compact, personDetailFn, familyDetailFn)
 	}
 
-	return ch, nil
+	return ch, seq.people, nil
 }
 
-func BuildAncestorChart(t *tree.Tree, startPerson *model.Person, detail int, depth int, compact bool) (*gtree.AncestorChart, error) {
+func BuildAncestorChart(t *tree.Tree, startPerson *model.Person, detail int, depth int, compact bool) (*gtree.AncestorChart, ChartPeople, error) {
 	var personDetailFn func(*model.Person, int) []string
 	switch detail {
 	case 0:
@@ -310,13 +312,14 @@ func BuildAncestorChart(t *tree.Tree, startPerson *model.Person, detail int, dep
 			return details
 		}
 	default:
-		return nil, fmt.Errorf("unsupported detail level: %d", detail)
+		return nil, nil, fmt.Errorf("unsupported detail level: %d", detail)
 
 	}
 
 	ch := new(gtree.AncestorChart)
-	ch.Root = ancestors(startPerson, new(sequence), 1, depth+1, personDetailFn)
-	return ch, nil
+	seq := new(sequence)
+	ch.Root = ancestors(startPerson, seq, 1, depth+1, personDetailFn)
+	return ch, seq.people, nil
 }
 
 func BuildButterflyChart(t *tree.Tree, startPerson *model.Person) (*gtree.ButterflyChart, error) {
@@ -331,7 +334,7 @@ func BuildFanChart(t *tree.Tree, startPerson *model.Person, maxGeneration int) (
 	return ch, nil
 }
 
-func BuildFocusChart(t *tree.Tree, startPerson *model.Person, detail int, minimalSurnames bool, showStars bool) (*gtree.DescendantChart, error) {
+func BuildFocusChart(t *tree.Tree, startPerson *model.Person, detail int, minimalSurnames bool, showStars bool) (*gtree.DescendantChart, ChartPeople, error) {
 	var personDetailFn personDetailFunc
 	var familyDetailFn familyDetailFunc
 
@@ -402,7 +405,7 @@ func BuildFocusChart(t *tree.Tree, startPerson *model.Person, detail int, minima
 		}
 		familyDetailFn = familyWhereDetails
 	default:
-		return nil, fmt.Errorf("unsupported detail level: %d", detail)
+		return nil, nil, fmt.Errorf("unsupported detail level: %d", detail)
 	}
 
 	seq := new(sequence)
@@ -464,5 +467,5 @@ func BuildFocusChart(t *tree.Tree, startPerson *model.Person, detail int, minima
 		}
 	}
 
-	return ch, nil
+	return ch, seq.people, nil
 }
@@ -0,0 +1,355 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/iand/gtree"
+)
+
+// ptPerPixel converts layout pixels (1/96 inch) to PDF points (1/72 inch).
+const ptPerPixel = 72.0 / layoutDPI
+
+// ptPerMM converts millimetres to PDF points.
+const ptPerMM = 72 / 25.4
+
+// pdfPageMargin is the unprinted border left around each page, in millimetres,
+// since most printers cannot print to the edge of the paper.
+const pdfPageMargin = 10
+
+// A Paper is a physical paper size used when printing charts.
+type Paper struct {
+	Name   string
+	Width  float64 // width in millimetres
+	Height float64 // height in millimetres
+}
+
+// Landscape returns the paper rotated so that its longest edge is horizontal.
+func (p Paper) Landscape() Paper {
+	if p.Width > p.Height {
+		return p
+	}
+	return Paper{Name: p.Name + "Landscape", Width: p.Height, Height: p.Width}
+}
+
+var isoPapers = []Paper{
+	{Name: "A0", Width: 841, Height: 1189},
+	{Name: "A1", Width: 594, Height: 841},
+	{Name: "A2", Width: 420, Height: 594},
+	{Name: "A3", Width: 297, Height: 420},
+	{Name: "A4", Width: 210, Height: 297},
+}
+
+// LookupPaper finds the paper size with the given name. Names are an ISO A series size
+// from A0 to A4, optionally followed by Portrait or Landscape, such as A4 or A3Landscape.
+// Portrait is assumed if no orientation is given.
+func LookupPaper(name string) (Paper, bool) {
+	for _, p := range isoPapers {
+		switch name {
+		case p.Name, p.Name + "Portrait":
+			return p, true
+		case p.Name + "Landscape":
+			return p.Landscape(), true
+		}
+	}
+	return Paper{}, false
+}
+
+// A tile is the region of a chart printed on a single page, in points measured from the
+// top left of the chart.
+type tile struct {
+	Row, Col int
+	X, Y     float64
+}
+
+// tileChart divides a chart of the given size into tiles that fit within the printable
+// area of a page. Adjacent tiles share a strip of the chart of the given overlap to allow
+// the printed pages to be aligned and pasted together. All sizes are in points.
+func tileChart(chartWidth, chartHeight, areaWidth, areaHeight, overlap float64) (int, int, []tile) {
+	cols := tileCount(chartWidth, areaWidth, overlap)
+	rows := tileCount(chartHeight, areaHeight, overlap)
+
+	tiles := make([]tile, 0, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			tiles = append(tiles, tile{
+				Row: r,
+				Col: c,
+				X:   float64(c) * (areaWidth - overlap),
+				Y:   float64(r) * (areaHeight - overlap),
+			})
+		}
+	}
+	return rows, cols, tiles
+}
+
+func tileCount(length, area, overlap float64) int {
+	if length <= area || area <= overlap {
+		return 1
+	}
+	return int(math.Ceil((length - overlap) / (area - overlap)))
+}
+
+// PDF renders lay as a PDF document. When the chart is larger than the printable area of
+// the paper it is split across multiple pages at its natural size. Neighbouring pages
+// overlap by the given number of millimetres and carry alignment marks so that they can
+// be assembled into a wall chart.
+func PDF(lay gtree.Layout, paper Paper, overlapMM float64) ([]byte, error) {
+	chartWidth := float64(lay.Width()) * ptPerPixel
+	chartHeight := float64(lay.Height()) * ptPerPixel
+	if chartWidth <= 0 || chartHeight <= 0 {
+		return nil, fmt.Errorf("chart has no area to draw")
+	}
+
+	pageWidth := paper.Width * ptPerMM
+	pageHeight := paper.Height * ptPerMM
+	margin := pdfPageMargin * ptPerMM
+	areaWidth := pageWidth - 2*margin
+	areaHeight := pageHeight - 2*margin
+	overlap := overlapMM * ptPerMM
+	if overlap < 0 || overlap >= areaWidth/2 || overlap >= areaHeight/2 {
+		return nil, fmt.Errorf("overlap of %gmm is too large for %s paper", overlapMM, paper.Name)
+	}
+
+	// The chart is drawn once into a form that is then placed on each page
+	form := &pdfCanvas{height: chartHeight}
+	drawLayout(form, lay)
+
+	rows, cols, tiles := tileChart(chartWidth, chartHeight, areaWidth, areaHeight, overlap)
+
+	w := &pdfWriter{}
+	w.begin()
+
+	const (
+		catalogObj = 1
+		pagesObj   = 2
+		fontObj    = 3
+		formObj    = 4
+		firstPage  = 5
+	)
+
+	w.object(catalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj))
+
+	kids := make([]string, len(tiles))
+	for i := range tiles {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	w.object(pagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(tiles)))
+	w.object(fontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	w.stream(formObj, fmt.Sprintf("/Type /XObject /Subtype /Form /BBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R >> >>", pdfNum(chartWidth), pdfNum(chartHeight), fontObj), form.buf.Bytes())
+
+	for i, t := range tiles {
+		content := new(bytes.Buffer)
+
+		// Place the chart so that the tile's region falls within the printable area
+		tx := margin - t.X
+		ty := pageHeight - margin - chartHeight + t.Y
+		fmt.Fprintf(content, "q %s %s %s %s re W n 1 0 0 1 %s %s cm /Chart Do Q\n", pdfNum(margin), pdfNum(margin), pdfNum(areaWidth), pdfNum(areaHeight), pdfNum(tx), pdfNum(ty))
+
+		if len(tiles) > 1 {
+			writeTileMarks(content, t, rows, cols, pageWidth, pageHeight, margin, overlap)
+		}
+
+		pageObj := firstPage + 2*i
+		w.object(pageObj, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R >> /XObject << /Chart %d 0 R >> >> /Contents %d 0 R >>", pagesObj, pdfNum(pageWidth), pdfNum(pageHeight), fontObj, formObj, pageObj+1))
+		w.stream(pageObj+1, "", content.Bytes())
+	}
+
+	return w.finish(catalogObj), nil
+}
+
+// writeTileMarks adds crop marks at the corners of the printable area, alignment marks
+// where the overlap with the next page begins and a label identifying the page's position
+// in the assembled chart.
+func writeTileMarks(buf *bytes.Buffer, t tile, rows, cols int, pageWidth, pageHeight, margin, overlap float64) {
+	const markLen = 12
+	left, right := margin, pageWidth-margin
+	bottom, top := margin, pageHeight-margin
+
+	fmt.Fprintln(buf, "q 0.5 w 0 G")
+	for _, x := range []float64{left, right} {
+		for _, y := range []float64{bottom, top} {
+			dx, dy := markLen, markLen
+			if x == left {
+				dx = -markLen
+			}
+			if y == bottom {
+				dy = -markLen
+			}
+			fmt.Fprintf(buf, "%s %s m %s %s l S\n", pdfNum(x), pdfNum(y), pdfNum(x+float64(dx)), pdfNum(y))
+			fmt.Fprintf(buf, "%s %s m %s %s l S\n", pdfNum(x), pdfNum(y), pdfNum(x), pdfNum(y+float64(dy)))
+		}
+	}
+
+	// The overlap begins where the next page's printable area starts. Marks are drawn
+	// in the margin so they remain visible when the page is trimmed for pasting.
+	if t.Col < cols-1 {
+		x := right - overlap
+		fmt.Fprintf(buf, "%s %s m %s %s l S\n", pdfNum(x), pdfNum(top), pdfNum(x), pdfNum(top+markLen))
+		fmt.Fprintf(buf, "%s %s m %s %s l S\n", pdfNum(x), pdfNum(bottom), pdfNum(x), pdfNum(bottom-markLen))
+	}
+	if t.Row < rows-1 {
+		y := bottom + overlap
+		fmt.Fprintf(buf, "%s %s m %s %s l S\n", pdfNum(left), pdfNum(y), pdfNum(left-markLen), pdfNum(y))
+		fmt.Fprintf(buf, "%s %s m %s %s l S\n", pdfNum(right), pdfNum(y), pdfNum(right+markLen), pdfNum(y))
+	}
+	fmt.Fprintln(buf, "Q")
+
+	label := fmt.Sprintf("Row %d of %d, column %d of %d", t.Row+1, rows, t.Col+1, cols)
+	fmt.Fprintf(buf, "BT /F1 7 Tf 0.4 g %s %s Td (%s) Tj ET\n", pdfNum(left+markLen+4), pdfNum(bottom-9), pdfString(label))
+}
+
+// pdfCanvas draws into a PDF content stream. The PDF coordinate system has its origin at
+// the bottom left so vertical positions are flipped using the height of the chart.
+type pdfCanvas struct {
+	buf    bytes.Buffer
+	height float64 // height of the chart in points
+}
+
+var _ canvas = (*pdfCanvas)(nil)
+
+func (c *pdfCanvas) Polyline(pts []gtree.Point, width float64) {
+	fmt.Fprintf(&c.buf, "%s w 0 G 0 J 0 j", pdfNum(width*ptPerPixel))
+	for i, p := range pts {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(&c.buf, " %s %s %s", pdfNum(float64(p.X)*ptPerPixel), pdfNum(c.height-float64(p.Y)*ptPerPixel), op)
+	}
+	fmt.Fprintln(&c.buf, " S")
+}
+
+func (c *pdfCanvas) Rect(x, y, w, h float64, fill color.Color) {
+	r, g, b, _ := fill.RGBA()
+	fmt.Fprintf(&c.buf, "%s %s %s rg %s %s %s %s re f\n", pdfNum(float64(r)/0xffff), pdfNum(float64(g)/0xffff), pdfNum(float64(b)/0xffff), pdfNum(x*ptPerPixel), pdfNum(c.height-(y+h)*ptPerPixel), pdfNum(w*ptPerPixel), pdfNum(h*ptPerPixel))
+}
+
+func (c *pdfCanvas) Text(x, y float64, size float64, col color.Color, anchor textAnchor, s string) {
+	if s == "" {
+		return
+	}
+	size *= ptPerPixel
+	px := x * ptPerPixel
+	if anchor == anchorMiddle {
+		px -= helveticaWidth(s) * size / 2
+	}
+	r, g, b, _ := col.RGBA()
+	fmt.Fprintf(&c.buf, "BT /F1 %s Tf %s %s %s rg %s %s Td (%s) Tj ET\n", pdfNum(size), pdfNum(float64(r)/0xffff), pdfNum(float64(g)/0xffff), pdfNum(float64(b)/0xffff), pdfNum(px), pdfNum(c.height-y*ptPerPixel), pdfString(s))
+}
+
+// pdfWriter assembles the objects of a PDF file and tracks their offsets for the
+// cross reference table.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (w *pdfWriter) begin() {
+	w.offsets = make(map[int]int)
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+}
+
+func (w *pdfWriter) object(n int, body string) {
+	w.offsets[n] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", n, body)
+}
+
+func (w *pdfWriter) stream(n int, dict string, data []byte) {
+	w.offsets[n] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< %s /Length %d >>\nstream\n", n, dict, len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *pdfWriter) finish(root int) []byte {
+	xref := w.buf.Len()
+	size := len(w.offsets) + 1
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for i := 1; i < size; i++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[i])
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, root, xref)
+	return w.buf.Bytes()
+}
+
+// pdfNum formats a number compactly for use in a PDF content stream.
+func pdfNum(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// winAnsi maps characters outside Latin-1 that have a place in the WinAnsi encoding
+// used by the standard PDF fonts.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9a, '›': 0x9b, 'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+	'★': '*', // no star in the standard fonts so use the closest substitute
+}
+
+// pdfString encodes s as the contents of a PDF literal string using WinAnsi encoding.
+// Characters that cannot be represented are replaced by a question mark.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		var c byte
+		switch {
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			c = byte(r)
+		default:
+			var ok bool
+			c, ok = winAnsi[r]
+			if !ok {
+				c = '?'
+			}
+		}
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c >= 0x80:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// helveticaWidths holds the advance widths of the printable ASCII characters in the
+// standard Helvetica font, in thousandths of an em, starting from the space character.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space to /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556, // 0 to ?
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778, // @ to O
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556, // P to _
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556, // ` to o
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584, // p to ~
+}
+
+// helveticaWidth returns the width of s in ems when set in Helvetica. Characters outside
+// printable ASCII are assumed to have the width of a digit, apart from dashes.
+func helveticaWidth(s string) float64 {
+	w := 0
+	for _, r := range s {
+		switch {
+		case r >= ' ' && r <= '~':
+			w += helveticaWidths[r-' ']
+		case r == '—':
+			w += 1000
+		default:
+			w += 556
+		}
+	}
+	return float64(w) / 1000
+}
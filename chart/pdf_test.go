@@ -0,0 +1,180 @@
+package chart
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/iand/gtree"
+)
+
+func TestLookupPaper(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		ok     bool
+		width  float64
+		height float64
+	}{
+		{name: "A4", ok: true, width: 210, height: 297},
+		{name: "A4Portrait", ok: true, width: 210, height: 297},
+		{name: "A4Landscape", ok: true, width: 297, height: 210},
+		{name: "A0Landscape", ok: true, width: 1189, height: 841},
+		{name: "A5", ok: false},
+		{name: "a4", ok: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := LookupPaper(tt.name)
+			if ok != tt.ok {
+				t.Fatalf("got ok=%v, wanted %v", ok, tt.ok)
+			}
+			if p.Width != tt.width || p.Height != tt.height {
+				t.Errorf("got %gx%g, wanted %gx%g", p.Width, p.Height, tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestTileChart(t *testing.T) {
+	for _, tt := range []struct {
+		name                    string
+		chartWidth, chartHeight float64
+		rows, cols              int
+	}{
+		{name: "fits", chartWidth: 400, chartHeight: 300, rows: 1, cols: 1},
+		{name: "exact", chartWidth: 500, chartHeight: 700, rows: 1, cols: 1},
+		{name: "wide", chartWidth: 1000, chartHeight: 300, rows: 1, cols: 3},
+		{name: "tall", chartWidth: 400, chartHeight: 1300, rows: 2, cols: 1},
+		{name: "just over", chartWidth: 501, chartHeight: 701, rows: 2, cols: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			const areaWidth, areaHeight, overlap = 500, 700, 50
+			rows, cols, tiles := tileChart(tt.chartWidth, tt.chartHeight, areaWidth, areaHeight, overlap)
+			if rows != tt.rows || cols != tt.cols {
+				t.Fatalf("got %d rows and %d cols, wanted %d rows and %d cols", rows, cols, tt.rows, tt.cols)
+			}
+			if len(tiles) != rows*cols {
+				t.Fatalf("got %d tiles, wanted %d", len(tiles), rows*cols)
+			}
+
+			// the last tile must reach the far edges of the chart
+			last := tiles[len(tiles)-1]
+			if last.X+areaWidth < tt.chartWidth || last.Y+areaHeight < tt.chartHeight {
+				t.Errorf("last tile at %g,%g does not cover chart", last.X, last.Y)
+			}
+		})
+	}
+}
+
+type testLayout struct {
+	width, height gtree.Pixel
+}
+
+func (l *testLayout) Width() gtree.Pixel  { return l.width }
+func (l *testLayout) Height() gtree.Pixel { return l.height }
+func (l *testLayout) Margin() gtree.Pixel { return 10 }
+func (l *testLayout) Debug() bool         { return false }
+
+func (l *testLayout) Title() gtree.TextElement {
+	return gtree.TextElement{Text: "Descendants of (John) Smith", Style: gtree.TextStyle{FontSize: 20, LineHeight: 24}}
+}
+
+func (l *testLayout) Notes() []gtree.TextElement {
+	return []gtree.TextElement{{Text: "★ denotes a direct ancestor", Style: gtree.TextStyle{FontSize: 10, LineHeight: 12}}}
+}
+
+func (l *testLayout) Blurbs() []*gtree.Blurb {
+	return []*gtree.Blurb{
+		{
+			HeadingTexts:        gtree.TextSection{Lines: []string{"John Smith"}, Style: gtree.TextStyle{FontSize: 12, LineHeight: 14, Color: "#000"}},
+			DetailTexts:         gtree.TextSection{Lines: []string{"1801–1870"}, Style: gtree.TextStyle{FontSize: 10, LineHeight: 12, Color: "#444444"}},
+			AbsolutePositioning: true,
+			LeftPos:             20,
+			TopPos:              60,
+			Width:               100,
+			Height:              30,
+			CentreText:          true,
+		},
+	}
+}
+
+func (l *testLayout) Connectors() []*gtree.Connector {
+	return []*gtree.Connector{{Points: []gtree.Point{{X: 70, Y: 90}, {X: 70, Y: 120}, {X: 300, Y: 120}}}}
+}
+
+func TestPDF(t *testing.T) {
+	paper, _ := LookupPaper("A4")
+
+	single, err := PDF(&testLayout{width: 400, height: 300}, paper, 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(single, []byte("%PDF-")) {
+		t.Fatalf("output is not a pdf")
+	}
+	if got := bytes.Count(single, []byte("/Type /Page ")); got != 1 {
+		t.Errorf("got %d pages, wanted 1", got)
+	}
+	if !bytes.Contains(single, []byte(`Descendants of \(John\) Smith`)) {
+		t.Errorf("title not escaped in output")
+	}
+
+	// An A4 page has a printable area of 190x277mm, around 718x1047 pixels
+	tiled, err := PDF(&testLayout{width: 2000, height: 1200}, paper, 15)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := bytes.Count(tiled, []byte("/Type /Page ")); got != 6 {
+		t.Errorf("got %d pages, wanted 6", got)
+	}
+	if !bytes.Contains(tiled, []byte("Row 2 of 2, column 3 of 3")) {
+		t.Errorf("missing page label")
+	}
+
+	if _, err := PDF(&testLayout{width: 400, height: 300}, paper, 120); err == nil {
+		t.Errorf("expected error for oversized overlap")
+	}
+}
+
+func TestPNG(t *testing.T) {
+	data, err := PNG(&testLayout{width: 400, height: 300}, 192, color.White)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode png: %v", err)
+	}
+	if got := img.Bounds().Dx(); got != 800 {
+		t.Errorf("got width %d, wanted 800", got)
+	}
+	if got := img.Bounds().Dy(); got != 600 {
+		t.Errorf("got height %d, wanted 600", got)
+	}
+}
+
+func TestPNGBackground(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		background color.Color
+		want       color.RGBA
+	}{
+		{name: "white", background: color.White, want: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}},
+		{name: "colour", background: color.RGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff}, want: color.RGBA{R: 0x33, G: 0x66, B: 0x99, A: 0xff}},
+		{name: "transparent", background: nil, want: color.RGBA{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := PNG(&testLayout{width: 40, height: 30}, layoutDPI, tt.background)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			img, err := png.Decode(bytes.NewReader(data))
+			if err != nil {
+				t.Fatalf("decode png: %v", err)
+			}
+			if got := color.RGBAModel.Convert(img.At(0, 0)); got != tt.want {
+				t.Errorf("got corner colour %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
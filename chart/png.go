@@ -0,0 +1,150 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"github.com/iand/gtree"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// layoutDPI is the resolution assumed by chart layouts, which are measured in CSS pixels.
+const layoutDPI = 96
+
+// PNG rasterises lay into a PNG image at the requested resolution, drawn on the
+// background colour or on a transparent background if background is nil.
+func PNG(lay gtree.Layout, dpi float64, background color.Color) ([]byte, error) {
+	if dpi <= 0 {
+		dpi = layoutDPI
+	}
+	scale := dpi / layoutDPI
+
+	w := int(math.Ceil(float64(lay.Width()) * scale))
+	h := int(math.Ceil(float64(lay.Height()) * scale))
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("chart has no area to draw")
+	}
+
+	fnt, err := opentype.Parse(goregular.TTF)
+	if err != nil {
+		return nil, fmt.Errorf("parse font: %w", err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	if background != nil {
+		draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	}
+
+	c := &pngCanvas{
+		img:   img,
+		scale: scale,
+		font:  fnt,
+		faces: make(map[float64]font.Face),
+	}
+	defer c.close()
+
+	drawLayout(c, lay)
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+type pngCanvas struct {
+	img   *image.RGBA
+	scale float64
+	font  *opentype.Font
+	faces map[float64]font.Face // faces by pixel size
+}
+
+var _ canvas = (*pngCanvas)(nil)
+
+func (c *pngCanvas) close() {
+	for _, f := range c.faces {
+		f.Close()
+	}
+}
+
+func (c *pngCanvas) face(size float64) (font.Face, error) {
+	if f, ok := c.faces[size]; ok {
+		return f, nil
+	}
+	// At 72 DPI one point is one pixel, so the size can be used directly
+	f, err := opentype.NewFace(c.font, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.faces[size] = f
+	return f, nil
+}
+
+func (c *pngCanvas) Polyline(pts []gtree.Point, width float64) {
+	hw := width * c.scale / 2
+	r := vector.NewRasterizer(c.img.Bounds().Dx(), c.img.Bounds().Dy())
+	for i := 1; i < len(pts); i++ {
+		x0, y0 := float64(pts[i-1].X)*c.scale, float64(pts[i-1].Y)*c.scale
+		x1, y1 := float64(pts[i].X)*c.scale, float64(pts[i].Y)*c.scale
+		dx, dy := x1-x0, y1-y0
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			continue
+		}
+		// unit vectors along and across the segment, extended by half the width
+		// at each end so that consecutive segments join without a notch
+		ux, uy := dx/l*hw, dy/l*hw
+		nx, ny := -uy, ux
+		x0, y0 = x0-ux, y0-uy
+		x1, y1 = x1+ux, y1+uy
+
+		r.MoveTo(float32(x0+nx), float32(y0+ny))
+		r.LineTo(float32(x1+nx), float32(y1+ny))
+		r.LineTo(float32(x1-nx), float32(y1-ny))
+		r.LineTo(float32(x0-nx), float32(y0-ny))
+		r.ClosePath()
+	}
+	r.Draw(c.img, c.img.Bounds(), image.Black, image.Point{})
+}
+
+func (c *pngCanvas) Rect(x, y, w, h float64, fill color.Color) {
+	r := image.Rect(int(math.Round(x*c.scale)), int(math.Round(y*c.scale)), int(math.Round((x+w)*c.scale)), int(math.Round((y+h)*c.scale)))
+	draw.Draw(c.img, r, image.NewUniform(fill), image.Point{}, draw.Over)
+}
+
+func (c *pngCanvas) Text(x, y float64, size float64, col color.Color, anchor textAnchor, s string) {
+	if s == "" {
+		return
+	}
+	f, err := c.face(size * c.scale)
+	if err != nil {
+		return
+	}
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(col),
+		Face: f,
+	}
+	px := x * c.scale
+	if anchor == anchorMiddle {
+		px -= float64(d.MeasureString(s)) / 64 / 2
+	}
+	d.Dot = fixed.Point26_6{
+		X: fixed.Int26_6(px * 64),
+		Y: fixed.Int26_6(y * c.scale * 64),
+	}
+	d.DrawString(s)
+}
@@ -0,0 +1,122 @@
+package chart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/iand/gtree"
+)
+
+// SVG renders lay as an SVG document sized for the paper ps using the renderer in gtree.
+// The gtree renderer cannot draw the fills and legend of layouts produced by ColourLayout
+// so these are added to its output as a group placed ahead of the first element of the
+// chart, sharing its coordinate system and sitting beneath its text and lines.
+func SVG(lay gtree.Layout, ps *gtree.PaperSize) (string, error) {
+	svg, err := gtree.SVG(lay, ps)
+	if err != nil {
+		return "", err
+	}
+
+	cl, ok := lay.(*colouredLayout)
+	if !ok {
+		return svg, nil
+	}
+
+	c := new(svgCanvas)
+	fmt.Fprintln(&c.buf, `<g class="colouring">`)
+	drawFills(c, cl)
+	drawLegend(c, cl)
+	fmt.Fprintln(&c.buf, "</g>")
+
+	off, err := chartContentOffset(svg)
+	if err != nil {
+		return "", err
+	}
+	return svg[:off] + c.buf.String() + svg[off:], nil
+}
+
+// chartContentOffset parses the SVG document svg and returns the offset of the first
+// text or path element, which is where the content of the chart begins. If there is no
+// such element the offset of the end tag of the root svg element is returned.
+func chartContentOffset(svg string) (int, error) {
+	dec := xml.NewDecoder(strings.NewReader(svg))
+	depth := 0
+	for {
+		off := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return 0, fmt.Errorf("chart SVG has no root svg element")
+		}
+		if err != nil {
+			return 0, fmt.Errorf("parse chart SVG: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth == 0 && t.Name.Local != "svg" {
+				return 0, fmt.Errorf("chart SVG has root element %s, wanted svg", t.Name.Local)
+			}
+			if t.Name.Local == "text" || t.Name.Local == "path" {
+				return off, nil
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+			if depth == 0 {
+				return off, nil
+			}
+		}
+	}
+}
+
+type svgCanvas struct {
+	buf bytes.Buffer
+}
+
+var _ canvas = (*svgCanvas)(nil)
+
+func (c *svgCanvas) Polyline(pts []gtree.Point, width float64) {
+	fmt.Fprint(&c.buf, `<path d="`)
+	for i, p := range pts {
+		op := "L"
+		if i == 0 {
+			op = "M"
+		} else {
+			c.buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&c.buf, "%s %s,%s", op, SVGNum(float64(p.X)), SVGNum(float64(p.Y)))
+	}
+	fmt.Fprintf(&c.buf, "\" fill=\"none\" stroke=\"#000000\" stroke-width=\"%s\"/>\n", SVGNum(width))
+}
+
+func (c *svgCanvas) Text(x, y float64, size float64, col color.Color, anchor textAnchor, s string) {
+	if s == "" {
+		return
+	}
+	ta := "start"
+	if anchor == anchorMiddle {
+		ta = "middle"
+	}
+	fmt.Fprintf(&c.buf, "<text x=\"%s\" y=\"%s\" text-anchor=\"%s\" font-size=\"%spx\" fill=\"%s\">%s</text>\n", SVGNum(x), SVGNum(y), ta, SVGNum(size), svgColor(col), html.EscapeString(s))
+}
+
+func (c *svgCanvas) Rect(x, y, w, h float64, fill color.Color) {
+	fmt.Fprintf(&c.buf, "<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"/>\n", SVGNum(x), SVGNum(y), SVGNum(w), SVGNum(h), svgColor(fill))
+}
+
+// SVGNum formats v for use in an SVG attribute, rounded to two decimal places and with
+// no more precision than is needed.
+func SVGNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+func svgColor(col color.Color) string {
+	r, g, b, _ := col.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
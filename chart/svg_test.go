@@ -0,0 +1,59 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChartContentOffset(t *testing.T) {
+	testCases := []struct {
+		name    string
+		svg     string
+		before  string
+		wantErr bool
+	}{
+		{
+			name:   "text",
+			svg:    `<?xml version="1.0"?>` + "\n" + `<svg xmlns="http://www.w3.org/2000/svg"><rect width="100%" fill="white"/><text>a</text><path d="M 0,0"/></svg>`,
+			before: `<text>a</text>`,
+		},
+		{
+			name:   "scaled group",
+			svg:    `<svg><rect/><g transform="scale(0.5)"><!-- <text --><path d="M 0,0"/><text>a</text></g></svg>`,
+			before: `<path d="M 0,0"/>`,
+		},
+		{
+			name:   "empty chart",
+			svg:    `<svg><rect/></svg>`,
+			before: `</svg>`,
+		},
+		{
+			name:    "not svg",
+			svg:     `<html><text>a</text></html>`,
+			wantErr: true,
+		},
+		{
+			name:    "truncated",
+			svg:     `<svg><rect/>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			off, err := chartContentOffset(tc.svg)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("got offset %d, wanted error", off)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(tc.svg[off:], tc.before) {
+				t.Errorf("got offset before %q, wanted before %q", tc.svg[off:], tc.before)
+			}
+		})
+	}
+}
@@ -17,7 +17,7 @@ type (
 func newDescendantPerson(p *model.Person, seq *sequence, personDetailFn personDetailFunc, firstUseOfSurname bool, compact bool, includeSpouse model.PersonMatcher) *gtree.DescendantPerson {
 	headings, details := personDetailFn(p, firstUseOfSurname, compact, includeSpouse)
 
-	return &gtree.DescendantPerson{ID: seq.next(), Headings: headings, Details: details}
+	return &gtree.DescendantPerson{ID: seq.nextFor(p), Headings: headings, Details: details}
 }
 
 func appendDescendantPersonSpouses(details []string, p *model.Person, inclAbbrevDetails bool, compact bool, includeSpouse model.PersonMatcher) []string {
@@ -47,7 +47,7 @@ func appendDescendantPersonSpouses(details []string, p *model.Person, inclAbbrev
 func descendants(p *model.Person, seq *sequence, generations int, children string, compact bool, personDetailFn personDetailFunc, familyDetailFn familyDetailFunc) *gtree.DescendantPerson {
 	tp := newDescendantPerson(p, seq, personDetailFn, seq.n == 0, compact, excludeAllSpouses())
 
-	if children == "all" || p.IsDirectAncestor() {
+	if children == "all" || children == "everyone" || p.IsDirectAncestor() {
 		if generations > 0 {
 			for _, f := range p.Families {
 				tf := new(gtree.DescendantFamily)
@@ -58,12 +58,12 @@ func descendants(p *model.Person, seq *sequence, generations int, children strin
 					o := f.OtherParent(p)
 					if o != nil {
 						oh, od := personDetailFn(o, true, compact, excludeSingleSpouse(p))
-						tf.Other = &gtree.DescendantPerson{ID: seq.next(), Headings: oh, Details: od}
+						tf.Other = &gtree.DescendantPerson{ID: seq.nextFor(o), Headings: oh, Details: od}
 					}
 				}
 				// TODO: sort by date
 				for _, c := range f.Children {
-					if c.IsDirectAncestor() || children == "direct" {
+					if c.IsDirectAncestor() || children == "direct" || children == "everyone" {
 						tf.Children = append(tf.Children, descendants(c, seq, generations-1, children, compact, personDetailFn, familyDetailFn))
 					}
 				}
@@ -77,7 +77,7 @@ func descendants(p *model.Person, seq *sequence, generations int, children strin
 					tf.Details = familyDetailFn(f)
 					tp.Families = append(tp.Families, tf)
 					oh, od := personDetailFn(f.OtherParent(p), true, compact, excludeSingleSpouse(p))
-					tf.Other = &gtree.DescendantPerson{ID: seq.next(), Headings: oh, Details: od}
+					tf.Other = &gtree.DescendantPerson{ID: seq.nextFor(f.OtherParent(p)), Headings: oh, Details: od}
 					break
 				}
 			}
@@ -88,7 +88,7 @@ func descendants(p *model.Person, seq *sequence, generations int, children strin
 }
 
 func ancestors(p *model.Person, seq *sequence, generation int, maxGeneration int, personDetailFn func(*model.Person, int) []string) *gtree.AncestorPerson {
-	tp := &gtree.AncestorPerson{ID: seq.next(), Headings: []string{p.PreferredFullName}, Details: personDetailFn(p, generation)}
+	tp := &gtree.AncestorPerson{ID: seq.nextFor(p), Headings: []string{p.PreferredFullName}, Details: personDetailFn(p, generation)}
 	if generation < maxGeneration {
 		if p.Father != nil {
 			tp.Father = ancestors(p.Father, seq, generation+1, maxGeneration, personDetailFn)
@@ -0,0 +1,445 @@
+// Package dateparse recognises dates written in forms that the gdate parser
+// does not understand: Quaker dates such as "3rd day of 1st month 1702",
+// regnal years such as "5 Geo III" and feast days such as "Michaelmas 1745".
+package dateparse
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iand/gdate"
+	"github.com/iand/genster/fact"
+	"github.com/iand/genster/model"
+)
+
+// A Parser converts dates written in Quaker, regnal or feast day form into
+// model dates that keep the original wording.
+type Parser struct {
+	// ReckoningLocation is used to choose the calendar in use in the year of
+	// the date. These forms are English usage so ReckoningLocationNone is
+	// treated as England and Wales.
+	ReckoningLocation gdate.ReckoningLocation
+}
+
+type matcher struct {
+	re      *regexp.Regexp
+	convert func(p *Parser, m []string) (gdate.Date, bool)
+}
+
+var matchers = []matcher{
+	{re: reQuakerDayMonth, convert: (*Parser).quakerDayMonth},
+	{re: reQuakerMonthDay, convert: (*Parser).quakerMonthDay},
+	{re: reQuakerMonth, convert: (*Parser).quakerMonth},
+	{re: reRegnal, convert: (*Parser).regnal},
+	{re: reFeast, convert: (*Parser).feast},
+}
+
+// Parse converts s to a date if it consists of a single date in one of the
+// recognised forms.
+func (p *Parser) Parse(s string) (*model.Date, bool) {
+	s = strings.TrimRight(strings.TrimSpace(s), ",;")
+	// a trailing full stop may be part of an abbreviation such as "3 Ann."
+	for _, text := range []string{s, strings.TrimRight(s, ".,;")} {
+		for _, mt := range matchers {
+			loc := mt.re.FindStringSubmatchIndex(text)
+			if loc == nil || loc[0] != 0 || loc[1] != len(text) {
+				continue
+			}
+			if dt, ok := mt.convert(p, submatches(text, loc)); ok {
+				return &model.Date{Date: dt, Original: text}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Find searches text, such as an event description or citation detail, for
+// the first date written in one of the recognised forms.
+func (p *Parser) Find(text string) (*model.Date, bool) {
+	var best *model.Date
+	bestStart, bestEnd := -1, -1
+	for _, mt := range matchers {
+		for _, loc := range mt.re.FindAllStringSubmatchIndex(text, -1) {
+			if bestStart != -1 && (loc[0] > bestStart || (loc[0] == bestStart && loc[1] <= bestEnd)) {
+				break
+			}
+			dt, ok := mt.convert(p, submatches(text, loc))
+			if !ok {
+				continue
+			}
+			best = &model.Date{Date: dt, Original: strings.TrimSpace(text[loc[0]:loc[1]])}
+			bestStart, bestEnd = loc[0], loc[1]
+			break
+		}
+	}
+	return best, best != nil
+}
+
+// EventDate returns the date of an event when it was written in one of the
+// recognised forms. The date text s is used if it is such a date. If the
+// loader could not parse s, leaving dt unknown, the detail of the event and
+// the details of its citations are searched instead.
+func (p *Parser) EventDate(s string, dt *model.Date, detail string, cits []*model.GeneralCitation) (*model.Date, bool) {
+	if wd, ok := p.Parse(s); ok {
+		return wd, true
+	}
+	if !dt.IsUnknown() {
+		return nil, false
+	}
+	texts := []string{s, detail}
+	for _, c := range cits {
+		texts = append(texts, c.Detail)
+	}
+	for _, text := range texts {
+		if wd, ok := p.Find(text); ok {
+			return wd, true
+		}
+	}
+	return nil, false
+}
+
+func submatches(s string, loc []int) []string {
+	m := make([]string, len(loc)/2)
+	for i := range m {
+		if loc[2*i] >= 0 {
+			m[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return m
+}
+
+// calendar returns the calendar in use in year y, which begins on 1 January.
+func (p *Parser) calendar(y int) gdate.Calendar {
+	loc := p.ReckoningLocation
+	if loc == gdate.ReckoningLocationNone {
+		loc = gdate.ReckoningLocationEnglandAndWales
+	}
+	return loc.Calendar(y)
+}
+
+// precise returns a date in year y, which begins on 1 January, with the year
+// as it would have been written at the time.
+func (p *Parser) precise(y, m, d int) *gdate.Precise {
+	c := p.calendar(y)
+	if c == gdate.Julian25Mar && beforeLadyDay(m, d) {
+		y--
+	}
+	return &gdate.Precise{C: c, Y: y, M: m, D: d}
+}
+
+func beforeLadyDay(m, d int) bool {
+	return m < 3 || (m == 3 && d < 25)
+}
+
+func validDay(c gdate.Calendar, y, m, d int) bool {
+	return m >= 1 && m <= 12 && d >= 1 && d <= fact.MonthLength(c, y, m)
+}
+
+const ordinalSuffix = `(?:st|nd|rd|th|d)?`
+
+var ordinalMonths = []string{"first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth", "eleventh", "twelfth"}
+
+// quakerMonthNumber is a month number in digits or in words
+var quakerMonthNumber = `(\d{1,2}|` + strings.Join(ordinalMonths, "|") + `)` + ordinalSuffix
+
+var (
+	// 3rd day of 1st month 1702, 3d 1st mo. 1702, 3.1mo.1702
+	reQuakerDayMonth = regexp.MustCompile(`(?i)\b(\d{1,2})` + ordinalSuffix + `(?:\s+day)?(?:\s+of)?(?:\s+the)?(?:\s+|\s*\.\s*)` + quakerMonthNumber + `\s*(?:month|mo\b\.?)\s*,?\s*(?:of\s+)?(?:the\s+year\s+)?(\d{4})\b`)
+
+	// 1st month 3rd day 1702
+	reQuakerMonthDay = regexp.MustCompile(`(?i)\b` + quakerMonthNumber + `\s*(?:month|mo\b\.?)\s*,?\s*(?:the\s+)?(\d{1,2})` + ordinalSuffix + `\s+day\s*,?\s*(?:of\s+)?(\d{4})\b`)
+
+	// 12th month 1701
+	reQuakerMonth = regexp.MustCompile(`(?i)\b` + quakerMonthNumber + `\s*(?:month|mo\b\.?)\s*,?\s*(?:of\s+)?(\d{4})\b`)
+)
+
+func quakerMonthValue(s string) int {
+	s = strings.ToLower(s)
+	for i, w := range ordinalMonths {
+		if s == w {
+			return i + 1
+		}
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// quakerMonthOf returns the calendar month of the nth month of the year y as
+// numbered by Quakers. Until the calendar reform of 1752 the first month was
+// March, after which it was January.
+func quakerMonthOf(n, y int) int {
+	if y < 1752 {
+		return (n+1)%12 + 1
+	}
+	return n
+}
+
+func (p *Parser) quakerDayMonth(m []string) (gdate.Date, bool) {
+	return p.quaker(m[1], m[2], m[3])
+}
+
+func (p *Parser) quakerMonthDay(m []string) (gdate.Date, bool) {
+	return p.quaker(m[2], m[1], m[3])
+}
+
+func (p *Parser) quaker(ds, ms, ys string) (gdate.Date, bool) {
+	d, _ := strconv.Atoi(ds)
+	n := quakerMonthValue(ms)
+	y, _ := strconv.Atoi(ys)
+	if n < 1 || n > 12 {
+		return nil, false
+	}
+	mo := quakerMonthOf(n, y)
+	// the written year is kept, so it is already Old Style where that applies
+	c := p.calendar(y)
+	if !validDay(c, y, mo, d) {
+		return nil, false
+	}
+	return &gdate.Precise{C: c, Y: y, M: mo, D: d}, true
+}
+
+func (p *Parser) quakerMonth(m []string) (gdate.Date, bool) {
+	n := quakerMonthValue(m[1])
+	y, _ := strconv.Atoi(m[2])
+	if n < 1 || n > 12 {
+		return nil, false
+	}
+	return &gdate.MonthYear{C: p.calendar(y), Y: y, M: quakerMonthOf(n, y)}, true
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "january": 1,
+	"feb": 2, "february": 2,
+	"mar": 3, "march": 3,
+	"apr": 4, "april": 4,
+	"may": 5,
+	"jun": 6, "june": 6,
+	"jul": 7, "july": 7,
+	"aug": 8, "august": 8,
+	"sep": 9, "sept": 9, "september": 9,
+	"oct": 10, "october": 10,
+	"nov": 11, "november": 11,
+	"dec": 12, "december": 12,
+}
+
+type monarch struct {
+	Y, M, D int // accession, with the year beginning on 1 January
+	Length  int // number of regnal years
+}
+
+// monarchs is keyed by the monarch's name and regnal number, which is omitted
+// for monarchs without one
+var monarchs = map[string]monarch{
+	"Henry VIII":   {Y: 1509, M: 4, D: 22, Length: 38},
+	"Edward VI":    {Y: 1547, M: 1, D: 28, Length: 7},
+	"Elizabeth I":  {Y: 1558, M: 11, D: 17, Length: 45},
+	"James I":      {Y: 1603, M: 3, D: 24, Length: 23},
+	"Charles I":    {Y: 1625, M: 3, D: 27, Length: 24},
+	"Charles II":   {Y: 1649, M: 1, D: 30, Length: 37},
+	"James II":     {Y: 1685, M: 2, D: 6, Length: 4},
+	"William III":  {Y: 1689, M: 2, D: 13, Length: 14},
+	"Anne":         {Y: 1702, M: 3, D: 8, Length: 13},
+	"George I":     {Y: 1714, M: 8, D: 1, Length: 13},
+	"George II":    {Y: 1727, M: 6, D: 11, Length: 34},
+	"George III":   {Y: 1760, M: 10, D: 25, Length: 60},
+	"George IV":    {Y: 1820, M: 1, D: 29, Length: 11},
+	"William IV":   {Y: 1830, M: 6, D: 26, Length: 7},
+	"Victoria":     {Y: 1837, M: 6, D: 20, Length: 64},
+	"Edward VII":   {Y: 1901, M: 1, D: 22, Length: 10},
+	"George V":     {Y: 1910, M: 5, D: 6, Length: 26},
+	"Elizabeth":    {Y: 1558, M: 11, D: 17, Length: 45},
+	"William Mary": {Y: 1689, M: 2, D: 13, Length: 14},
+}
+
+// monarchNames maps the abbreviations used in regnal years to the monarch's name
+var monarchNames = map[string]string{
+	"hen": "Henry", "henry": "Henry",
+	"edw": "Edward", "edward": "Edward",
+	"eliz": "Elizabeth", "elizabeth": "Elizabeth",
+	"jac": "James", "jas": "James", "james": "James",
+	"car": "Charles", "chas": "Charles", "charles": "Charles",
+	"gul": "William", "will": "William", "wm": "William", "william": "William",
+	"ann": "Anne", "anne": "Anne",
+	"geo": "George", "george": "George",
+	"vic": "Victoria", "vict": "Victoria", "victoria": "Victoria",
+}
+
+var regnalNumbers = map[string]string{
+	"i": "I", "1": "I", "1st": "I",
+	"ii": "II", "2": "II", "2nd": "II",
+	"iii": "III", "3": "III", "3rd": "III",
+	"iv": "IV", "4": "IV", "4th": "IV",
+	"v": "V", "5": "V", "5th": "V",
+	"vi": "VI", "6": "VI", "6th": "VI",
+	"vii": "VII", "7": "VII", "7th": "VII",
+	"viii": "VIII", "8": "VIII", "8th": "VIII",
+}
+
+// 5 Geo III, 12 Vict., 12 March 5 Geo. III, 3rd year of the reign of George II, 2 W & M
+var reRegnal = regexp.MustCompile(`\b(?:(\d{1,2})` + ordinalSuffix + `\s+(?i:day\s+of\s+)?(` + monthPattern() + `)\.?,?\s+(?i:in\s+)?(?i:the\s+)?)?` +
+	`(\d{1,2})` + ordinalSuffix + `\s+((?i:year\s+(?:of\s+(?:the\s+)?reign\s+)?of\s+(?:(?:our\s+)?(?:sovereign\s+)?(?:lord|lady)\s+)?(?:(?:king|queen)\s+)?))?` +
+	`(?:(W(?:ill(?:iam)?)?\.?\s*(?:&|and|et)\s*M(?:ary)?\.?)|(Hen(?:ry)?|Edw(?:ard)?|Eliz(?:abeth)?|Jac|Jas|James|Car|Chas|Charles|Gul|Will(?:iam)?|Wm|Anne?|Geo(?:rge)?|Vict?(?:oria)?)\b(\.)?(?:\s+(VIII|VII|VI|IV|V|III|II|I|[1-8](?:st|nd|rd|th)?)\b)?)`)
+
+func monthPattern() string {
+	names := make([]string, 0, len(monthNames))
+	for n := range monthNames {
+		names = append(names, n)
+	}
+	// longest first so that full names are preferred to abbreviations
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	return "(?i:" + strings.Join(names, "|") + ")"
+}
+
+func (p *Parser) regnal(m []string) (gdate.Date, bool) {
+	if !regnalMarked(m) {
+		return nil, false
+	}
+	var key string
+	if m[5] != "" {
+		key = "William Mary"
+	} else {
+		name := monarchNames[strings.ToLower(m[6])]
+		key = name
+		if m[8] != "" {
+			key += " " + regnalNumbers[strings.ToLower(m[8])]
+		}
+	}
+	mon, ok := monarchs[key]
+	if !ok {
+		return nil, false
+	}
+
+	n, _ := strconv.Atoi(m[3])
+	if n < 1 || n > mon.Length {
+		return nil, false
+	}
+	start := mon.Y + n - 1
+
+	if m[1] != "" {
+		d, _ := strconv.Atoi(m[1])
+		mo := monthNames[strings.ToLower(m[2])]
+		y := start
+		if mo < mon.M || (mo == mon.M && d < mon.D) {
+			y++
+		}
+		if !validDay(p.calendar(y), y, mo, d) {
+			return nil, false
+		}
+		return p.precise(y, mo, d), true
+	}
+
+	from := p.precise(start, mon.M, mon.D)
+	ey, em, ed := fact.AddDays(p.calendar(start+1), start+1, mon.M, mon.D, -1)
+	to := p.precise(ey, em, ed)
+	return &gdate.BetweenPrecise{
+		C:          from.C,
+		StartYear:  from.Y,
+		StartMonth: from.M,
+		StartDay:   from.D,
+		EndYear:    to.Y,
+		EndMonth:   to.M,
+		EndDay:     to.D,
+	}, true
+}
+
+// regnalMarked reports whether a regnal year match is written in a form that
+// marks it as a regnal year, so that a number followed by a name, such as
+// "12 Victoria Street" or "2 Elizabeth and John", is not taken for one. The
+// year must be followed by wording such as "year of the reign of", or the
+// monarch's name must be abbreviated with a full stop or followed by a
+// regnal number.
+func regnalMarked(m []string) bool {
+	if m[4] != "" {
+		return true
+	}
+	if m[5] != "" {
+		lower := strings.ToLower(m[5])
+		return !strings.Contains(lower, "william") && !strings.Contains(lower, "mary")
+	}
+	full := monarchNames[strings.ToLower(m[6])] == m[6]
+	if m[8] != "" {
+		// a bare digit after a full name is more likely a count than a regnal number
+		_, err := strconv.Atoi(m[8])
+		return !full || err != nil
+	}
+	return !full && m[7] != ""
+}
+
+// feastNames maps the names of feast days, in lower case with single spaces
+// and without apostrophes, to the day
+var feastNames = map[string]fact.NamedDay{
+	"easter":          fact.NamedDayEasterSunday,
+	"easter day":      fact.NamedDayEasterSunday,
+	"easter sunday":   fact.NamedDayEasterSunday,
+	"christmas":       fact.NamedDayChristmasDay,
+	"christmas day":   fact.NamedDayChristmasDay,
+	"lady day":        fact.NamedDayLadyDay,
+	"annunciation":    fact.NamedDayLadyDay,
+	"michaelmas":      fact.NamedDayMichaelmas,
+	"michaelmas day":  fact.NamedDayMichaelmas,
+	"candlemas":       fact.NamedDayCandlemas,
+	"candlemas day":   fact.NamedDayCandlemas,
+	"epiphany":        fact.NamedDayEpiphany,
+	"all saints":      fact.NamedDayAllSaintsDay,
+	"all saints day":  fact.NamedDayAllSaintsDay,
+	"all hallows":     fact.NamedDayAllSaintsDay,
+	"hallowmas":       fact.NamedDayAllSaintsDay,
+	"maundy thursday": fact.NamedDayMaundyThursday,
+	"palm sunday":     fact.NamedDayPalmSunday,
+	"good friday":     fact.NamedDayGoodFriday,
+	"ash wednesday":   fact.NamedDayAshWednesday,
+	"ascension day":   fact.NamedDayAscensionDay,
+	"holy thursday":   fact.NamedDayAscensionDay,
+	"whitsunday":      fact.NamedDayWhitsunday,
+	"whit sunday":     fact.NamedDayWhitsunday,
+	"whitsun":         fact.NamedDayWhitsunday,
+	"pentecost":       fact.NamedDayWhitsunday,
+	"trinity sunday":  fact.NamedDayTrinitySunday,
+}
+
+// Michaelmas 1745, Lady Day in the year 1720, the feast of All Saints 1699
+var reFeast = regexp.MustCompile(`(?i)\b(?:the\s+feast\s+of\s+)?(` + feastPattern() + `)\b\s*,?\s*(?:in\s+)?(?:the\s+year\s+)?(?:of\s+)?(\d{4})\b`)
+
+func feastPattern() string {
+	names := make([]string, 0, len(feastNames))
+	for n := range feastNames {
+		names = append(names, n)
+	}
+	// longest first so that "easter sunday" is preferred to "easter"
+	sort.Slice(names, func(i, j int) bool {
+		if len(names[i]) != len(names[j]) {
+			return len(names[i]) > len(names[j])
+		}
+		return names[i] < names[j]
+	})
+	for i, n := range names {
+		n = strings.ReplaceAll(n, " ", `\s+`)
+		n = strings.ReplaceAll(n, "saints", `saints['’]?`)
+		names[i] = n
+	}
+	return strings.Join(names, "|")
+}
+
+var reSpaces = regexp.MustCompile(`\s+`)
+
+func (p *Parser) feast(m []string) (gdate.Date, bool) {
+	name := strings.ToLower(reSpaces.ReplaceAllString(m[1], " "))
+	name = strings.NewReplacer("'", "", "’", "").Replace(name)
+	nd, ok := feastNames[name]
+	if !ok {
+		return nil, false
+	}
+	y, _ := strconv.Atoi(m[2])
+	dt, ok := fact.NamedDayDate(nd, y, p.calendar(y))
+	if !ok {
+		return nil, false
+	}
+	return dt, true
+}
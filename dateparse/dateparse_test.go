@@ -0,0 +1,223 @@
+package dateparse
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/gdate"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want gdate.Date
+	}{
+		{
+			in:   "3rd day of 1st month 1702",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1702, M: 3, D: 3},
+		},
+		{
+			in:   "14th day of the 12th month 1701",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1701, M: 2, D: 14},
+		},
+		{
+			in:   "3d 11 mo. 1700",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1700, M: 1, D: 3},
+		},
+		{
+			in:   "3.1mo.1702",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1702, M: 3, D: 3},
+		},
+		{
+			in:   "5th day of First Month 1760",
+			want: &gdate.Precise{C: gdate.Gregorian, Y: 1760, M: 1, D: 5},
+		},
+		{
+			in:   "7th month 22nd day 1690",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1690, M: 9, D: 22},
+		},
+		{
+			in:   "12th month 1701",
+			want: &gdate.MonthYear{C: gdate.Julian25Mar, Y: 1701, M: 2},
+		},
+		{
+			in: "5 Geo III",
+			want: &gdate.BetweenPrecise{
+				C:         gdate.Gregorian,
+				StartYear: 1764, StartMonth: 10, StartDay: 25,
+				EndYear: 1765, EndMonth: 10, EndDay: 24,
+			},
+		},
+		{
+			in: "3 Ann.",
+			want: &gdate.BetweenPrecise{
+				C:         gdate.Julian25Mar,
+				StartYear: 1703, StartMonth: 3, StartDay: 8,
+				EndYear: 1704, EndMonth: 3, EndDay: 7,
+			},
+		},
+		{
+			in: "2 W & M",
+			want: &gdate.BetweenPrecise{
+				C:         gdate.Julian25Mar,
+				StartYear: 1689, StartMonth: 2, StartDay: 13,
+				EndYear: 1690, EndMonth: 2, EndDay: 12,
+			},
+		},
+		{
+			in: "12 Vict.",
+			want: &gdate.BetweenPrecise{
+				C:         gdate.Gregorian,
+				StartYear: 1848, StartMonth: 6, StartDay: 20,
+				EndYear: 1849, EndMonth: 6, EndDay: 19,
+			},
+		},
+		{
+			in: "12 Geo. III",
+			want: &gdate.BetweenPrecise{
+				C:         gdate.Gregorian,
+				StartYear: 1771, StartMonth: 10, StartDay: 25,
+				EndYear: 1772, EndMonth: 10, EndDay: 24,
+			},
+		},
+		{
+			in: "12th year of the reign of Queen Victoria",
+			want: &gdate.BetweenPrecise{
+				C:         gdate.Gregorian,
+				StartYear: 1848, StartMonth: 6, StartDay: 20,
+				EndYear: 1849, EndMonth: 6, EndDay: 19,
+			},
+		},
+		{
+			in:   "12 March 5 Geo III",
+			want: &gdate.Precise{C: gdate.Gregorian, Y: 1765, M: 3, D: 12},
+		},
+		{
+			in:   "20th day of January in the 3rd year of the reign of George II",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1729, M: 1, D: 20},
+		},
+		{
+			in:   "Michaelmas 1745",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1745, M: 9, D: 29},
+		},
+		{
+			in:   "Lady Day in the year 1720",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1720, M: 3, D: 25},
+		},
+		{
+			in:   "Easter Sunday 1745",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1745, M: 4, D: 14},
+		},
+		{
+			// Ash Wednesday before Easter 1745 fell in February of the year
+			// then written as 1744
+			in:   "Ash Wednesday 1744",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1744, M: 2, D: 27},
+		},
+		{
+			in:   "Whitsunday 1745",
+			want: &gdate.Precise{C: gdate.Julian25Mar, Y: 1745, M: 6, D: 2},
+		},
+		{
+			in: "5 Geo",
+		},
+		{
+			in: "61 Geo III",
+		},
+		{
+			in: "12 Victoria",
+		},
+		{
+			in: "3 Anne",
+		},
+		{
+			in: "31st day of 2nd month 1702",
+		},
+		{
+			in: "12 March 1702",
+		},
+	}
+
+	p := &Parser{ReckoningLocation: gdate.ReckoningLocationEnglandAndWales}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, ok := p.Parse(tc.in)
+			if tc.want == nil {
+				if ok {
+					t.Fatalf("got %#v, wanted no date", got.Date)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("did not parse")
+			}
+			if diff := cmp.Diff(tc.want, got.Date); diff != "" {
+				t.Errorf("date mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	testCases := []struct {
+		text     string
+		want     gdate.Date
+		original string
+	}{
+		{
+			text:     "Married at the meeting house 3rd day of 1st month 1702 in the presence of friends",
+			want:     &gdate.Precise{C: gdate.Julian25Mar, Y: 1702, M: 3, D: 3},
+			original: "3rd day of 1st month 1702",
+		},
+		{
+			text:     "Lease for 21 years, 5 Geo III",
+			want:     &gdate.BetweenPrecise{C: gdate.Gregorian, StartYear: 1764, StartMonth: 10, StartDay: 25, EndYear: 1765, EndMonth: 10, EndDay: 24},
+			original: "5 Geo III",
+		},
+		{
+			text:     "Rent due at Michaelmas 1745.",
+			want:     &gdate.Precise{C: gdate.Julian25Mar, Y: 1745, M: 9, D: 29},
+			original: "Michaelmas 1745",
+		},
+		{
+			text: "Left 5 Georgian chairs to his son",
+		},
+		{
+			text: "12 Victoria Street, Bristol",
+		},
+		{
+			text: "3 Anne Street",
+		},
+		{
+			text: "Baptism of 2 Elizabeth and John",
+		},
+		{
+			text: "Baptism of 2 William and Mary",
+		},
+		{
+			text: "Left 2 George 3 shillings",
+		},
+	}
+
+	p := &Parser{}
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			got, ok := p.Find(tc.text)
+			if tc.want == nil {
+				if ok {
+					t.Fatalf("got %#v, wanted no date", got.Date)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("did not find a date")
+			}
+			if diff := cmp.Diff(tc.want, got.Date); diff != "" {
+				t.Errorf("date mismatch (-want +got):\n%s", diff)
+			}
+			if got.Original != tc.original {
+				t.Errorf("got original %q, wanted %q", got.Original, tc.original)
+			}
+		})
+	}
+}
@@ -135,6 +135,9 @@ func DumpPerson(p *model.Person, w io.Writer) error {
 			}
 			fmt.Fprintln(w, "  Title:", o.Name)
 			fmt.Fprintln(w, "  Detail:", o.Detail)
+			fmt.Fprintln(w, "  Code:", o.Code)
+			fmt.Fprintln(w, "  Class:", o.Class.Numeral())
+			fmt.Fprintln(w, "  Group:", o.Group)
 			fmt.Fprintln(w, "  Occurrences:", o.Occurrences)
 			fmt.Fprintln(w, "  StartDate:", ObjectTitle(o.StartDate))
 			fmt.Fprintln(w, "  EndDate:", ObjectTitle(o.EndDate))
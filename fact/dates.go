@@ -85,6 +85,87 @@ func lookupPrecise(p *gdate.Precise) NamedDay {
 	return DateLookups[jd]
 }
 
+// NamedDayDate returns the date of the named day in the year yr, as the year
+// was written at the time. Under the gdate.Julian25Mar calendar a year
+// began on 25 March, so an Ash Wednesday written as 1743 fell in February
+// of the year we would now call 1744. Fixed feasts take the calendar c,
+// moveable feasts the calendar in use when Easter was observed. It returns
+// false for moveable feasts in years whose Easter is not known.
+func NamedDayDate(n NamedDay, yr int, c gdate.Calendar) (*gdate.Precise, bool) {
+	for k, named := range dayLookups {
+		if named == n {
+			m := (k - 1) / 31
+			return &gdate.Precise{C: c, Y: yr, M: m, D: k - m*31}, true
+		}
+	}
+
+	offset, ok := moveableFeastOffsets[n]
+	if !ok {
+		return nil, false
+	}
+	initDateLookupsOncer.Do(initDateLookups)
+
+	// a feast before 25 March in the written year yr fell in the following
+	// year's spring
+	for _, ey := range []int{yr, yr + 1} {
+		easter, ok := easterSundays[ey]
+		if !ok {
+			continue
+		}
+		y, m, d := AddDays(easter.C, easter.Y, easter.M, easter.D, offset)
+		if easter.C == gdate.Julian25Mar && (m < 3 || (m == 3 && d < 25)) {
+			y--
+		}
+		if y == yr {
+			return &gdate.Precise{C: easter.C, Y: y, M: m, D: d}, true
+		}
+	}
+	return nil, false
+}
+
+// AddDays adds n days, which may be negative, to a date in the calendar c,
+// counting years from 1 January.
+func AddDays(c gdate.Calendar, y, m, d, n int) (int, int, int) {
+	for ; n > 0; n-- {
+		d++
+		if d > MonthLength(c, y, m) {
+			d = 1
+			m++
+			if m > 12 {
+				m = 1
+				y++
+			}
+		}
+	}
+	for ; n < 0; n++ {
+		d--
+		if d < 1 {
+			m--
+			if m < 1 {
+				m = 12
+				y--
+			}
+			d = MonthLength(c, y, m)
+		}
+	}
+	return y, m, d
+}
+
+// MonthLength returns the number of days in month m of year y in the calendar c.
+func MonthLength(c gdate.Calendar, y, m int) int {
+	switch m {
+	case 2:
+		if y%4 == 0 && (c != gdate.Gregorian || y%100 != 0 || y%400 == 0) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	default:
+		return 31
+	}
+}
+
 // daynum calculates a quick unique number for each day of the year
 func daynum(m int, d int) int {
 	return m*31 + d
@@ -102,6 +183,23 @@ var dayLookups = map[int]NamedDay{
 // DateLookups is a nested map of julian day to a NamedDay
 var DateLookups = map[int]NamedDay{}
 
+// easterSundays maps a year, beginning on 1 January, to the date of Easter
+// Sunday in that year
+var easterSundays = map[int]*gdate.Precise{}
+
+// moveableFeastOffsets is the number of days from Easter Sunday to each
+// moveable feast
+var moveableFeastOffsets = map[NamedDay]int{
+	NamedDayEasterSunday:   0,
+	NamedDayGoodFriday:     -2,
+	NamedDayMaundyThursday: -3,
+	NamedDayPalmSunday:     -7,
+	NamedDayAshWednesday:   -46,
+	NamedDayAscensionDay:   39,
+	NamedDayWhitsunday:     49,
+	NamedDayTrinitySunday:  56,
+}
+
 var initDateLookupsOncer sync.Once
 
 func initDateLookups() {
@@ -509,6 +607,7 @@ func initDateLookups() {
 }
 
 func addMoveableFeasts(easterSunday *gdate.Precise) {
+	easterSundays[easterSunday.Y] = easterSunday
 	jd := easterSunday.C.JulianDay(easterSunday.Y, easterSunday.M, easterSunday.D)
 
 	DateLookups[jd] = NamedDayEasterSunday
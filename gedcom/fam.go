@@ -5,6 +5,7 @@ import (
 
 	"github.com/iand/gdate"
 	"github.com/iand/gedcom"
+	"github.com/iand/genster/dateparse"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/tree"
@@ -84,7 +85,7 @@ func (l *Loader) populateFamilyFacts(m ModelFinder, fr *gedcom.FamilyRecord) err
 			AssumeGROQuarter: true,
 		}
 
-		dt, err := dp.Parse(er.Date)
+		dt, err := parseDate(dp, er.Date)
 		if err != nil {
 			return fmt.Errorf("date: %w", err)
 		}
@@ -98,6 +99,11 @@ func (l *Loader) populateFamilyFacts(m ModelFinder, fr *gedcom.FamilyRecord) err
 		}
 		var anoms []*model.Anomaly
 		gev.Citations, anoms = l.parseCitationRecords(m, er.Citation, logger)
+
+		wp := &dateparse.Parser{ReckoningLocation: reckoningForPlace(pl)}
+		if wd, ok := wp.EventDate(er.Date, gev.Date, gev.Detail, gev.Citations); ok {
+			gev.Date = wd
+		}
 		for _, anom := range anoms {
 			if fatherPresent {
 				father.Anomalies = append(father.Anomalies, anom)
@@ -10,6 +10,7 @@ import (
 	"github.com/adrg/strutil/metrics"
 	"github.com/iand/gdate"
 	"github.com/iand/gedcom"
+	"github.com/iand/genster/dateparse"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 )
@@ -156,7 +157,7 @@ func (l *Loader) populatePersonFacts(m ModelFinder, in *gedcom.IndividualRecord)
 
 		logger.Debug("found gedcom event", "date", er.Date)
 
-		dt, err := dp.Parse(er.Date)
+		dt, err := parseDate(dp, er.Date)
 		if err != nil {
 			return fmt.Errorf("date: %w", err)
 		}
@@ -185,6 +186,11 @@ func (l *Loader) populatePersonFacts(m ModelFinder, in *gedcom.IndividualRecord)
 			gev.Citations, citanoms = l.parseCitationRecords(m, er.Citation, logger)
 		}
 
+		wp := &dateparse.Parser{ReckoningLocation: reckoningForPlace(pl)}
+		if wd, ok := wp.EventDate(er.Date, gev.Date, gev.Detail, gev.Citations); ok {
+			gev.Date = wd
+		}
+
 		var ev model.TimelineEvent
 
 		switch er.Tag {
@@ -2,6 +2,7 @@ package gedcom
 
 import (
 	"net/url"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -83,3 +84,17 @@ func reckoningForPlace(pl *model.Place) gdate.ReckoningLocation {
 		return gdate.ReckoningLocationNone
 	}
 }
+
+var reDualYear = regexp.MustCompile(`^(.*\d{4})/\d{1,4}$`)
+
+// parseDate parses a gedcom date. A dual year such as 12 FEB 1719/20, used for
+// dates before 25 March when the year began on that day, is read as a Julian
+// date in the earlier year.
+func parseDate(dp *gdate.Parser, s string) (gdate.Date, error) {
+	if m := reDualYear.FindStringSubmatch(strings.TrimSpace(s)); m != nil {
+		dp.ReckoningLocation = gdate.ReckoningLocationNone
+		dp.Calendar = gdate.Julian25Mar
+		s = m[1]
+	}
+	return dp.Parse(s)
+}
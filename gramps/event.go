@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/iand/gdate"
+	"github.com/iand/genster/dateparse"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/text"
@@ -109,6 +110,15 @@ func (l *Loader) populateEventFacts(m ModelFinder, grev *grampsxml.Event) error
 		}
 	}
 
+	var datestr string
+	if grev.Datestr != nil {
+		datestr = grev.Datestr.Val
+	}
+	wp := &dateparse.Parser{ReckoningLocation: dp.ReckoningLocation}
+	if wd, ok := wp.EventDate(datestr, gev.Date, gev.Detail, gev.Citations); ok {
+		gev.Date = wd
+	}
+
 	var ev model.TimelineEvent
 	evtype := strings.ToLower(pval(grev.Type, "unknown"))
 	switch evtype {
@@ -15,6 +15,7 @@ import (
 func (l *Loader) populatePlaceFacts(m ModelFinder, gp *grampsxml.Placeobj) error {
 	id := pval(gp.ID, gp.Handle)
 	pl := m.FindPlace(l.ScopeName, id)
+	pl.GrampsID = pval(gp.ID, "")
 
 	changeTime, err := changeToTime(gp.Change)
 	if err == nil {
@@ -116,6 +117,22 @@ func (l *Loader) populatePlaceFacts(m ModelFinder, gp *grampsxml.Placeobj) error
 			pl.Name = strings.TrimSuffix(pl.Name, " Registration District")
 		}
 
+		// later names are alternatives that may have been used for a period
+		for _, pn := range gp.Pname[1:] {
+			alt := strings.TrimSpace(pn.Value)
+			if alt == "" {
+				continue
+			}
+			yp, err := placePeriod(pn.Dateval, pn.Daterange, pn.Datespan, pn.Datestr)
+			if err != nil {
+				logger.Warn("could not parse date of place name", "name", alt, "error", err)
+				continue
+			}
+			pl.AltNames = append(pl.AltNames, &model.PlaceAltName{
+				YearPeriod: yp,
+				Name:       alt,
+			})
+		}
 	}
 
 	if gp.Coord != nil {
@@ -161,52 +178,76 @@ func (l *Loader) populatePlaceFacts(m ModelFinder, gp *grampsxml.Placeobj) error
 		}
 	}
 
-	// Enhance names with parent context
-	if len(gp.Placeref) > 0 {
-		for _, pr := range gp.Placeref {
-			paro, ok := l.PlacesByHandle[pr.Hlink]
-			if !ok {
-				continue
+	// A place may have been part of different places over time. The one it
+	// is part of now, or was part of most recently, gives its name context.
+	var parents []*model.Place
+	var periods []model.YearPeriod
+	current := -1
+	for _, pr := range gp.Placeref {
+		paro, ok := l.PlacesByHandle[pr.Hlink]
+		if !ok {
+			continue
+		}
+		if !l.populatedPlaces[paro.Handle] {
+			if err := l.populatePlaceFacts(m, paro); err != nil {
+				return fmt.Errorf("populate parent place: %w", err)
 			}
-			if !l.populatedPlaces[paro.Handle] {
-				if err := l.populatePlaceFacts(m, paro); err != nil {
-					return fmt.Errorf("populate parent place: %w", err)
-				}
+		}
+		yp, err := placePeriod(pr.Dateval, pr.Daterange, pr.Datespan, pr.Datestr)
+		if err != nil {
+			logger.Warn("could not parse date of enclosing place", "handle", pr.Hlink, "error", err)
+		}
+		parents = append(parents, m.FindPlace(l.ScopeName, pval(paro.ID, paro.Handle)))
+		periods = append(periods, yp)
+		if current == -1 || (periods[current].To != 0 && (yp.To == 0 || yp.To > periods[current].To)) {
+			current = len(parents) - 1
+		}
+	}
+	if len(parents) > 1 {
+		for i, par := range parents {
+			if par.IsUnknown() || par.PlaceType == model.PlaceTypeCategory {
+				continue
 			}
-			parent := m.FindPlace(l.ScopeName, pval(paro.ID, paro.Handle))
+			pl.Jurisdictions = append(pl.Jurisdictions, &model.PlaceJurisdiction{
+				YearPeriod: periods[i],
+				Parent:     par,
+			})
+		}
+	}
 
-			// handle buildings or streets
-			if !parent.IsUnknown() && pl.Numbered && pl.PlaceType == model.PlaceTypeBuilding && (parent.PlaceType == model.PlaceTypeStreet || parent.PlaceType == model.PlaceTypeBuilding) {
-				// combine into a single place
-				if parent.Name != "" {
-					pl.Name += " " + parent.Name
-				}
-				if parent.PreferredSortName != "" {
-					pl.PreferredSortName = parent.PreferredSortName + " " + pl.PreferredSortName
-				}
-				parent = parent.Parent
-			}
+	// Enhance names with parent context
+	if current != -1 {
+		parent := parents[current]
 
-			if !parent.IsUnknown() && parent.PlaceType != model.PlaceTypeCategory {
-				connector := ", "
-				if pl.Singular {
-					connector = " " + parent.InAt() + " "
-				}
+		// handle buildings or streets
+		if !parent.IsUnknown() && pl.Numbered && pl.PlaceType == model.PlaceTypeBuilding && (parent.PlaceType == model.PlaceTypeStreet || parent.PlaceType == model.PlaceTypeBuilding) {
+			// combine into a single place
+			if parent.Name != "" {
+				pl.Name += " " + parent.Name
+			}
+			if parent.PreferredSortName != "" {
+				pl.PreferredSortName = parent.PreferredSortName + " " + pl.PreferredSortName
+			}
+			parent = parent.Parent
+		}
 
-				pl.Parent = parent
-				if parent.FullName != "" {
-					pl.FullName += connector + parent.FullName
-				}
-				if parent.PreferredSortName != "" {
-					pl.PreferredSortName = parent.PreferredSortName + ", " + pl.PreferredSortName
-				}
+		if !parent.IsUnknown() && parent.PlaceType != model.PlaceTypeCategory {
+			connector := ", "
+			if pl.Singular {
+				connector = " " + parent.InAt() + " "
 			}
 
-			if pl.GeoLocation == nil && parent.GeoLocation != nil {
-				pl.GeoLocation = parent.GeoLocation
+			pl.Parent = parent
+			if parent.FullName != "" {
+				pl.FullName += connector + parent.FullName
+			}
+			if parent.PreferredSortName != "" {
+				pl.PreferredSortName = parent.PreferredSortName + ", " + pl.PreferredSortName
 			}
+		}
 
-			break
+		if pl.GeoLocation == nil && parent.GeoLocation != nil {
+			pl.GeoLocation = parent.GeoLocation
 		}
 	}
 
@@ -241,6 +282,56 @@ func (l *Loader) populatePlaceFacts(m ModelFinder, gp *grampsxml.Placeobj) error
 	return nil
 }
 
+// placePeriod returns the years covered by the date attached to a place name
+// or to a reference to an enclosing place. A place with no date has a period
+// that is open at both ends. Approximate or estimated dates are rejected
+// since they cannot mark when a name or boundary changed.
+func placePeriod(dv *grampsxml.Dateval, dr *grampsxml.Daterange, ds *grampsxml.Datespan, dstr *grampsxml.Datestr) (model.YearPeriod, error) {
+	dp := gdate.Parser{}
+	var dt *model.Date
+	var err error
+	switch {
+	case dv != nil:
+		dt, err = ParseDateval(*dv, dp)
+	case dr != nil:
+		dt, err = ParseDaterange(*dr, dp)
+	case ds != nil:
+		dt, err = ParseDatespan(*ds, dp)
+	case dstr != nil:
+		return model.ParseYearPeriod(dstr.Val)
+	default:
+		return model.YearPeriod{}, nil
+	}
+	if err != nil {
+		return model.YearPeriod{}, err
+	}
+	if dt.Derivation != model.DateDerivationStandard {
+		return model.YearPeriod{}, fmt.Errorf("date %s is not exact", dt)
+	}
+
+	switch d := dt.Date.(type) {
+	case *gdate.BeforeYear:
+		return model.YearPeriod{To: d.Y}, nil
+	case *gdate.BeforePrecise:
+		return model.YearPeriod{To: d.Y}, nil
+	case *gdate.AfterYear:
+		return model.YearPeriod{From: d.Y}, nil
+	case *gdate.AfterPrecise:
+		return model.YearPeriod{From: d.Y}, nil
+	case *gdate.YearRange:
+		return model.YearPeriod{From: d.Lower, To: d.Upper}, nil
+	case *gdate.MonthYearRange:
+		return model.YearPeriod{From: d.LowerYear, To: d.UpperYear}, nil
+	case *gdate.BetweenPrecise:
+		return model.YearPeriod{From: d.StartYear, To: d.EndYear}, nil
+	case *gdate.Precise, *gdate.MonthYear, *gdate.Year, *gdate.YearQuarter:
+		yr, _ := dt.Year()
+		return model.YearPeriod{From: yr, To: yr}, nil
+	default:
+		return model.YearPeriod{}, fmt.Errorf("date %s is not exact", dt)
+	}
+}
+
 // reckoningForPlace attempts to find a ReckoningLocation based on the place
 func reckoningForPlace(pl *model.Place) gdate.ReckoningLocation {
 	if pl.IsUnknown() {
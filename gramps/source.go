@@ -22,6 +22,7 @@ func (l *Loader) populateRepositoryFacts(m ModelFinder, gr *grampsxml.Repository
 func (l *Loader) populateSourceFacts(m ModelFinder, gs *grampsxml.Source) error {
 	id := pval(gs.ID, gs.Handle)
 	s := m.FindSource(l.ScopeName, id)
+	s.GrampsID = pval(gs.ID, "")
 
 	logger := logging.With("source", "source", "id", s.ID, "native_id", id)
 	logger.Debug("populating from source record", "handle", gs.Handle)
@@ -16,9 +16,11 @@ const (
 	PageLayoutSource          PageLayout = "source"
 	PageLayoutFamily          PageLayout = "family"
 	PageLayoutCitation        PageLayout = "citation"
+	PageLayoutGallery         PageLayout = "gallery"
 	PageLayoutListInferences  PageLayout = "listinferences"
 	PageLayoutListAnomalies   PageLayout = "listanomalies"
 	PageLayoutListTodo        PageLayout = "listtodo"
+	PageLayoutListNegative    PageLayout = "listnegative"
 	PageLayoutListPeople      PageLayout = "listpeople"
 	PageLayoutListPlaces      PageLayout = "listplaces"
 	PageLayoutListSources     PageLayout = "listsources"
@@ -31,4 +33,5 @@ const (
 	PageLayoutTreeOverview    PageLayout = "treeoverview"
 	PageLayoutChartAncestors  PageLayout = "chartancestors"
 	PageLayoutChartTrees      PageLayout = "charttrees"
+	PageLayoutStatistics      PageLayout = "statistics"
 )
@@ -0,0 +1,91 @@
+package locale
+
+import (
+	"fmt"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/text"
+)
+
+// English is the default locale. The narrative code is written in English so
+// phrases are returned unchanged and the remaining wording comes from the
+// model and text packages.
+type English struct{}
+
+var _ Locale = English{}
+
+func (English) Tag() string { return "en" }
+
+func (English) T(s string) string { return s }
+
+func (English) Tf(format string, args ...any) string { return fmt.Sprintf(format, args...) }
+
+func (English) SubjectPronoun(g model.Gender) string         { return g.SubjectPronoun() }
+func (English) SubjectPronounWithLink(g model.Gender) string { return g.SubjectPronounWithLink() }
+func (English) ObjectPronoun(g model.Gender) string          { return g.ObjectPronoun() }
+func (English) ReflexivePronoun(g model.Gender) string       { return g.ReflexivePronoun() }
+
+func (English) PossessivePronoun(g model.Gender, noun string) string {
+	return text.JoinSentenceParts(g.PossessivePronounSingular(), noun)
+}
+
+func (l English) PossessivePronounWith(g model.Gender, noun string) string {
+	return l.PossessivePronoun(g, noun)
+}
+
+func (English) RelativePronoun(g model.Gender) string { return "who" }
+
+func (English) PossessiveName(name string) string { return text.MaybePossessiveSuffix(name) }
+
+func (English) Definite(noun string) string { return "the " + noun }
+
+func (English) Indefinite(noun string) string { return "a" + text.MaybeAn(noun) }
+
+func (English) RelationName(r *model.Relation) string { return r.Name() }
+
+func (English) CardinalNoun(n int) string         { return text.CardinalNoun(n) }
+func (English) SmallCardinalNoun(n int) string    { return text.SmallCardinalNoun(n) }
+func (English) OrdinalNoun(n int) string          { return text.OrdinalNoun(n) }
+func (English) MultiplicativeAdverb(n int) string { return text.MultiplicativeAdverb(n) }
+
+func (English) CardinalWithUnit(n int, singular string, plural string) string {
+	return text.CardinalWithUnit(n, singular, plural)
+}
+
+func (English) When(d *model.Date) string { return d.When() }
+func (English) WhenInYear(d *model.Date) (string, bool) {
+	s, ok := d.DateInYear(true)
+	if !ok {
+		return "", false
+	}
+	return "on " + s, true
+}
+
+func (English) WhenNamedDay(name string, d *model.Date) string {
+	return fmt.Sprintf("on %s, %s", name, d.Date.String())
+}
+
+func (English) InAt(pl *model.Place) string { return pl.InAt() }
+func (English) What(w model.Whater) string  { return model.What(w) }
+func (English) PassiveWhat(w model.Whater) string {
+	return model.PassiveWhat(w)
+}
+
+func (English) ConditionalWhat(w model.Whater, adverb string) string {
+	return model.ConditionalWhat(w, adverb)
+}
+
+func (English) PassiveConditionalWhat(w model.Whater, adverb string) string {
+	return model.PassiveConditionalWhat(w, adverb)
+}
+
+func (English) PresentPerfectWhat(w model.Whater) string { return model.PresentPerfectWhat(w) }
+
+func (English) Predicate(verb string, parts ...string) string {
+	return text.JoinSentenceParts(append([]string{verb}, parts...)...)
+}
+
+func (English) ActiveTense(s string) string { return text.StripWasIs(s) }
+
+func (English) JoinList(strs []string) string   { return text.JoinList(strs) }
+func (English) JoinListOr(strs []string) string { return text.JoinListOr(strs) }
@@ -0,0 +1,683 @@
+package locale
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iand/gdate"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/text"
+)
+
+// German is the locale for narratives written in German. Phrases without a
+// translation are left in English.
+type German struct{}
+
+var _ Locale = German{}
+
+func (German) Tag() string { return "de" }
+
+func (German) T(s string) string {
+	if t, ok := germanPhrases[s]; ok {
+		return t
+	}
+	return s
+}
+
+func (g German) Tf(format string, args ...any) string {
+	return fmt.Sprintf(g.T(format), args...)
+}
+
+func (German) SubjectPronoun(g model.Gender) string {
+	switch g {
+	case model.GenderMale:
+		return "er"
+	case model.GenderFemale:
+		return "sie"
+	default:
+		return "er/sie"
+	}
+}
+
+func (German) SubjectPronounWithLink(g model.Gender) string {
+	switch g {
+	case model.GenderMale:
+		return "er war"
+	case model.GenderFemale:
+		return "sie war"
+	default:
+		return "er/sie war"
+	}
+}
+
+func (German) ObjectPronoun(g model.Gender) string {
+	switch g {
+	case model.GenderMale:
+		return "ihn"
+	case model.GenderFemale:
+		return "sie"
+	default:
+		return "ihn/sie"
+	}
+}
+
+func (German) ReflexivePronoun(g model.Gender) string {
+	return "sich"
+}
+
+// PossessivePronoun inflects the pronoun to agree with the grammatical gender
+// of the noun, such as "sein Vater" and "seine Mutter".
+func (l German) PossessivePronoun(g model.Gender, noun string) string {
+	n := l.T(noun)
+	stem := possessiveStem(g)
+	switch nounGender(n) {
+	case feminine, plural:
+		stem = strings.ReplaceAll(stem+"e", "/", "e/")
+	}
+	return text.JoinSentenceParts(stem, n)
+}
+
+// PossessivePronounWith inflects the pronoun and noun in the dative case
+// taken by "mit", such as "seinem Vater", "seiner Mutter" and "seinen
+// Kindern".
+func (l German) PossessivePronounWith(g model.Gender, noun string) string {
+	n := l.T(noun)
+	var ending string
+	switch nounGender(n) {
+	case feminine:
+		ending = "er"
+	case plural:
+		ending = "en"
+		if !strings.HasSuffix(n, "n") && !strings.HasSuffix(n, "s") {
+			n += "n"
+		}
+	default:
+		ending = "em"
+	}
+	stem := strings.ReplaceAll(possessiveStem(g)+ending, "/", ending+"/")
+	return text.JoinSentenceParts(stem, n)
+}
+
+func possessiveStem(g model.Gender) string {
+	switch g {
+	case model.GenderMale:
+		return "sein"
+	case model.GenderFemale:
+		return "ihr"
+	default:
+		return "sein/ihr"
+	}
+}
+
+func (German) RelativePronoun(g model.Gender) string {
+	switch g {
+	case model.GenderMale:
+		return "der"
+	case model.GenderFemale:
+		return "die"
+	default:
+		return "der/die"
+	}
+}
+
+// PossessiveName adds the genitive s, or an apostrophe after a sibilant, as
+// in "Johanns" and "Hans'".
+func (German) PossessiveName(name string) string {
+	if strings.HasSuffix(name, "s") || strings.HasSuffix(name, "ß") || strings.HasSuffix(name, "x") || strings.HasSuffix(name, "z") {
+		return name + "'"
+	}
+	return name + "s"
+}
+
+func (l German) Definite(noun string) string {
+	n := l.T(noun)
+	switch nounGender(n) {
+	case feminine, plural:
+		return "die " + n
+	case neuter:
+		return "das " + n
+	default:
+		return "der " + n
+	}
+}
+
+func (l German) Indefinite(noun string) string {
+	n := l.T(noun)
+	if nounGender(n) == feminine {
+		return "eine " + n
+	}
+	return "ein " + n
+}
+
+// RelationName names the relation using the German terms, counting further
+// generations of ancestors and descendants with the Ur- prefix.
+func (l German) RelationName(r *model.Relation) string {
+	head, tail := germanRelation(r)
+	return head + tail
+}
+
+func (German) CardinalNoun(n int) string {
+	switch {
+	case n == 0:
+		return "keine"
+	case n > 199:
+		return strconv.Itoa(n)
+	}
+	return germanNumber(n)
+}
+
+func (l German) SmallCardinalNoun(n int) string {
+	if n > 5 {
+		return strconv.Itoa(n)
+	}
+	return l.CardinalNoun(n)
+}
+
+// OrdinalNoun returns the weak form of the ordinal used after a definite
+// article, as in "der zweite Sohn".
+func (German) OrdinalNoun(n int) string {
+	switch n {
+	case 1:
+		return "erste"
+	case 3:
+		return "dritte"
+	case 7:
+		return "siebte"
+	case 8:
+		return "achte"
+	}
+	if n < 20 {
+		return germanNumber(n) + "te"
+	}
+	return germanNumber(n) + "ste"
+}
+
+func (l German) MultiplicativeAdverb(n int) string {
+	switch n {
+	case 0:
+		return "kein"
+	case 1:
+		return "einmal"
+	default:
+		return l.CardinalNoun(n) + "mal"
+	}
+}
+
+func (l German) CardinalWithUnit(n int, singular string, plural string) string {
+	if n == 1 {
+		return l.Indefinite(singular)
+	}
+	return l.CardinalNoun(n) + " " + l.T(plural)
+}
+
+var germanMonthNames = []string{"", "Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}
+
+func (l German) When(d *model.Date) string {
+	if d.IsUnknown() {
+		return "an einem unbekannten Datum"
+	}
+
+	var s string
+	switch dt := d.Date.(type) {
+	case *gdate.Precise:
+		s = "am " + germanDay(d, dt.D, dt.M, dt.Y)
+	case *gdate.MonthYear:
+		s = "im " + germanMonthNames[dt.M] + " " + germanYear(d, dt.Y)
+	case *gdate.Year:
+		s = "im Jahr " + strconv.Itoa(dt.Y)
+	case *gdate.BeforeYear:
+		s = "vor " + strconv.Itoa(dt.Y)
+	case *gdate.AfterYear:
+		s = "nach " + strconv.Itoa(dt.Y)
+	case *gdate.AboutYear:
+		s = "um " + strconv.Itoa(dt.Y)
+	case *gdate.EstimatedYear:
+		s = "geschätzt " + strconv.Itoa(dt.Y)
+	case *gdate.YearQuarter:
+		s = fmt.Sprintf("im %d. Quartal %d", dt.Q, dt.Y)
+	case *gdate.YearRange:
+		if dt.Lower%10 == 0 && dt.Upper == dt.Lower+9 {
+			s = fmt.Sprintf("in den %der-Jahren", dt.Lower)
+		} else {
+			s = fmt.Sprintf("zwischen %d und %d", dt.Lower, dt.Upper)
+		}
+	case *gdate.BeforePrecise:
+		s = "vor dem " + germanDay(d, dt.D, dt.M, dt.Y)
+	case *gdate.AfterPrecise:
+		s = "nach dem " + germanDay(d, dt.D, dt.M, dt.Y)
+	case *gdate.BetweenPrecise:
+		s = "zwischen dem " + germanDay(d, dt.StartDay, dt.StartMonth, dt.StartYear) + " und dem " + germanDay(d, dt.EndDay, dt.EndMonth, dt.EndYear)
+	case *gdate.MonthYearRange:
+		s = fmt.Sprintf("zwischen %s %d und %s %d", germanMonthNames[dt.LowerMonth], dt.LowerYear, germanMonthNames[dt.UpperMonth], dt.UpperYear)
+	default:
+		return d.When()
+	}
+	return s + germanAnnotation(d)
+}
+
+func (German) WhenInYear(d *model.Date) (string, bool) {
+	p, ok := d.Date.(*gdate.Precise)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("am %d. %s", p.D, germanMonthNames[p.M]), true
+}
+
+func (l German) WhenNamedDay(name string, d *model.Date) string {
+	p, ok := d.Date.(*gdate.Precise)
+	if !ok {
+		return l.When(d)
+	}
+	return l.T("on "+name) + ", dem " + germanDay(d, p.D, p.M, p.Y)
+}
+
+// germanDay formats a day of the month as in "3. März 1702", with a dual year
+// if it falls before the Old Style new year.
+func germanDay(d *model.Date, day, month, year int) string {
+	ys := strconv.Itoa(year)
+	if d.Date.Calendar() == gdate.Julian25Mar && (month < 3 || (month == 3 && day < 25)) {
+		ys = model.DualYear(year)
+	}
+	return fmt.Sprintf("%d. %s %s", day, germanMonthNames[month], ys)
+}
+
+func germanYear(d *model.Date, year int) string {
+	if d.IsDualDated() {
+		return model.DualYear(year)
+	}
+	return strconv.Itoa(year)
+}
+
+// germanAnnotation returns the original wording of d and its New Style
+// equivalent, when they are shown.
+func germanAnnotation(d *model.Date) string {
+	var notes []string
+	if d.Original != "" {
+		notes = append(notes, "geschrieben „"+d.Original+"“")
+	}
+	if d.ShowNewStyle {
+		if ns, ok := d.NewStyle(); ok {
+			if p, ok := ns.Date.(*gdate.Precise); ok {
+				notes = append(notes, germanDay(ns, p.D, p.M, p.Y)+" neuen Stils")
+			}
+		}
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(notes, "; ") + ")"
+}
+
+func (l German) InAt(pl *model.Place) string {
+	return l.T(pl.InAt())
+}
+
+func (l German) What(w model.Whater) string {
+	if ev, ok := germanEvent(w); ok {
+		return ev.active
+	}
+	return l.T(w.What())
+}
+
+func (l German) PassiveWhat(w model.Whater) string {
+	if ev, ok := germanEvent(w); ok {
+		return ev.passive
+	}
+	return "wurde " + splitMark + " " + l.T(w.What())
+}
+
+func (l German) ConditionalWhat(w model.Whater, adverb string) string {
+	return text.JoinSentenceParts(l.T(adverb), l.What(w))
+}
+
+// PassiveConditionalWhat places the adverb after the finite verb, as in
+// "wurde wahrscheinlich … geboren".
+func (l German) PassiveConditionalWhat(w model.Whater, adverb string) string {
+	passive := l.PassiveWhat(w)
+	if strings.Contains(passive, splitMark) {
+		return strings.Replace(passive, splitMark, l.T(adverb)+" "+splitMark, 1)
+	}
+	return text.JoinSentenceParts(passive, l.T(adverb))
+}
+
+func (l German) PresentPerfectWhat(w model.Whater) string {
+	if ev, ok := germanEvent(w); ok {
+		return splitMark + " " + ev.perfect
+	}
+	return splitMark + " " + l.T(w.What()) + " worden sein"
+}
+
+// Predicate places the qualifying phrases where the verb phrase is marked to
+// be split, so that the participle ends the clause as in
+// "wurde am 3. März 1702 in Berlin geboren".
+func (German) Predicate(verb string, parts ...string) string {
+	rest := text.JoinSentenceParts(parts...)
+	i := strings.LastIndex(verb, splitMark)
+	if i == -1 {
+		return text.JoinSentenceParts(verb, rest)
+	}
+	// the qualifiers belong to the last verb when several are joined, as in
+	// "starb … im Kindbett und wurde … begraben"
+	before := strings.Join(strings.Fields(strings.ReplaceAll(verb[:i], splitMark, "")), " ")
+	return text.JoinSentenceParts(before, rest, strings.TrimSpace(verb[i+len(splitMark):]))
+}
+
+// ActiveTense removes the auxiliary verb that begins a passive verb phrase,
+// leaving the participle at the end, as in "am 3. März 1702 geboren".
+func (German) ActiveTense(s string) string {
+	for _, aux := range []string{"wurde ", "war ", "ist "} {
+		if strings.HasPrefix(s, aux) {
+			return s[len(aux):]
+		}
+	}
+	return s
+}
+
+func (German) JoinList(strs []string) string   { return germanJoin(strs, " und ") }
+func (German) JoinListOr(strs []string) string { return germanJoin(strs, " oder ") }
+
+func germanJoin(strs []string, last string) string {
+	var ret strings.Builder
+	for i, s := range strs {
+		if i != 0 {
+			if i == len(strs)-1 {
+				ret.WriteString(last)
+			} else {
+				ret.WriteString(", ")
+			}
+		}
+		ret.WriteString(strings.Trim(s, " ,!.?"))
+	}
+	return ret.String()
+}
+
+// splitMark marks where a verb phrase is split around the phrases that
+// qualify it.
+const splitMark = "…"
+
+type germanEventPhrases struct {
+	active  string // participle phrase, such as "geboren"
+	passive string // finite verb phrase, such as "wurde … geboren"
+	perfect string // infinitive phrase, such as "geboren worden sein"
+}
+
+// germanEvent returns the phrases describing w. Events whose description
+// includes recorded text, such as an occupation, are phrased around it.
+func germanEvent(w model.Whater) (germanEventPhrases, bool) {
+	switch ev := w.(type) {
+	case *model.GeneralEvent:
+		if ev.Title != "" {
+			return germanEventPhrases{}, false
+		}
+	case *model.OccupationEvent:
+		return germanEventPhrases{
+			active:  "Beruf verzeichnet als " + ev.Occupation.Name,
+			passive: "wurde " + splitMark + " als " + ev.Occupation.Name + " verzeichnet",
+			perfect: "als " + ev.Occupation.Name + " tätig gewesen sein",
+		}, true
+	case *model.EconomicStatusEvent:
+		return germanEventPhrases{
+			active:  "wirtschaftlicher Stand: " + ev.Status,
+			passive: "hatte " + splitMark + " den wirtschaftlichen Stand " + ev.Status,
+			perfect: "den wirtschaftlichen Stand " + ev.Status + " gehabt haben",
+		}, true
+	case *model.PhysicalDescriptionEvent:
+		return germanEventPhrases{
+			active:  "beschrieben als " + ev.Description,
+			passive: "wurde " + splitMark + " als " + ev.Description + " beschrieben",
+			perfect: "als " + ev.Description + " beschrieben worden sein",
+		}, true
+	case *model.ConvictionEvent:
+		return germanEventPhrases{
+			active:  "verurteilt wegen " + ev.Crime,
+			passive: "wurde " + splitMark + " wegen " + ev.Crime + " verurteilt",
+			perfect: "wegen " + ev.Crime + " verurteilt worden sein",
+		}, true
+	}
+	ph, ok := germanEventWords[w.What()]
+	return ph, ok
+}
+
+var germanEventWords = map[string]germanEventPhrases{
+	"born":                      {"geboren", "wurde " + splitMark + " geboren", "geboren worden sein"},
+	"possibly born":             {"möglicherweise geboren", "wurde " + splitMark + " möglicherweise geboren", "möglicherweise geboren worden sein"},
+	"baptised":                  {"getauft", "wurde " + splitMark + " getauft", "getauft worden sein"},
+	"baptised privately":        {"privat getauft", "wurde " + splitMark + " privat getauft", "privat getauft worden sein"},
+	"received into the church":  {"in die Kirche aufgenommen", "wurde " + splitMark + " in die Kirche aufgenommen", "in die Kirche aufgenommen worden sein"},
+	"named":                     {"benannt", "wurde " + splitMark + " benannt", "benannt worden sein"},
+	"died":                      {"gestorben", "starb", "gestorben sein"},
+	"possibly died":             {"möglicherweise gestorben", "starb " + splitMark + " möglicherweise", "möglicherweise gestorben sein"},
+	"died by own hand":          {"durch eigene Hand gestorben", "starb " + splitMark + " durch eigene Hand", "durch eigene Hand gestorben sein"},
+	"lost at sea":               {"auf See verschollen", "blieb " + splitMark + " auf See verschollen", "auf See verschollen sein"},
+	"killed in action":          {"gefallen", "fiel", "gefallen sein"},
+	"drowned":                   {"ertrunken", "ertrank", "ertrunken sein"},
+	"executed":                  {"hingerichtet", "wurde " + splitMark + " hingerichtet", "hingerichtet worden sein"},
+	"died in childbirth":        {"im Kindbett gestorben", "starb " + splitMark + " im Kindbett", "im Kindbett gestorben sein"},
+	"died accidentally":         {"durch einen Unfall gestorben", "starb " + splitMark + " durch einen Unfall", "durch einen Unfall gestorben sein"},
+	"died at sea":               {"auf See gestorben", "starb " + splitMark + " auf See", "auf See gestorben sein"},
+	"buried":                    {"begraben", "wurde " + splitMark + " begraben", "begraben worden sein"},
+	"cremated":                  {"eingeäschert", "wurde " + splitMark + " eingeäschert", "eingeäschert worden sein"},
+	"memorial":                  {"Gedenkfeier", "wurde " + splitMark + " mit einer Gedenkfeier geehrt", "mit einer Gedenkfeier geehrt worden sein"},
+	"departed":                  {"abgereist", "reiste " + splitMark + " ab", "abgereist sein"},
+	"arrived":                   {"angekommen", "kam " + splitMark + " an", "angekommen sein"},
+	"immigrated":                {"eingewandert", "wanderte " + splitMark + " ein", "eingewandert sein"},
+	"apprenticed":               {"in die Lehre gegeben", "wurde " + splitMark + " in die Lehre gegeben", "in die Lehre gegeben worden sein"},
+	"recorded in the census":    {"in der Volkszählung verzeichnet", "wurde " + splitMark + " in der Volkszählung verzeichnet", "in der Volkszählung verzeichnet worden sein"},
+	"inquest held":              {"Leichenschau abgehalten", "Leichenschau wurde " + splitMark + " abgehalten", "einer Leichenschau unterzogen worden sein"},
+	"probate granted":           {"Nachlass eröffnet", "Nachlass wurde " + splitMark + " eröffnet", "den Nachlass eröffnet bekommen haben"},
+	"wrote a will":              {"verfasste ein Testament", "verfasste " + splitMark + " ein Testament", "ein Testament verfasst haben"},
+	"resided":                   {"wohnhaft", "wohnte", "gewohnt haben"},
+	"sold some property":        {"verkaufte Grundbesitz", "verkaufte " + splitMark + " Grundbesitz", "Grundbesitz verkauft haben"},
+	"entered":                   {"eingetreten", "trat " + splitMark + " ein", "eingetreten sein"},
+	"left":                      {"verließ", "verließ", "verlassen haben"},
+	"married":                   {"heiratete", "heiratete", "geheiratet haben"},
+	"marriage license obtained": {"Heiratserlaubnis erteilt", "Heiratserlaubnis wurde " + splitMark + " erteilt", "eine Heiratserlaubnis erhalten haben"},
+	"marriage banns read":       {"Aufgebot verlesen", "Aufgebot wurde " + splitMark + " verlesen", "das Aufgebot bestellt haben"},
+	"divorced":                  {"geschieden", "wurde " + splitMark + " geschieden", "geschieden worden sein"},
+	"separated":                 {"getrennt", "trennte sich", "sich getrennt haben"},
+	"had marriage anulled":      {"Ehe annulliert", "ließ " + splitMark + " die Ehe annullieren", "die Ehe haben annullieren lassen"},
+}
+
+type grammaticalGender int
+
+const (
+	masculine grammaticalGender = iota
+	feminine
+	neuter
+	plural
+)
+
+// nounGender guesses the grammatical gender of the last word of a German
+// noun phrase from the nouns used in narratives.
+func nounGender(n string) grammaticalGender {
+	if i := strings.LastIndexAny(n, " -"); i != -1 {
+		n = n[i+1:]
+	}
+	n = strings.ToLower(n)
+	if n == "cousin" {
+		return masculine
+	}
+	for _, s := range []string{"eltern", "kinder", "geschwister", "leute"} {
+		if strings.HasSuffix(n, s) {
+			return plural
+		}
+	}
+	for _, s := range []string{"kind", "mädchen", "paar", "jahr"} {
+		if strings.HasSuffix(n, s) {
+			return neuter
+		}
+	}
+	for _, s := range []string{"mutter", "tochter", "schwester", "frau", "tante", "nichte", "cousine", "witwe", "person", "in", "ehe", "familie", "kirche", "zeit"} {
+		if strings.HasSuffix(n, s) {
+			return feminine
+		}
+	}
+	return masculine
+}
+
+var germanUnits = []string{"", "ein", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun", "zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn"}
+
+var germanTens = []string{"", "", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"}
+
+// germanNumber returns the German word for n between 1 and 199, with units
+// before tens as in "einundzwanzig".
+func germanNumber(n int) string {
+	var prefix string
+	if n >= 100 {
+		prefix = "hundert"
+		n -= 100
+		if n == 0 {
+			return prefix
+		}
+	}
+	if n < 20 {
+		return prefix + germanUnits[n]
+	}
+	if n%10 == 0 {
+		return prefix + germanTens[n/10]
+	}
+	return prefix + germanUnits[n%10] + "und" + germanTens[n/10]
+}
+
+// germanRelation returns the head noun of the relation and any qualifier that
+// follows it, so the head can be put in the genitive.
+func germanRelation(r *model.Relation) (string, string) {
+	if r == nil {
+		return "keine Verwandtschaft", ""
+	}
+
+	gendered := func(g model.Gender, male, female, unknown string) string {
+		switch g {
+		case model.GenderMale:
+			return male
+		case model.GenderFemale:
+			return female
+		default:
+			return unknown
+		}
+	}
+
+	if r.CommonAncestor != nil {
+		if r.To.SameAs(r.CommonAncestor) {
+			// Direct ancestor
+			gens := r.FromGenerations
+			if gens == 0 {
+				return "dieselbe Person wie", ""
+			}
+			name := gendered(r.To.Gender, "Vater", "Mutter", "Elternteil")
+			if gens == 1 {
+				return name, ""
+			}
+			return germanGenerations(gens-2, "Groß"+strings.ToLower(name)), ""
+		}
+
+		if r.From.SameAs(r.CommonAncestor) {
+			// Direct descendant
+			gens := r.ToGenerations
+			if gens == 0 {
+				return "selbst", ""
+			}
+			if gens == 1 {
+				return gendered(r.To.Gender, "Sohn", "Tochter", "Kind"), ""
+			}
+			return germanGenerations(gens-2, gendered(r.To.Gender, "Enkel", "Enkelin", "Enkelkind")), ""
+		}
+
+		if r.FromGenerations == 1 && r.ToGenerations == 1 {
+			return gendered(r.To.Gender, "Bruder", "Schwester", "Geschwisterkind"), ""
+		}
+
+		if r.FromGenerations > 1 && r.ToGenerations == 1 && !r.To.Gender.IsUnknown() {
+			name := gendered(r.To.Gender, "Onkel", "Tante", "")
+			if r.FromGenerations == 2 {
+				return name, ""
+			}
+			return germanGenerations(r.FromGenerations-3, "Groß"+strings.ToLower(name)), ""
+		}
+
+		if r.FromGenerations == 1 && r.ToGenerations > 1 && !r.To.Gender.IsUnknown() {
+			name := gendered(r.To.Gender, "Neffe", "Nichte", "")
+			if r.ToGenerations == 2 {
+				return name, ""
+			}
+			return germanGenerations(r.ToGenerations-3, "Groß"+strings.ToLower(name)), ""
+		}
+
+		name := gendered(r.To.Gender, "Cousin", "Cousine", "Cousin")
+		degree := min(r.FromGenerations, r.ToGenerations) - 1
+		var tail string
+		if degree > 1 {
+			tail = fmt.Sprintf(" %d. Grades", degree)
+		}
+		switch removal := abs(r.FromGenerations - r.ToGenerations); removal {
+		case 0:
+		case 1:
+			tail += ", einmal entfernt"
+		default:
+			tail += fmt.Sprintf(", %s entfernt", German{}.MultiplicativeAdverb(removal))
+		}
+		return name, tail
+	}
+
+	if r.ClosestDirectRelation != nil && r.SpouseRelation != nil {
+		var rel string
+		if r.SpouseRelation.IsSelf() {
+			if r.ClosestDirectRelation.IsParent() {
+				return gendered(r.To.Gender, "Stiefvater", "Stiefmutter", "Stiefelternteil"), ""
+			}
+			rel = gendered(r.To.Gender, "Ehemann", "Ehefrau", "Ehepartner")
+		} else if r.SpouseRelation.FromGenerations == 1 {
+			rel = gendered(r.To.Gender, "Schwiegervater", "Schwiegermutter", "Schwiegerelternteil")
+		} else if r.SpouseRelation.ToGenerations == 1 {
+			rel = gendered(r.To.Gender, "Stiefsohn", "Stieftochter", "Stiefkind")
+		}
+
+		if rel != "" {
+			if r.ClosestDirectRelation.From.SameAs(r.ClosestDirectRelation.To) {
+				return rel, ""
+			}
+			head, tail := germanRelation(r.ClosestDirectRelation)
+			return rel, " " + germanGenitive(head) + tail
+		}
+	}
+
+	return "unbekannte Verwandtschaft", ""
+}
+
+// germanGenerations prefixes name with Ur- for each further generation,
+// switching to a count once the prefixes become hard to read.
+func germanGenerations(n int, name string) string {
+	if n <= 0 {
+		return name
+	}
+	lower := strings.ToLower(name[:1]) + name[1:]
+	if n < 3 {
+		return "Ur" + strings.Repeat("ur", n-1) + lower
+	}
+	return fmt.Sprintf("%d-fach Ur%s", n, lower)
+}
+
+// germanGenitive returns a relationship noun with the definite article in the
+// genitive case, as in "des Vaters" or "der Mutter".
+func germanGenitive(n string) string {
+	switch nounGender(n) {
+	case feminine, plural:
+		return "der " + n
+	}
+	switch {
+	case strings.HasSuffix(n, "e"):
+		// weak nouns such as Neffe
+		return "des " + n + "n"
+	case strings.HasSuffix(n, "s"):
+		return "des " + n
+	}
+	return "des " + n + "s"
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,245 @@
+package locale
+
+// germanPhrases translates the fixed English phrases used by the narrative
+// code, keyed by the English text. Phrases that surround a verb mark where
+// the qualifying phrases go with splitMark and are passed through Predicate.
+var germanPhrases = map[string]string{
+	// linking words
+	"and":     "und",
+	"and was": "und wurde",
+	"as":      "als",
+	"for":     "für",
+	"from":    "aus",
+	"had":     "hatte",
+	"here":    "hier",
+	"in the":  "im",
+	"is":      "ist",
+	"of":      "von",
+	"there":   "dort",
+	"was":     "war",
+	"were":    "waren",
+	"with":    "mit",
+	"They":    "Sie",
+	"aged":    "im Alter von",
+	"now":     "heute",
+	"unknown": "unbekannt",
+
+	"however, there is some evidence that": "es gibt jedoch Hinweise darauf, dass",
+	"it's possible that":                   "möglicherweise",
+	"probably":                             "wahrscheinlich",
+	"possibly":                             "möglicherweise",
+
+	// people and relations
+	"father":            "Vater",
+	"mother":            "Mutter",
+	"parent":            "Elternteil",
+	"parents":           "Eltern",
+	"son":               "Sohn",
+	"sons":              "Söhne",
+	"daughter":          "Tochter",
+	"daughters":         "Töchter",
+	"child":             "Kind",
+	"children":          "Kinder",
+	"other child":       "weiteres Kind",
+	"other children":    "weitere Kinder",
+	"first child":       "erste Kind",
+	"husband":           "Ehemann",
+	"wife":              "Ehefrau",
+	"spouse":            "Ehepartner",
+	"husbands":          "Ehemänner",
+	"wives":             "Ehefrauen",
+	"spouses":           "Ehepartner",
+	"brother":           "Bruder",
+	"sister":            "Schwester",
+	"sibling":           "Geschwisterteil",
+	"siblings":          "Geschwister",
+	"man":               "Mann",
+	"woman":             "Frau",
+	"person":            "Person",
+	"head":              "Haushaltsvorstand",
+	"uncle":             "Onkel",
+	"aunt":              "Tante",
+	"nephew":            "Neffe",
+	"niece":             "Nichte",
+	"grandson":          "Enkel",
+	"granddaughter":     "Enkelin",
+	"son-in-law":        "Schwiegersohn",
+	"daughter-in-law":   "Schwiegertochter",
+	"father-in-law":     "Schwiegervater",
+	"mother-in-law":     "Schwiegermutter",
+	"brother-in-law":    "Schwager",
+	"sister-in-law":     "Schwägerin",
+	"lodger":            "Untermieter",
+	"boarder":           "Kostgänger",
+	"inmate":            "Insasse",
+	"patient":           "Patient",
+	"servant":           "Dienstbote",
+	"visitor":           "Besucher",
+	"soldier":           "Soldat",
+	"an unknown person": "eine unbekannte Person",
+	"unknown parents":   "unbekannten Eltern",
+	"the child of":      "das Kind von",
+	"the informant":     "die meldende Person",
+	"the informant was": "die meldende Person war",
+	"only %s":           "einzige %s",
+	"only known %s":     "einzige bekannte %s",
+	"eldest %s":         "älteste %s",
+	"youngest %s":       "jüngste %s",
+	"%s surviving %s":   "%s überlebende %s",
+	"(known as %s)":     "(genannt %s)",
+	"was the twin to":   "war der Zwilling von",
+	"survived by":       "überlebt von",
+
+	// things
+	"life":  "Leben",
+	"death": "Tod",
+	"year":  "Jahr",
+	"years": "Jahre",
+
+	// ages and intervals
+	"at the age of %s":               "im Alter von %s",
+	"%s, at the age of %s":           "%s, im Alter von %s",
+	"died age %s,":                   "starb im Alter von %s,",
+	"%s years later":                 "%s Jahre später",
+	"%s years later, %s":             "%s Jahre später, %s",
+	"%d %s later":                    "%d %s später",
+	"%s days later":                  "%s Tage später",
+	"%s after %s was born":           "%[1]s nach der Geburt",
+	"a couple of weeks":              "ein paar Wochen",
+	"a couple of weeks later":        "ein paar Wochen später",
+	"a few days later":               "ein paar Tage später",
+	"a few months":                   "ein paar Monate",
+	"a few months later":             "ein paar Monate später",
+	"a short while later":            "kurze Zeit später",
+	"just a few days later":          "nur wenige Tage später",
+	"just a month":                   "nur einen Monat",
+	"just a week":                    "nur eine Woche",
+	"just a week later":              "nur eine Woche später",
+	"less than a week":               "weniger als eine Woche",
+	"less than a year":               "weniger als ein Jahr",
+	"less than a year later":         "weniger als ein Jahr später",
+	"several days later":             "einige Tage später",
+	"shortly":                        "kurz",
+	"shortly after":                  "kurz darauf",
+	"very shortly after":             "sehr kurz darauf",
+	"some time later":                "einige Zeit später",
+	"two days later":                 "zwei Tage später",
+	"the same day":                   "am selben Tag",
+	"the next day":                   "am nächsten Tag",
+	"the next month":                 "im nächsten Monat",
+	"the next year,":                 "im nächsten Jahr",
+	"the following year,":            "im folgenden Jahr",
+	"the same year":                  "im selben Jahr",
+	"that same year":                 "im selben Jahr",
+	"later that year":                "später im selben Jahr",
+	"later that same year":           "später im selben Jahr",
+	"around this time":               "um diese Zeit",
+	"on an unknown date":             "an einem unbekannten Datum",
+	"as a child":                     "als Kind",
+	"as an infant":                   "als Säugling",
+	"in infancy":                     "im Säuglingsalter",
+	"died in infancy":                "starb im Säuglingsalter",
+	"is inferred to %s":              "dürfte %s",
+	"is calculated to %s":            "müsste rechnerisch %s",
+	"nothing else is known about %s": "über %s ist nichts weiter bekannt",
+
+	"nothing is known about the early life of":    "über die Kindheit ist nichts bekannt von",
+	"the time and place of %s death is not known": "Zeit und Ort von %s Tod sind nicht bekannt",
+	"are not known": "sind nicht bekannt",
+	"is not known":  "ist nicht bekannt",
+
+	// events
+	"born":                              "geboren",
+	"was baptised":                      "wurde " + splitMark + " getauft",
+	"and again":                         "und erneut",
+	"buried":                            splitMark + " begraben",
+	"cremated":                          splitMark + " eingeäschert",
+	"died":                              "starb",
+	"divorced":                          "wurden " + splitMark + " geschieden",
+	"the marriage ended":                "die Ehe endete",
+	"married":                           "heiratete",
+	"likely married":                    "heiratete vermutlich",
+	"probably married":                  "heiratete wahrscheinlich",
+	"met":                               "traf",
+	"to marry":                          "zu heiraten",
+	"never married":                     "heiratete nie",
+	"never married and had no children": "heiratete nie und hatte keine Kinder",
+	"had no children":                   "hatte keine Kinder",
+	"they had no children":              "sie hatten keine Kinder",
+	"arrived":                           "kam an",
+	"departed":                          "reiste ab",
+	"entered":                           "trat ein",
+	"left":                              "verließ",
+	"emigrated to":                      "wanderte aus nach",
+	"from here":                         "von hier",
+	"recorded":                          "verzeichnet",
+	"residing":                          "wohnhaft",
+	"living":                            "lebend",
+	"as residing":                       "als wohnhaft",
+	"as living":                         "als lebend",
+	"taken":                             "aufgenommen",
+	"at muster":                         "beim Appell",
+	"registered in the %s district":     "im Bezirk %s registriert",
+	"(the record reads “%s”)":           "(im Eintrag: „%s“)",
+	"probate office":                    "Nachlassgericht",
+	"was attributed to":                 "wurde zugeschrieben",
+
+	"recorded in the %d census":                 "in der Volkszählung von %d verzeichnet",
+	"recorded in the census":                    "in der Volkszählung verzeichnet",
+	"%s recorded in the %d census":              "%s " + splitMark + " in der Volkszählung von %d verzeichnet",
+	"%s %s recorded in the %d census":           "%s %s " + splitMark + " in der Volkszählung von %d verzeichnet",
+	"by the time of the %d census %s %s living": "zur Zeit der Volkszählung von %[1]d %[3]s %[2]s " + splitMark + " wohnhaft",
+	"in the %d census %s %s living":             "laut der Volkszählung von %[1]d %[3]s %[2]s " + splitMark + " wohnhaft",
+	"in the %s battalion, %s":                   "im %s Bataillon, %s",
+	"in the %s regiment":                        "im Regiment %s",
+
+	// children
+	"had a child":                      "bekam ein Kind",
+	"had a son":                        "bekam einen Sohn",
+	"had a daughter":                   "bekam eine Tochter",
+	"gave birth to a son":              "brachte einen Sohn zur Welt",
+	"gave birth to a daughter":         "brachte eine Tochter zur Welt",
+	"gave birth to a child":            "brachte ein Kind zur Welt",
+	"had %s":                           "bekam %s",
+	"and had %s":                       "und bekam %s",
+	"They had %s":                      "Sie bekamen %s",
+	"They went on to have %s":          "Sie bekamen später %s",
+	"and went on to have %s with %s":   "und bekam später %s mit %s",
+	"had just one child together":      "bekamen nur ein gemeinsames Kind",
+	"They had just one child together": "Sie bekamen nur ein gemeinsames Kind",
+	"with an unknown %s":               "mit unbekanntem %s",
+	"by an unknown %s":                 "von unbekanntem %s",
+	"%s living or recently died":       "%s lebend oder kürzlich verstorben",
+
+	// wills and probate
+	"Administrator of the probate of":     "Nachlassverwalter von",
+	"Beneficiary of the probate of":       "Begünstigter im Nachlass von",
+	"Executor of the probate of":          "Testamentsvollstrecker im Nachlass von",
+	"Named as beneficiary in the will of": "Als Begünstigter genannt im Testament von",
+	"Named as executor in the will of":    "Als Testamentsvollstrecker genannt im Testament von",
+	"Witnessed the will of":               "Zeuge des Testaments von",
+	"for the marriage of":                 "für die Heirat von",
+	"witness to the marriage of":          "Trauzeuge bei der Heirat von",
+
+	// headings
+	"%s background": "%s Herkunft",
+	"%s death":      "%s Tod",
+	"%s later life": "%s späteres Leben",
+	"Family life":   "Familienleben",
+
+	// named days
+	"on Easter Sunday":   "am Ostersonntag",
+	"on Christmas Day":   "am Weihnachtstag",
+	"on Lady Day":        "an Mariä Verkündigung",
+	"on Michaelmas":      "an Michaeli",
+	"on Candlemas":       "an Mariä Lichtmess",
+	"on Epiphany":        "am Dreikönigstag",
+	"on All Saints Day":  "an Allerheiligen",
+	"on Maundy Thursday": "am Gründonnerstag",
+	"on Palm Sunday":     "am Palmsonntag",
+	"on Good Friday":     "am Karfreitag",
+	"on Ash Wednesday":   "am Aschermittwoch",
+	"on Ascension Day":   "an Christi Himmelfahrt",
+	"on Whitsun":         "an Pfingsten",
+	"on Trinity Sunday":  "am Dreifaltigkeitssonntag",
+}
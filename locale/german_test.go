@@ -0,0 +1,151 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/iand/gdate"
+	"github.com/iand/genster/model"
+)
+
+func TestFor(t *testing.T) {
+	for _, tt := range []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{tag: "", want: "en"},
+		{tag: "en", want: "en"},
+		{tag: "de", want: "de"},
+		{tag: "de-AT", want: "de"},
+		{tag: "DE", want: "de"},
+		{tag: "fr", wantErr: true},
+	} {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := For(tt.tag)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got locale %q, wanted error", got.Tag())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Tag() != tt.want {
+				t.Errorf("got %q, wanted %q", got.Tag(), tt.want)
+			}
+		})
+	}
+}
+
+func TestGermanRelationName(t *testing.T) {
+	self := &model.Person{ID: "self", Gender: model.GenderMale}
+	ancestor := func(gender model.Gender, gens int) *model.Relation {
+		p := &model.Person{ID: "anc", Gender: gender}
+		return &model.Relation{From: self, To: p, CommonAncestor: p, FromGenerations: gens}
+	}
+	descendant := func(gender model.Gender, gens int) *model.Relation {
+		p := &model.Person{ID: "desc", Gender: gender}
+		return &model.Relation{From: self, To: p, CommonAncestor: self, ToGenerations: gens}
+	}
+	sibling := func(gender model.Gender) *model.Relation {
+		p := &model.Person{ID: "sib", Gender: gender}
+		parent := &model.Person{ID: "parent"}
+		return &model.Relation{From: self, To: p, CommonAncestor: parent, FromGenerations: 1, ToGenerations: 1}
+	}
+
+	for _, tt := range []struct {
+		name string
+		rel  *model.Relation
+		want string
+	}{
+		{name: "father", rel: ancestor(model.GenderMale, 1), want: "Vater"},
+		{name: "grandmother", rel: ancestor(model.GenderFemale, 2), want: "Großmutter"},
+		{name: "great grandfather", rel: ancestor(model.GenderMale, 3), want: "Urgroßvater"},
+		{name: "daughter", rel: descendant(model.GenderFemale, 1), want: "Tochter"},
+		{name: "grandson", rel: descendant(model.GenderMale, 2), want: "Enkel"},
+		{name: "sister", rel: sibling(model.GenderFemale), want: "Schwester"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := German{}.RelationName(tt.rel)
+			if got != tt.want {
+				t.Errorf("got %q, wanted %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGermanWhen(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		date gdate.Date
+		want string
+	}{
+		{name: "precise", date: &gdate.Precise{Y: 1850, M: 7, D: 14}, want: "am 14. Juli 1850"},
+		{name: "month", date: &gdate.MonthYear{Y: 1850, M: 3}, want: "im März 1850"},
+		{name: "year", date: &gdate.Year{Y: 1850}, want: "im Jahr 1850"},
+		{name: "about", date: &gdate.AboutYear{Y: 1850}, want: "um 1850"},
+		{name: "decade", date: &gdate.YearRange{Lower: 1850, Upper: 1859}, want: "in den 1850er-Jahren"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := German{}.When(&model.Date{Date: tt.date})
+			if got != tt.want {
+				t.Errorf("got %q, wanted %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGermanNumbers(t *testing.T) {
+	for _, tt := range []struct {
+		n           int
+		wantOrdinal string
+		wantUnit    string
+	}{
+		{n: 1, wantOrdinal: "erste", wantUnit: "ein Sohn"},
+		{n: 3, wantOrdinal: "dritte", wantUnit: "drei Söhne"},
+		{n: 21, wantOrdinal: "einundzwanzigste", wantUnit: "einundzwanzig Söhne"},
+	} {
+		if got := (German{}).OrdinalNoun(tt.n); got != tt.wantOrdinal {
+			t.Errorf("OrdinalNoun(%d): got %q, wanted %q", tt.n, got, tt.wantOrdinal)
+		}
+		if got := (German{}).CardinalWithUnit(tt.n, "son", "sons"); got != tt.wantUnit {
+			t.Errorf("CardinalWithUnit(%d): got %q, wanted %q", tt.n, got, tt.wantUnit)
+		}
+	}
+}
+
+func TestGermanPhrasing(t *testing.T) {
+	l := German{}
+	for _, tt := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "possessive masculine noun", got: l.PossessivePronoun(model.GenderMale, "father"), want: "sein Vater"},
+		{name: "possessive feminine noun", got: l.PossessivePronoun(model.GenderMale, "mother"), want: "seine Mutter"},
+		{name: "possessive plural noun", got: l.PossessivePronoun(model.GenderFemale, "parents"), want: "ihre Eltern"},
+		{name: "possessive with masculine noun", got: l.PossessivePronounWith(model.GenderMale, "son"), want: "seinem Sohn"},
+		{name: "possessive with feminine noun", got: l.PossessivePronounWith(model.GenderFemale, "wife"), want: "ihrer Ehefrau"},
+		{name: "possessive with plural noun", got: l.PossessivePronounWith(model.GenderMale, "drei Kinder"), want: "seinen drei Kindern"},
+		{name: "possessive with unknown gender", got: l.PossessivePronounWith(model.GenderUnknown, "father"), want: "seinem/ihrem Vater"},
+		{name: "relative pronoun masculine", got: l.RelativePronoun(model.GenderMale), want: "der"},
+		{name: "relative pronoun feminine", got: l.RelativePronoun(model.GenderFemale), want: "die"},
+		{name: "and again", got: l.T("and again"), want: "und erneut"},
+		{name: "definite neuter", got: l.Definite("child"), want: "das Kind"},
+		{name: "possessive name", got: l.PossessiveName("Hans"), want: "Hans'"},
+		{name: "join list", got: l.JoinList([]string{"Anna", "Berta", "Clara"}), want: "Anna, Berta und Clara"},
+		{name: "join list or", got: l.JoinListOr([]string{"Anna", "Berta"}), want: "Anna oder Berta"},
+		{name: "predicate", got: l.Predicate(l.T("was baptised"), "am 14. Juli 1850", "in Berlin"), want: "wurde am 14. Juli 1850 in Berlin getauft"},
+		{name: "predicate without marker", got: l.Predicate("heiratete", "im Jahr 1850"), want: "heiratete im Jahr 1850"},
+		{name: "active tense", got: l.ActiveTense("wurde am 14. Juli 1850 geboren"), want: "am 14. Juli 1850 geboren"},
+		{name: "untranslated phrase", got: l.T("no such phrase"), want: "no such phrase"},
+		{name: "reordered format", got: l.Tf("in the %d census %s %s living", 1881, "er", "war"), want: "laut der Volkszählung von 1881 war er … wohnhaft"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, wanted %q", tt.got, tt.want)
+			}
+		})
+	}
+}
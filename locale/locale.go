@@ -0,0 +1,136 @@
+// Package locale supplies the language dependent wording used when
+// generating narrative text: pronouns, relationship names, numbers, dates,
+// event phrases and the fixed phrases that join them together.
+package locale
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iand/genster/model"
+)
+
+// A Locale produces narrative wording in one language. Fixed phrases are
+// written in English in the narrative code and passed through T or Tf, so a
+// Locale that has no translation for a phrase falls back to the English.
+type Locale interface {
+	// Tag returns the language tag of the locale, such as "en" or "de".
+	Tag() string
+
+	// T returns the translation of the English phrase s.
+	T(s string) string
+
+	// Tf translates the English format string and formats it with args.
+	Tf(format string, args ...any) string
+
+	SubjectPronoun(g model.Gender) string         // he, she, they
+	SubjectPronounWithLink(g model.Gender) string // he was, she was, they were
+	ObjectPronoun(g model.Gender) string          // him, her, them
+	ReflexivePronoun(g model.Gender) string       // himself, herself, themselves
+
+	// PossessivePronoun returns noun, translated, preceded by the possessive
+	// pronoun for a person of gender g, such as "his father".
+	PossessivePronoun(g model.Gender, noun string) string
+
+	// PossessivePronounWith is PossessivePronoun in the form that follows
+	// the word "with", which takes the dative case in German.
+	PossessivePronounWith(g model.Gender, noun string) string
+
+	// RelativePronoun returns the pronoun introducing a relative clause about
+	// a person of gender g, such as "who".
+	RelativePronoun(g model.Gender) string
+
+	// PossessiveName returns name in the possessive form used before a noun,
+	// such as "John's".
+	PossessiveName(name string) string
+
+	// Definite returns noun, translated, with a definite article, such as
+	// "the son".
+	Definite(noun string) string
+
+	// Indefinite returns noun, translated, with an indefinite article, such
+	// as "a son".
+	Indefinite(noun string) string
+
+	// RelationName returns the name of the relation r in the form that
+	// "r.To is the RelationName(r) of r.From".
+	RelationName(r *model.Relation) string
+
+	CardinalNoun(n int) string      // forty-two
+	SmallCardinalNoun(n int) string // two, or 42 for larger numbers
+	OrdinalNoun(n int) string       // twenty-first
+	MultiplicativeAdverb(n int) string
+
+	// CardinalWithUnit returns n followed by the translated singular or
+	// plural unit, such as "one child" or "three children".
+	CardinalWithUnit(n int, singular string, plural string) string
+
+	// When returns a phrase describing when something happened on date d,
+	// such as "on 3 Mar 1702" or "in 1702".
+	When(d *model.Date) string
+
+	// WhenInYear returns a phrase describing the day and month of d without
+	// its year, such as "on 3 March", or false if d is not a precise date.
+	WhenInYear(d *model.Date) (string, bool)
+
+	// WhenNamedDay returns a phrase describing the precise date d that is
+	// also known by the name of a feast or quarter day, such as
+	// "on Lady Day, 25 Mar 1720".
+	WhenNamedDay(name string, d *model.Date) string
+
+	// InAt returns the preposition used before the name of pl when saying
+	// where something happened, such as "in" or "at".
+	InAt(pl *model.Place) string
+
+	// What returns an active verb phrase in the past tense describing what
+	// happened.
+	What(w model.Whater) string
+
+	// PassiveWhat returns a passive verb phrase in the past tense, such as
+	// "was born".
+	PassiveWhat(w model.Whater) string
+
+	// ConditionalWhat returns an active verb phrase qualified by an adverb
+	// such as "probably".
+	ConditionalWhat(w model.Whater, adverb string) string
+
+	// PassiveConditionalWhat returns a passive verb phrase qualified by an
+	// adverb such as "probably".
+	PassiveConditionalWhat(w model.Whater, adverb string) string
+
+	// PresentPerfectWhat returns a verb phrase in the present perfect tense,
+	// such as "have been born", used after phrases like "inferred to".
+	PresentPerfectWhat(w model.Whater) string
+
+	// Predicate joins a verb phrase returned by one of the What methods with
+	// the phrases that qualify it, such as when and where it happened. The
+	// verb phrase comes first in English but some languages place part of it
+	// after the qualifiers.
+	Predicate(verb string, parts ...string) string
+
+	// ActiveTense removes the auxiliary verb from a passive verb phrase so it
+	// can follow a name, as in "John, born 3 Mar 1702".
+	ActiveTense(s string) string
+
+	JoinList(strs []string) string   // one, two and three
+	JoinListOr(strs []string) string // one, two or three
+}
+
+// Default is the locale used when none has been configured.
+var Default Locale = English{}
+
+// For returns the locale for the language tag, which may name a regional
+// variant such as "de-AT". An empty tag selects the default locale.
+func For(tag string) (Locale, error) {
+	lang, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	switch lang {
+	case "":
+		return Default, nil
+	case "en":
+		return English{}, nil
+	case "de":
+		return German{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported language: %q", tag)
+	}
+}
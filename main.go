@@ -26,6 +26,7 @@ func main() {
 		Usage: "Generate a website from a gedcom file",
 		Commands: []*cli.Command{
 			site.Command,
+			site.PlacesCommand,
 			build.Command,
 			serve.Command,
 			chart.Command,
@@ -0,0 +1,179 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iand/gdate"
+)
+
+// Dates recorded under the Julian calendar with the year beginning on 25 March
+// (Old Style) keep the year as it was written at the time, so 12 February 1719
+// fell eleven months after 12 March 1719 and is 12 February 1720 by a modern
+// (New Style) reckoning. Such dates between 1 January and 24 March are shown
+// with a dual year, such as 12 Feb 1719/20.
+
+// IsDualDated reports whether d falls between 1 January and 24 March in a
+// year that began on 25 March, and so is shown with a dual year.
+func (d *Date) IsDualDated() bool {
+	if d == nil {
+		return false
+	}
+	switch dt := d.Date.(type) {
+	case *gdate.Precise:
+		return dt.C == gdate.Julian25Mar && beforeLadyDay(dt.M, dt.D)
+	case *gdate.MonthYear:
+		// March straddles the start of the year so only January and
+		// February are certain
+		return dt.C == gdate.Julian25Mar && dt.M < 3
+	}
+	return false
+}
+
+// IsOldStyle reports whether d was recorded under the Julian calendar.
+func (d *Date) IsOldStyle() bool {
+	if d == nil || d.Date == nil {
+		return false
+	}
+	c := d.Date.Calendar()
+	return c == gdate.Julian || c == gdate.Julian25Mar
+}
+
+// DisplayYear returns the year of d as it should be shown, as a dual year
+// such as 1719/20 if the date is dual dated.
+func (d *Date) DisplayYear() (string, bool) {
+	yr, ok := d.Year()
+	if !ok {
+		return "", false
+	}
+	if d.IsDualDated() {
+		return DualYear(yr), true
+	}
+	return strconv.Itoa(yr), true
+}
+
+// newStyleYear returns the year y of d counted from 1 January, which is the
+// following year if d is dual dated.
+func (d *Date) newStyleYear(y int) int {
+	if d.IsDualDated() {
+		return y + 1
+	}
+	return y
+}
+
+// DualYear formats the year that began on 25 March in yr as a dual year
+// such as 1719/20 or 1699/1700.
+func DualYear(yr int) string {
+	next := yr + 1
+	if next/100 != yr/100 {
+		return fmt.Sprintf("%d/%d", yr, next)
+	}
+	return fmt.Sprintf("%d/%02d", yr, next%100)
+}
+
+// NewStyle returns the Gregorian calendar equivalent of d, with the year
+// beginning on 1 January, if d is a precise date recorded under the Julian
+// calendar.
+func (d *Date) NewStyle() (*Date, bool) {
+	if d == nil {
+		return nil, false
+	}
+	dt, ok := d.Date.(*gdate.Precise)
+	if !ok {
+		return nil, false
+	}
+	y := dt.Y
+	switch dt.C {
+	case gdate.Julian:
+	case gdate.Julian25Mar:
+		if beforeLadyDay(dt.M, dt.D) {
+			y++
+		}
+	default:
+		return nil, false
+	}
+	gy, gm, gd := gregorianFromJulianDay(julianCalendarDay(y, dt.M, dt.D))
+	return &Date{
+		Date:       &gdate.Precise{C: gdate.Gregorian, Y: gy, M: gm, D: gd},
+		Derivation: d.Derivation,
+	}, true
+}
+
+// dateText returns the date without its derivation qualifier, with the year
+// shown as a dual year where one applies.
+func (d *Date) dateText() string {
+	return d.withDualYear(d.Date.String())
+}
+
+// withDualYear replaces the year of d in s with a dual year if d is dual dated.
+func (d *Date) withDualYear(s string) string {
+	if !d.IsDualDated() {
+		return s
+	}
+	yr, _ := d.Year()
+	ys := strconv.Itoa(yr)
+	i := strings.LastIndex(s, ys)
+	if i == -1 {
+		return s
+	}
+	// gdate may already have added a second year
+	end := i + len(ys)
+	if end < len(s) && s[end] == '/' {
+		end++
+		for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+			end++
+		}
+	}
+	return s[:i] + DualYear(yr) + s[end:]
+}
+
+// annotation returns the notes to follow d in text: the original wording of
+// the date, if it was converted, and its Gregorian equivalent, if that has
+// been requested for d.
+func (d *Date) annotation() string {
+	var notes []string
+	if d.Original != "" {
+		notes = append(notes, fmt.Sprintf("written %q", d.Original))
+	}
+	if d.ShowNewStyle {
+		if ns, ok := d.NewStyle(); ok {
+			notes = append(notes, ns.Date.String()+" New Style")
+		}
+	}
+	if len(notes) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(notes, "; ") + ")"
+}
+
+// OldStyleNote is a sentence explaining how dates recorded under the Julian
+// calendar are shown.
+const OldStyleNote = "Dates before the adoption of the Gregorian calendar in 1752 are given as they were recorded at the time, in the Julian calendar. Until then the year began on 25 March in England, Wales and Ireland, so dates between 1 January and 24 March are shown with both years, such as 12 Feb 1719/20."
+
+// beforeLadyDay reports whether the month and day fall between 1 January and
+// 24 March, before the Old Style new year.
+func beforeLadyDay(m, d int) bool {
+	return m < 3 || (m == 3 && d < 25)
+}
+
+// julianCalendarDay returns the Julian day number of a date in the Julian
+// calendar with the year beginning on 1 January.
+func julianCalendarDay(y, m, d int) int {
+	a := (14 - m) / 12
+	y += 4800 - a
+	m += 12*a - 3
+	return d + (153*m+2)/5 + 365*y + y/4 - 32083
+}
+
+// gregorianFromJulianDay returns the Gregorian calendar date of a Julian day
+// number.
+func gregorianFromJulianDay(jd int) (int, int, int) {
+	a := jd + 32044
+	b := (4*a + 3) / 146097
+	c := a - 146097*b/4
+	d := (4*c + 3) / 1461
+	e := c - 1461*d/4
+	m := (5*e + 2) / 153
+	return 100*b + d - 4800 + m/10, m + 3 - 12*(m/10), e - (153*m+2)/5 + 1
+}
@@ -0,0 +1,156 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iand/gdate"
+)
+
+func TestDualDates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		date     *Date
+		dual     bool
+		str      string
+		gedcom   string
+		newStyle string
+	}{
+		{
+			name:     "old_style_february",
+			date:     &Date{Date: &gdate.Precise{C: gdate.Julian25Mar, Y: 1719, M: 2, D: 12}},
+			dual:     true,
+			str:      "12 Feb 1719/20",
+			gedcom:   "12 FEB 1719/20",
+			newStyle: "23 Feb 1720",
+		},
+		{
+			name:     "old_style_lady_day",
+			date:     &Date{Date: &gdate.Precise{C: gdate.Julian25Mar, Y: 1720, M: 3, D: 25}},
+			str:      "25 Mar 1720",
+			gedcom:   "25 MAR 1720",
+			newStyle: "5 Apr 1720",
+		},
+		{
+			name:     "old_style_century",
+			date:     &Date{Date: &gdate.Precise{C: gdate.Julian25Mar, Y: 1699, M: 1, D: 1}},
+			dual:     true,
+			str:      "1 Jan 1699/1700",
+			gedcom:   "1 JAN 1699/1700",
+			newStyle: "11 Jan 1700",
+		},
+		{
+			name:   "old_style_month",
+			date:   &Date{Date: &gdate.MonthYear{C: gdate.Julian25Mar, Y: 1709, M: 1}},
+			dual:   true,
+			str:    "Jan 1709/10",
+			gedcom: "JAN 1709/10",
+		},
+		{
+			name:     "julian_calendar_change",
+			date:     &Date{Date: &gdate.Precise{C: gdate.Julian, Y: 1752, M: 9, D: 2}},
+			str:      "2 Sep 1752",
+			gedcom:   "2 SEP 1752",
+			newStyle: "13 Sep 1752",
+		},
+		{
+			name:   "gregorian",
+			date:   &Date{Date: &gdate.Precise{C: gdate.Gregorian, Y: 1800, M: 2, D: 12}},
+			str:    "12 Feb 1800",
+			gedcom: "12 FEB 1800",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.date.IsDualDated(); got != tc.dual {
+				t.Errorf("IsDualDated got %v, want %v", got, tc.dual)
+			}
+			if got := tc.date.String(); got != tc.str {
+				t.Errorf("String got %q, want %q", got, tc.str)
+			}
+			if tc.dual {
+				if got := tc.date.When(); !strings.Contains(got, strings.Fields(tc.str)[len(strings.Fields(tc.str))-1]) {
+					t.Errorf("When got %q, want it to contain a dual year", got)
+				}
+			}
+			if got := tc.date.Gedcom(); got != tc.gedcom {
+				t.Errorf("Gedcom got %q, want %q", got, tc.gedcom)
+			}
+			ns, ok := tc.date.NewStyle()
+			if tc.newStyle == "" {
+				if ok {
+					t.Errorf("NewStyle got %q, wanted none", ns.String())
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("NewStyle got none, want %q", tc.newStyle)
+			}
+			if got := ns.String(); got != tc.newStyle {
+				t.Errorf("NewStyle got %q, want %q", got, tc.newStyle)
+			}
+
+			tc.date.ShowNewStyle = true
+			if got, want := tc.date.String(), tc.str+" ("+tc.newStyle+" New Style)"; got != want {
+				t.Errorf("String with new style got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestISO8601DualDates(t *testing.T) {
+	testCases := []struct {
+		name string
+		date *Date
+		want string
+	}{
+		{
+			name: "old_style_february",
+			date: &Date{Date: &gdate.Precise{C: gdate.Julian25Mar, Y: 1720, M: 2, D: 10}},
+			want: "1721-02-10",
+		},
+		{
+			name: "old_style_lady_day",
+			date: &Date{Date: &gdate.Precise{C: gdate.Julian25Mar, Y: 1720, M: 3, D: 25}},
+			want: "1720-03-25",
+		},
+		{
+			name: "old_style_month",
+			date: &Date{Date: &gdate.MonthYear{C: gdate.Julian25Mar, Y: 1709, M: 1}},
+			want: "1710-01",
+		},
+		{
+			name: "gregorian",
+			date: &Date{Date: &gdate.Precise{C: gdate.Gregorian, Y: 1800, M: 2, D: 12}},
+			want: "1800-02-12",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.date.ISO8601()
+			if !ok {
+				t.Fatalf("ISO8601 got none, want %q", tc.want)
+			}
+			if got != tc.want {
+				t.Errorf("ISO8601 got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDateOriginal(t *testing.T) {
+	dt := &Date{
+		Date:     &gdate.Precise{C: gdate.Julian25Mar, Y: 1701, M: 2, D: 14},
+		Original: "14th day of 12th month 1701",
+	}
+	if got, want := dt.String(), `14 Feb 1701/02 (written "14th day of 12th month 1701")`; got != want {
+		t.Errorf("String got %q, want %q", got, want)
+	}
+
+	dt.ShowNewStyle = true
+	if got, want := dt.String(), `14 Feb 1701/02 (written "14th day of 12th month 1701"; 25 Feb 1702 New Style)`; got != want {
+		t.Errorf("String with new style got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,189 @@
+package model
+
+import (
+	"fmt"
+)
+
+// UKCensusDates are the dates on which the decennial censuses of the United Kingdom were taken.
+var UKCensusDates = []*Date{
+	PreciseDate(1841, 6, 6),
+	PreciseDate(1851, 3, 30),
+	PreciseDate(1861, 4, 7),
+	PreciseDate(1871, 4, 2),
+	PreciseDate(1881, 4, 3),
+	PreciseDate(1891, 4, 5),
+	PreciseDate(1901, 3, 31),
+	PreciseDate(1911, 4, 2),
+	PreciseDate(1921, 6, 19),
+}
+
+// A CompletenessItem is a single piece of evidence that contributes to the research completeness of a person.
+type CompletenessItem struct {
+	Label string // short description of the evidence, such as "Birth" or "1881 census"
+	Met   bool   // true if the evidence has been found
+}
+
+// Completeness is a measure of how well researched a person is, built from the evidence
+// expected to be found for them.
+type Completeness struct {
+	Items []CompletenessItem
+}
+
+// Score returns the percentage of expected evidence that has been found for the person.
+func (c *Completeness) Score() int {
+	if c == nil || len(c.Items) == 0 {
+		return 0
+	}
+	met := 0
+	for _, it := range c.Items {
+		if it.Met {
+			met++
+		}
+	}
+	return met * 100 / len(c.Items)
+}
+
+// Missing returns the labels of the expected evidence that has not been found.
+func (c *Completeness) Missing() []string {
+	if c == nil {
+		return nil
+	}
+	var missing []string
+	for _, it := range c.Items {
+		if !it.Met {
+			missing = append(missing, it.Label)
+		}
+	}
+	return missing
+}
+
+// EvaluateCompleteness assesses the evidence held for a person. Vital events count only when
+// they are supported by a citation. Marriages, deaths and burials are only expected when they
+// could have occurred, and a census is only expected for years in which the person is known to
+// have been alive and living in the United Kingdom.
+func EvaluateCompleteness(p *Person) *Completeness {
+	c := &Completeness{}
+	if p.IsUnknown() {
+		return c
+	}
+	add := func(label string, met bool) {
+		c.Items = append(c.Items, CompletenessItem{Label: label, Met: met})
+	}
+
+	cited := func(ev TimelineEvent) bool {
+		return ev != nil && len(ev.GetCitations()) > 0
+	}
+
+	var birth, baptism, death, burial bool
+	censuses := make(map[int]bool)
+	inUK := false
+	knownPlace := false
+	for _, ev := range p.Timeline {
+		if !ev.DirectlyInvolves(p) {
+			continue
+		}
+		if pl := ev.GetPlace(); !pl.IsUnknown() {
+			knownPlace = true
+			if !pl.UKNationName.IsUnknown() {
+				inUK = true
+			}
+		}
+		if !cited(ev) {
+			continue
+		}
+		switch ev.(type) {
+		case *BirthEvent:
+			birth = true
+		case *BaptismEvent:
+			baptism = true
+		case *DeathEvent:
+			death = true
+		case *BurialEvent, *CremationEvent:
+			burial = true
+		case *CensusEvent:
+			if yr, ok := ev.GetDate().Year(); ok {
+				censuses[yr] = true
+			}
+		}
+	}
+
+	add("Birth", birth)
+	add("Baptism", baptism)
+
+	if !p.DiedYoung && !p.Unmarried {
+		married := 0
+		for _, f := range p.Families {
+			if f.Bond != FamilyBondMarried {
+				continue
+			}
+			married++
+			label := "Marriage"
+			if sp := f.OtherParent(p); !sp.IsUnknown() {
+				label = fmt.Sprintf("Marriage to %s", sp.PreferredUniqueName)
+			}
+			add(label, cited(f.BestStartEvent))
+		}
+		if married == 0 {
+			add("Marriage", false)
+		}
+	}
+
+	if !p.PossiblyAlive {
+		add("Death", death)
+		add("Burial", burial)
+	}
+
+	if inUK || !knownPlace {
+		for _, dt := range p.censusDatesAlive() {
+			yr, _ := dt.Year()
+			add(fmt.Sprintf("%d census", yr), censuses[yr])
+		}
+	}
+
+	add("Father", !p.Father.IsUnknown())
+	add("Mother", !p.Mother.IsUnknown())
+
+	if !p.DiedYoung {
+		add("Occupation", len(p.Occupations) > 0)
+	}
+
+	return c
+}
+
+// maxLifespan is the most years a person is assumed to have lived when no
+// date of death is known.
+const maxLifespan = 120
+
+// censusDatesAlive returns the census dates on which the person may have been
+// alive. Without a date of death the person is assumed to have lived no longer
+// than maxLifespan years.
+func (p *Person) censusDatesAlive() []*Date {
+	if p.BestBirthlikeEvent == nil || p.BestBirthlikeEvent.GetDate().IsUnknown() {
+		return nil
+	}
+	born := p.BestBirthlikeEvent.GetDate()
+
+	var died *Date
+	if p.BestDeathlikeEvent != nil && !p.BestDeathlikeEvent.GetDate().IsUnknown() {
+		died = p.BestDeathlikeEvent.GetDate()
+	}
+	lastYear := 0
+	if yr, ok := born.Year(); ok {
+		lastYear = yr + maxLifespan
+	}
+
+	var dates []*Date
+	for _, dt := range UKCensusDates {
+		if !born.SortsBefore(dt) {
+			continue
+		}
+		if died != nil && !dt.SortsBefore(died) {
+			continue
+		}
+		if yr, _ := dt.Year(); died == nil && lastYear != 0 && yr > lastYear {
+			continue
+		}
+		dates = append(dates, dt)
+	}
+	return dates
+}
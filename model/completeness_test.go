@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEvaluateCompleteness(t *testing.T) {
+	cit := []*GeneralCitation{{ID: "c1"}}
+
+	p := &Person{ID: "p", PreferredGivenName: "John"}
+	birth := &BirthEvent{
+		GeneralEvent:           GeneralEvent{Date: PreciseDate(1855, 6, 1), Citations: cit},
+		GeneralIndividualEvent: GeneralIndividualEvent{Principal: p},
+	}
+	death := &DeathEvent{
+		GeneralEvent:           GeneralEvent{Date: PreciseDate(1885, 2, 1)},
+		GeneralIndividualEvent: GeneralIndividualEvent{Principal: p},
+	}
+	census := &CensusEvent{
+		GeneralEvent: GeneralEvent{Date: PreciseDate(1861, 4, 7), Citations: cit},
+		Entries:      []*CensusEntry{{Principal: p}},
+	}
+	p.BestBirthlikeEvent = birth
+	p.BestDeathlikeEvent = death
+	p.Timeline = []TimelineEvent{birth, census, death}
+	p.Father = &Person{ID: "f"}
+	p.Unmarried = true
+
+	c := EvaluateCompleteness(p)
+
+	want := []string{"Baptism", "Death", "Burial", "1871 census", "1881 census", "Mother", "Occupation"}
+	if diff := cmp.Diff(want, c.Missing()); diff != "" {
+		t.Errorf("missing evidence mismatch (-want, +got):\n%s", diff)
+	}
+
+	// birth, 1861 census and father found out of 10 expected items
+	if got := c.Score(); got != 30 {
+		t.Errorf("got score %d, wanted 30", got)
+	}
+}
+
+func TestCompletenessScoreEmpty(t *testing.T) {
+	var c *Completeness
+	if got := c.Score(); got != 0 {
+		t.Errorf("got score %d for nil completeness, wanted 0", got)
+	}
+	if got := EvaluateCompleteness(&Person{Unknown: true}).Score(); got != 0 {
+		t.Errorf("got score %d for unknown person, wanted 0", got)
+	}
+}
+
+func TestCensusDatesAliveWithoutDeath(t *testing.T) {
+	p := &Person{ID: "p"}
+	p.BestBirthlikeEvent = &BirthEvent{
+		GeneralEvent:           GeneralEvent{Date: PreciseDate(1760, 3, 1)},
+		GeneralIndividualEvent: GeneralIndividualEvent{Principal: p},
+	}
+
+	var got []int
+	for _, dt := range p.censusDatesAlive() {
+		yr, _ := dt.Year()
+		got = append(got, yr)
+	}
+
+	want := []int{1841, 1851, 1861, 1871}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("census years mismatch (-want, +got):\n%s", diff)
+	}
+}
@@ -17,6 +17,14 @@ type Date struct {
 	// when true the date represents a span of time during which something continuously occurred (such as a residing at an address)
 	// when false it represents a range of time during which a discrete event occurred
 	Span bool
+
+	// ShowNewStyle indicates whether a date recorded under the Julian calendar
+	// should be followed by its Gregorian equivalent when shown
+	ShowNewStyle bool
+
+	// Original is the wording of the date as it was recorded, kept when the
+	// date was converted from a form such as a Quaker or regnal date
+	Original string
 }
 
 type DateDerivation int
@@ -204,7 +212,7 @@ func (d *Date) String() string {
 		qual += " "
 	}
 
-	return qual + d.Date.String()
+	return qual + d.dateText() + d.annotation()
 }
 
 func (d *Date) When() string {
@@ -212,7 +220,7 @@ func (d *Date) When() string {
 		return "on an unknown date"
 	}
 
-	return d.Date.Occurrence()
+	return d.withDualYear(d.Date.Occurrence()) + d.annotation()
 
 	// if d.Date.Calendar() == gdate.Gregorian {
 	// 	return d.Date.Occurrence()
@@ -261,6 +269,27 @@ func (d *Date) AsYear() (*Date, bool) {
 	}, true
 }
 
+// ISO8601 returns d formatted as an ISO 8601 calendar date: YYYY-MM-DD for a
+// precise date, YYYY-MM for a month and year or YYYY for a year. It reports
+// false for unknown and imprecise dates such as ranges and approximations.
+// The year of a dual dated Old Style date is given in the New Style, with the
+// year beginning on 1 January, so 10 Feb 1720/21 is 1721-02-10.
+func (d *Date) ISO8601() (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	switch dt := d.Date.(type) {
+	case *gdate.Precise:
+		return fmt.Sprintf("%04d-%02d-%02d", d.newStyleYear(dt.Y), dt.M, dt.D), true
+	case *gdate.MonthYear:
+		return fmt.Sprintf("%04d-%02d", d.newStyleYear(dt.Y), dt.M), true
+	case *gdate.Year:
+		return fmt.Sprintf("%04d", dt.Y), true
+	default:
+		return "", false
+	}
+}
+
 func (d *Date) Year() (int, bool) {
 	if d == nil {
 		return 0, false
@@ -404,9 +433,11 @@ func (d *Date) Gedcom() string {
 
 	switch dt := d.Date.(type) {
 	case *gdate.Precise:
-		return fmt.Sprintf("%s%d %s %d", prefix, dt.D, strings.ToUpper(shortMonthNames[dt.M]), dt.Y)
+		yr, _ := d.DisplayYear()
+		return fmt.Sprintf("%s%d %s %s", prefix, dt.D, strings.ToUpper(shortMonthNames[dt.M]), yr)
 	case *gdate.MonthYear:
-		return fmt.Sprintf("%s%s %d", prefix, strings.ToUpper(shortMonthNames[dt.M]), dt.Y)
+		yr, _ := d.DisplayYear()
+		return fmt.Sprintf("%s%s %s", prefix, strings.ToUpper(shortMonthNames[dt.M]), yr)
 	case *gdate.Year:
 		return fmt.Sprintf("%s%d", prefix, dt.Y)
 	case *gdate.YearQuarter:
@@ -23,6 +23,8 @@ type TimelineEvent interface {
 	AddParticipant(*EventParticipant)
 	GetParticipantsByRole(EventRole) []*EventParticipant
 	GetMediaObjects() []*CitedMediaObject
+	GetRegistration() *Registration // civil registration details, nil if not known
+	SetPlace(*Place)
 }
 
 // IndividualTimelineEvent is a timeline event involving one individual.
@@ -114,8 +116,9 @@ type GeneralEvent struct {
 	Narrative    Text // hand written narrative, if any
 	Attributes   map[string]string
 	MediaObjects []*CitedMediaObject
-	UpdateTime   *time.Time // time of last update, if known
-	CreateTime   *time.Time // time of creation if known
+	Registration *Registration // where and when the event was civilly registered, nil if not known
+	UpdateTime   *time.Time    // time of last update, if known
+	CreateTime   *time.Time    // time of creation if known
 }
 
 func (e *GeneralEvent) GetDate() *Date {
@@ -155,6 +158,14 @@ func (e *GeneralEvent) GetMediaObjects() []*CitedMediaObject {
 	return e.MediaObjects
 }
 
+func (e *GeneralEvent) SetPlace(pl *Place) {
+	e.Place = pl
+}
+
+func (e *GeneralEvent) GetRegistration() *Registration {
+	return e.Registration
+}
+
 func (e *GeneralEvent) EventDate() *Date {
 	return e.Date
 }
@@ -49,7 +49,7 @@ func AbbrevWhen(ev TimelineEvent) string {
 	// 	qual += " "
 	// }
 
-	return qual + dt.Date.String()
+	return qual + dt.dateText()
 }
 
 func AbbrevWhenWhere(ev TimelineEvent) string {
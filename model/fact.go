@@ -120,6 +120,7 @@ const (
 	LinkCategoryQuestionSubject LinkCategory = "question-subject"
 	LinkCategoryQuestionMention LinkCategory = "question-mention"
 	LinkCategoryWebsite         LinkCategory = "website"
+	LinkCategorySearch          LinkCategory = "search"
 )
 
 type Link struct {
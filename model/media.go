@@ -22,7 +22,8 @@ type MediaObject struct {
 	Width       int
 	Height      int
 	Citations   []*GeneralCitation
-	Redacted    bool // true if the object's details should be redacted
+	Redacted    bool              // true if the object's details should be redacted
+	Derivatives []MediaDerivative // resized copies of the whole image written for publishing, smallest first
 }
 
 type Region struct {
@@ -33,6 +34,16 @@ type Region struct {
 }
 
 type CitedMediaObject struct {
-	Object    *MediaObject
-	Highlight *Region
+	Object      *MediaObject
+	Highlight   *Region
+	Derivatives []MediaDerivative // resized copies of the highlighted region written for publishing, smallest first
+}
+
+// A MediaDerivative is a resized copy of a media object, or of a region of
+// one, written alongside the published pages.
+type MediaDerivative struct {
+	Size   string // name of the size, such as "thumbnail", "medium" or "full"
+	Link   string // link to the derivative image
+	Width  int    // width of the derivative in pixels
+	Height int    // height of the derivative in pixels
 }
@@ -34,6 +34,54 @@ const (
 	OccupationGroupService    OccupationGroup = "service" // nurse, servant, valet, groom
 )
 
+// SocialClass is the social class band of an occupation, following the five
+// classes used by the Registrar General from 1911.
+type SocialClass int
+
+const (
+	SocialClassUnknown       SocialClass = 0
+	SocialClassProfessional  SocialClass = 1 // I: clergyman, surgeon, solicitor
+	SocialClassIntermediate  SocialClass = 2 // II: farmer, shopkeeper, teacher
+	SocialClassSkilled       SocialClass = 3 // III: carpenter, clerk, blacksmith
+	SocialClassPartlySkilled SocialClass = 4 // IV: agricultural labourer, servant
+	SocialClassUnskilled     SocialClass = 5 // V: general labourer, charwoman
+)
+
+func (c SocialClass) String() string {
+	switch c {
+	case SocialClassProfessional:
+		return "professional"
+	case SocialClassIntermediate:
+		return "intermediate"
+	case SocialClassSkilled:
+		return "skilled"
+	case SocialClassPartlySkilled:
+		return "partly skilled"
+	case SocialClassUnskilled:
+		return "unskilled"
+	default:
+		return ""
+	}
+}
+
+// Numeral returns the roman numeral conventionally used to label the class.
+func (c SocialClass) Numeral() string {
+	switch c {
+	case SocialClassProfessional:
+		return "I"
+	case SocialClassIntermediate:
+		return "II"
+	case SocialClassSkilled:
+		return "III"
+	case SocialClassPartlySkilled:
+		return "IV"
+	case SocialClassUnskilled:
+		return "V"
+	default:
+		return ""
+	}
+}
+
 type Occupation struct {
 	Date        *Date
 	StartDate   *Date
@@ -41,9 +89,11 @@ type Occupation struct {
 	Place       *Place
 	Name        string // the name of the occupation, to be used in a sentence as `a {name}`
 	Comment     string // an explanatatory comment to be used alongside or as a footnote to the title
-	Detail      string
+	Detail      string // the occupation as worded in the record
 	Status      OccupationStatus
 	Group       OccupationGroup
+	Code        string      // HISCO code of the occupation, empty if it is not in the occupation dictionary
+	Class       SocialClass // social class band of the occupation
 	Citations   []*GeneralCitation
 	Occurrences int
 	Unknown     bool
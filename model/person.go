@@ -69,17 +69,20 @@ type Person struct {
 	Puzzle             bool          // true if this person is the centre of a significant puzzle
 	Occupations        []*Occupation // list of occupations
 	OccupationGroup    OccupationGroup
-	WikiTreeID         string // the wikitree id of this person
-	GrampsID           string // the gramps id of this person
-	FamilySearchID     string // the familysearch id of this person
-	Slug               string // a short url-friendly identifier that can be used to refer to this person
-	Links              []Link // list of links to more information relevant to this person
+	SocialClass        SocialClass // social class band of the person's main occupation
+	WikiTreeID         string      // the wikitree id of this person
+	GrampsID           string      // the gramps id of this person
+	FamilySearchID     string      // the familysearch id of this person
+	Slug               string      // a short url-friendly identifier that can be used to refer to this person
+	Links              []Link      // list of links to more information relevant to this person
+	Searches           []*Search   // searches made for this person, from the research log
 
 	Redacted           bool                // true if the person's details should be redacted
 	RedactionKeepsName bool                // true if this person's name should be kept during redaction
 	Inferences         []Inference         // list of inferences made
 	Anomalies          []*Anomaly          // list of anomalies detected
 	ToDos              []*ToDo             // list of todos detected
+	Completeness       *Completeness       // measure of how well researched the person is
 	MiscFacts          []Fact              // miscellaneous facts
 	Associations       []Association       // general associations with other people such as godparent or twin
 	FeatureImage       *CitedMediaObject   // an image that can be used to represent the person
@@ -1,7 +1,9 @@
 package model
 
 import (
+	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,8 +14,10 @@ const UnknownPlaceName = "unknown"
 
 type Place struct {
 	ID           string   // canonical identifier
+	GrampsID     string   // the original gramps id, if any
 	Tags         []string // tags to add to the place's page
 	OriginalText string   // the original text that was used to fill in the place information
+	SameAsID     string   // the id of a place this place should be merged into, set by annotation
 	Hints        []place.Hint
 	// TODO: consolidate these name fields
 	// Need names that are:
@@ -54,12 +58,16 @@ type Place struct {
 	Links        []Link       // list of links to more information relevant to this place
 	GeoLocation  *GeoLocation // geographic location of the place
 
+	Jurisdictions []*PlaceJurisdiction // the places that have contained this one over time, if they changed
+	AltNames      []*PlaceAltName      // other names the place has been known by
+
 	Country  *Place // Country is the place lowest in the parent hierarchy that has the type of country
 	Region   *Place // Region is the regional place lowest in the parent hierarchy below the country, such as state, county, nation, island, archipelago etc.
 	District *Place // District is the populated place lowest in the parent hierarchy below the region level, such as a parish, town, village etc.
 
 	CountryName  *place.PlaceName
 	UKNationName *place.PlaceName
+	Gazetteer    *place.PlaceName // the gazetteer entry describing the place, nil if it has none
 
 	ResearchNotes []Text              // research notes associated with this place
 	Comments      []Text              // comments associated with this place
@@ -69,6 +77,78 @@ type Place struct {
 	CreateTime *time.Time // time of creation
 }
 
+// A YearPeriod is a span of years, including the first and last. It is open
+// at either end when the year is zero.
+type YearPeriod struct {
+	From int // the first year of the period, zero if not known
+	To   int // the last year of the period, zero if the period has not ended or is not known
+}
+
+// ParseYearPeriod parses a period written as from-to, such as 1889-1965,
+// -1888 or 1965-. A single year is a period of that year alone.
+func ParseYearPeriod(s string) (YearPeriod, error) {
+	var yp YearPeriod
+	from, to, found := strings.Cut(strings.TrimSpace(s), "-")
+	if !found {
+		to = from
+	}
+	var err error
+	if from = strings.TrimSpace(from); from != "" {
+		if yp.From, err = strconv.Atoi(from); err != nil {
+			return yp, fmt.Errorf("invalid year %q", from)
+		}
+	}
+	if to = strings.TrimSpace(to); to != "" {
+		if yp.To, err = strconv.Atoi(to); err != nil {
+			return yp, fmt.Errorf("invalid year %q", to)
+		}
+	}
+	if yp.From != 0 && yp.To != 0 && yp.To < yp.From {
+		return yp, fmt.Errorf("period %q ends before it starts", s)
+	}
+	return yp, nil
+}
+
+// IsDated reports whether either end of the period is known.
+func (yp YearPeriod) IsDated() bool {
+	return yp.From != 0 || yp.To != 0
+}
+
+// Covers reports whether the year yr falls within the period.
+func (yp YearPeriod) Covers(yr int) bool {
+	return (yp.From == 0 || yr >= yp.From) && (yp.To == 0 || yr <= yp.To)
+}
+
+// When describes the period as a phrase such as "from 1889 to 1965".
+func (yp YearPeriod) When() string {
+	switch {
+	case yp.From != 0 && yp.To != 0 && yp.From == yp.To:
+		return fmt.Sprintf("in %d", yp.From)
+	case yp.From != 0 && yp.To != 0:
+		return fmt.Sprintf("from %d to %d", yp.From, yp.To)
+	case yp.From != 0:
+		return fmt.Sprintf("from %d", yp.From)
+	case yp.To != 0:
+		return fmt.Sprintf("until %d", yp.To)
+	default:
+		return ""
+	}
+}
+
+// A PlaceJurisdiction records that a place lay within another for a period.
+type PlaceJurisdiction struct {
+	YearPeriod
+	Parent   *Place
+	ParentID string // the id of the parent when set by annotation, before it has been resolved
+}
+
+// A PlaceAltName is another name a place was known by, for a period if one
+// is given.
+type PlaceAltName struct {
+	YearPeriod
+	Name string
+}
+
 type GeoLocation struct {
 	Latitude  float64 // latitude of the centre in decimal degrees, +ve is east of meridian, -ve is west
 	Longitude float64 // longitude of the centre in decimal degrees, +ve is north of equator, -ve is south
@@ -186,6 +266,45 @@ func (p *Place) Hierarchy() []*Place {
 	return hierarchy
 }
 
+// NameIn returns the name the place was known by in the year yr.
+func (p *Place) NameIn(yr int) string {
+	for _, an := range p.AltNames {
+		if an.IsDated() && an.Covers(yr) {
+			return an.Name
+		}
+	}
+	return p.Name
+}
+
+// ParentIn returns the place that contained this one in the year yr.
+func (p *Place) ParentIn(yr int) *Place {
+	for _, j := range p.Jurisdictions {
+		if j.Parent != nil && j.IsDated() && j.Covers(yr) {
+			return j.Parent
+		}
+	}
+	return p.Parent
+}
+
+// HasHistory reports whether the name of the place or the places containing
+// it changed over time.
+func (p *Place) HasHistory() bool {
+	if p == nil {
+		return false
+	}
+	for _, j := range p.Jurisdictions {
+		if j.IsDated() {
+			return true
+		}
+	}
+	for _, an := range p.AltNames {
+		if an.IsDated() {
+			return true
+		}
+	}
+	return false
+}
+
 func UnknownPlace() *Place {
 	return &Place{
 		Name:              UnknownPlaceName,
@@ -0,0 +1,39 @@
+package model
+
+import "testing"
+
+func TestParseYearPeriod(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want YearPeriod
+		when string
+		err  bool
+	}{
+		{in: "1889-1965", want: YearPeriod{From: 1889, To: 1965}, when: "from 1889 to 1965"},
+		{in: "-1888", want: YearPeriod{To: 1888}, when: "until 1888"},
+		{in: "1965-", want: YearPeriod{From: 1965}, when: "from 1965"},
+		{in: "1900", want: YearPeriod{From: 1900, To: 1900}, when: "in 1900"},
+		{in: "1965-1889", err: true},
+		{in: "early", err: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseYearPeriod(tc.in)
+			if tc.err {
+				if err == nil {
+					t.Errorf("got no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+			if got.When() != tc.when {
+				t.Errorf("got %q, want %q", got.When(), tc.when)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+import "fmt"
+
+// A Registration records where and when an event was registered by the civil
+// registration system of England and Wales, which indexes births, marriages
+// and deaths by registration district and quarter.
+type Registration struct {
+	District *Place // the registration district
+	Year     int    // year of the index quarter
+	Quarter  int    // index quarter, 1 for the quarter ending in March to 4 for December, 0 if not known
+	Late     bool   // true if the event may instead be indexed in the following quarter
+}
+
+var quarterNames = []string{"", "March", "June", "September", "December"}
+
+// QuarterName returns the name of the index quarter, such as "June quarter
+// of 1850", or an empty string if it is not known.
+func (r *Registration) QuarterName() string {
+	if r == nil || r.Quarter < 1 || r.Quarter > 4 {
+		return ""
+	}
+	if !r.Late {
+		return fmt.Sprintf("%s quarter of %d", quarterNames[r.Quarter], r.Year)
+	}
+	if r.Quarter == 4 {
+		return fmt.Sprintf("December quarter of %d or March quarter of %d", r.Year, r.Year+1)
+	}
+	return fmt.Sprintf("%s or %s quarter of %d", quarterNames[r.Quarter], quarterNames[r.Quarter+1], r.Year)
+}
@@ -0,0 +1,43 @@
+package model
+
+import "strings"
+
+// A SearchResult is the outcome of a search of a record set.
+type SearchResult string
+
+const (
+	SearchResultUnknown SearchResult = ""
+	SearchResultFound   SearchResult = "found"   // the records sought were found
+	SearchResultNil     SearchResult = "nil"     // nothing relevant was found
+	SearchResultPartial SearchResult = "partial" // some of the records sought were found
+)
+
+// A Search is a search of a record set made during research, as recorded in
+// the research log of a diary entry.
+type Search struct {
+	Repository string             // the repository or website searched, such as "FreeBMD"
+	RecordSet  string             // the record set searched, such as "GRO births"
+	From       int                // the first year searched, zero if the search was not limited
+	To         int                // the last year searched, zero if the search was not limited
+	Result     SearchResult       // the outcome of the search
+	People     []*Person          // the people sought
+	Citations  []*GeneralCitation // citations for the records found
+	Notes      string             // notes about the search, such as the variants tried
+	Link       Link               // the diary entry recording the search
+}
+
+// Covers reports whether the search was of the record set called recordSet
+// and included year. Record set names are compared without regard to case.
+// A search without a date range covers every year.
+func (s *Search) Covers(recordSet string, year int) bool {
+	if !strings.EqualFold(s.RecordSet, recordSet) {
+		return false
+	}
+	if s.From != 0 && year < s.From {
+		return false
+	}
+	if s.To != 0 && year > s.To {
+		return false
+	}
+	return true
+}
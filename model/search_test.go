@@ -0,0 +1,69 @@
+package model
+
+import "testing"
+
+func TestSearchCovers(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		search    Search
+		recordSet string
+		year      int
+		want      bool
+	}{
+		{
+			name:      "within range",
+			search:    Search{RecordSet: "GRO births", From: 1837, To: 1845},
+			recordSet: "GRO births",
+			year:      1840,
+			want:      true,
+		},
+		{
+			name:      "record set case differs",
+			search:    Search{RecordSet: "gro Births", From: 1837, To: 1845},
+			recordSet: "GRO births",
+			year:      1845,
+			want:      true,
+		},
+		{
+			name:      "before range",
+			search:    Search{RecordSet: "GRO births", From: 1837, To: 1845},
+			recordSet: "GRO births",
+			year:      1836,
+			want:      false,
+		},
+		{
+			name:      "after range",
+			search:    Search{RecordSet: "GRO births", From: 1837, To: 1845},
+			recordSet: "GRO births",
+			year:      1846,
+			want:      false,
+		},
+		{
+			name:      "open ended",
+			search:    Search{RecordSet: "GRO births", From: 1837},
+			recordSet: "GRO births",
+			year:      1900,
+			want:      true,
+		},
+		{
+			name:      "no range",
+			search:    Search{RecordSet: "GRO deaths"},
+			recordSet: "GRO deaths",
+			year:      1900,
+			want:      true,
+		},
+		{
+			name:      "other record set",
+			search:    Search{RecordSet: "GRO deaths"},
+			recordSet: "GRO births",
+			year:      1900,
+			want:      false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.search.Covers(tt.recordSet, tt.year); got != tt.want {
+				t.Errorf("got %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ const (
 
 type Source struct {
 	ID                  string // canonical id
+	GrampsID            string // the original gramps id, if any
 	Unknown             bool   // true if this source is known to have existed but no other information is known
 	Title               string
 	Author              string
@@ -26,6 +27,7 @@ type Source struct {
 	RepositoryRefs      []RepositoryRef
 	EventsCiting        []TimelineEvent
 	Tags                []string
+	Links               []Link // list of links to content pages that refer to this source
 	Quality             SourceQuality
 	IsCivilRegistration bool // indicates whether this source holds civil registration records such as births marriages and deaths
 	IsCensus            bool // indicates whether this source holds census records
@@ -18,4 +18,12 @@ type ToDo struct {
 	Context  string
 	Goal     string
 	Reason   string
+
+	// RecordSet is the record set the todo suggests searching, if any. It is
+	// matched against the record sets of searches in the research log.
+	RecordSet string
+
+	// Hints are links to searches of external record collections that may
+	// help complete the todo.
+	Hints []Link
 }
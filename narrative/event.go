@@ -3,6 +3,7 @@ package narrative
 import (
 	"fmt"
 
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/render"
@@ -15,15 +16,23 @@ type NarrativeTimelineEntryFormatter[T render.EncodedText] struct {
 	omitDate bool
 	logger   *logging.Logger
 	nc       NameChooser
+	loc      locale.Locale
 }
 
-func NewNarrativeTimelineEntryFormatter[T render.EncodedText](pov *model.POV, enc render.TextEncoder[T], logger *logging.Logger, nc NameChooser, omitDate bool) *NarrativeTimelineEntryFormatter[T] {
+// NewNarrativeTimelineEntryFormatter returns a formatter that words timeline
+// entries from the point of view of pov in the language of loc. A nil loc
+// selects the default locale.
+func NewNarrativeTimelineEntryFormatter[T render.EncodedText](pov *model.POV, enc render.TextEncoder[T], logger *logging.Logger, nc NameChooser, omitDate bool, loc locale.Locale) *NarrativeTimelineEntryFormatter[T] {
+	if loc == nil {
+		loc = locale.Default
+	}
 	return &NarrativeTimelineEntryFormatter[T]{
 		pov:      pov,
 		enc:      enc,
 		omitDate: omitDate,
 		logger:   logger,
 		nc:       nc,
+		loc:      loc,
 	}
 }
 
@@ -96,10 +105,10 @@ func (t *NarrativeTimelineEntryFormatter[T]) whenWhat(ev model.TimelineEvent) st
 	if !date.IsUnknown() {
 		switch ev.GetDate().Derivation {
 		case model.DateDerivationEstimated, model.DateDerivationCalculated:
-			return model.ConditionalWhat(ev, "probably") + " around this time"
+			return t.loc.ConditionalWhat(ev, "probably") + " " + t.loc.T("around this time")
 		}
 	}
-	return model.What(ev)
+	return t.loc.What(ev)
 }
 
 func (t *NarrativeTimelineEntryFormatter[T]) vitalEventTitle(seq int, ev model.IndividualTimelineEvent) string {
@@ -138,7 +147,7 @@ func (t *NarrativeTimelineEntryFormatter[T]) vitalEventTitle(seq int, ev model.I
 			if includeAge {
 				if ev != t.pov.Person.BestBirthlikeEvent {
 					if age, ok := t.pov.Person.AgeInYearsAt(date); ok {
-						title = text.JoinSentenceParts(title, AgeQualifier(age))
+						title = text.JoinSentenceParts(title, AgeQualifier(age, t.loc))
 					}
 				}
 			}
@@ -149,16 +158,16 @@ func (t *NarrativeTimelineEntryFormatter[T]) vitalEventTitle(seq int, ev model.I
 					name := t.enc.EncodeModelLinkNamed(infs[0].Person, t.nc, t.pov).String()
 					rel := infs[0].Person.RelationTo(t.pov.Person, ev.GetDate())
 					if rel != "" {
-						rel = t.pov.Person.Gender.PossessivePronounSingular() + " " + rel
+						rel = t.loc.PossessivePronoun(t.pov.Person.Gender, rel)
 					}
-					trailer = text.JoinSentenceParts("the informant was", rel, name)
+					trailer = text.JoinSentenceParts(t.loc.T("the informant was"), rel, name)
 				}
 			}
 
 		case model.EventRoleInformant:
 			obsContext := t.observerContext(ev, true)
 			title = text.JoinSentenceParts(title, obsContext, what)
-			trailer = text.JoinSentenceParts(t.pov.Person.Gender.SubjectPronounWithLink(), "the informant")
+			trailer = text.JoinSentenceParts(t.loc.SubjectPronounWithLink(t.pov.Person.Gender), t.loc.T("the informant"))
 		default:
 			t.logger.Warn("unsupported vital event role", "role", ep.Role)
 			obsContext := t.observerContext(ev, true)
@@ -168,39 +177,42 @@ func (t *NarrativeTimelineEntryFormatter[T]) vitalEventTitle(seq int, ev model.I
 
 	pl := ev.GetPlace()
 	if pl.SameAs(t.pov.Place) {
-		title = text.JoinSentenceParts(title, "here")
+		title = text.JoinSentenceParts(title, t.loc.T("here"))
 	}
 
 	if placeIsKnownAndIsNotSameAsPointOfView(pl, t.pov) {
-		title = WhatWhere(title, pl, t.enc, t.nc)
+		title = WhatWhere(title, pl, t.enc, t.nc, t.loc)
 	}
 
 	if trailer != "" {
 		title = text.JoinSentences(title, trailer)
 	}
+	if reg := registrationSentence(ev, t.enc, t.loc); reg != "" {
+		title = text.JoinSentences(title, reg)
+	}
 
 	return title
 }
 
 func (t *NarrativeTimelineEntryFormatter[T]) censusEventTitle(seq int, ev *model.CensusEvent) string {
 	var title string
-	title = text.JoinSentenceParts(title, t.observerContext(ev, false), "recorded in the")
-	year, ok := ev.GetDate().Year()
-	if ok {
-		title = text.JoinSentenceParts(title, fmt.Sprintf("%d ", year))
+	title = text.JoinSentenceParts(title, t.observerContext(ev, false))
+	if year, ok := ev.GetDate().Year(); ok {
+		title = text.JoinSentenceParts(title, t.loc.Tf("recorded in the %d census", year))
+	} else {
+		title = text.JoinSentenceParts(title, t.loc.T("recorded in the census"))
 	}
-	title = text.JoinSentenceParts(title, "census")
 
 	entry, _ := ev.Entry(t.pov.Person)
 	if entry != nil && entry.RelationToHead.IsImpersonal() {
-		title = text.JoinSentenceParts(title, "as", "a"+text.MaybeAn(string(entry.RelationToHead)))
+		title = text.JoinSentenceParts(title, t.loc.T("as"), t.loc.Indefinite(string(entry.RelationToHead)))
 	}
 
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
-		residing := ChooseFrom(seq, "residing", "", "living")
+		residing := ChooseFrom(seq, t.loc.T("residing"), "", t.loc.T("living"))
 		title = text.JoinSentenceParts(title, residing)
-		title = WhatWherePov(title, pl, t.enc, t.nc, t.pov)
+		title = WhatWherePov(title, pl, t.enc, t.nc, t.pov, t.loc)
 	}
 	return title
 }
@@ -214,7 +226,7 @@ func (t *NarrativeTimelineEntryFormatter[T]) generalEventTitle(seq int, ev model
 	title = text.JoinSentenceParts(title, t.whenWhat(ev))
 
 	pl := ev.GetPlace()
-	title = WhatWherePov(title, pl, t.enc, t.nc, t.pov)
+	title = WhatWherePov(title, pl, t.enc, t.nc, t.pov, t.loc)
 	return title
 }
 
@@ -228,16 +240,16 @@ func (t *NarrativeTimelineEntryFormatter[T]) probateEventTitle(seq int, ev model
 		// Does not participate so this is someone else's event
 		title = what
 		if pl.SameAs(t.pov.Place) {
-			title = text.JoinSentenceParts(title, "here")
+			title = text.JoinSentenceParts(title, t.loc.T("here"))
 		}
 
 		obsContext := t.observerContext(ev, true)
 		if obsContext != "" {
-			title = text.JoinSentenceParts(title, "for", obsContext)
+			title = text.JoinSentenceParts(title, t.loc.T("for"), obsContext)
 		}
 
 		if placeIsKnownAndIsNotSameAsPointOfView(pl, t.pov) {
-			title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc), "probate office")
+			title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc, t.loc), t.loc.T("probate office"))
 		}
 	} else if len(eps) > 0 {
 		ep := eps[0]
@@ -245,18 +257,18 @@ func (t *NarrativeTimelineEntryFormatter[T]) probateEventTitle(seq int, ev model
 		case model.EventRolePrincipal:
 			title = what
 			if pl.SameAs(t.pov.Place) {
-				title = text.JoinSentenceParts(title, "here")
+				title = text.JoinSentenceParts(title, t.loc.T("here"))
 			}
 			if placeIsKnownAndIsNotSameAsPointOfView(pl, t.pov) {
-				title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc), "probate office")
+				title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc, t.loc), t.loc.T("probate office"))
 			}
 		case model.EventRoleBeneficiary:
 			// James John Simpson
-			title = text.JoinSentenceParts("Beneficiary of the probate of", t.observerContext(ev, true))
+			title = text.JoinSentenceParts(t.loc.T("Beneficiary of the probate of"), t.observerContext(ev, true))
 		case model.EventRoleExecutor:
-			title = text.JoinSentenceParts("Executor of the probate of", t.observerContext(ev, true))
+			title = text.JoinSentenceParts(t.loc.T("Executor of the probate of"), t.observerContext(ev, true))
 		case model.EventRoleAdministrator:
-			title = text.JoinSentenceParts("Administrator of the probate of", t.observerContext(ev, true))
+			title = text.JoinSentenceParts(t.loc.T("Administrator of the probate of"), t.observerContext(ev, true))
 		default:
 			t.logger.Warn("unsupported role in probate event", "role", ep.Role)
 		}
@@ -275,16 +287,16 @@ func (t *NarrativeTimelineEntryFormatter[T]) willEventTitle(seq int, ev model.Ti
 		// Does not participate so this is someone else's event
 		title = what
 		if pl.SameAs(t.pov.Place) {
-			title = text.JoinSentenceParts(title, "here")
+			title = text.JoinSentenceParts(title, t.loc.T("here"))
 		}
 
 		obsContext := t.observerContext(ev, true)
 		if obsContext != "" {
-			title = text.JoinSentenceParts(title, "for", obsContext)
+			title = text.JoinSentenceParts(title, t.loc.T("for"), obsContext)
 		}
 
 		if placeIsKnownAndIsNotSameAsPointOfView(pl, t.pov) {
-			title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc))
+			title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc, t.loc))
 		}
 	} else if len(eps) > 0 {
 		ep := eps[0]
@@ -292,17 +304,17 @@ func (t *NarrativeTimelineEntryFormatter[T]) willEventTitle(seq int, ev model.Ti
 		case model.EventRolePrincipal:
 			title = what
 			if pl.SameAs(t.pov.Place) {
-				title = text.JoinSentenceParts(title, "here")
+				title = text.JoinSentenceParts(title, t.loc.T("here"))
 			}
 			if placeIsKnownAndIsNotSameAsPointOfView(pl, t.pov) {
-				title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc), "probate office")
+				title = text.JoinSentenceParts(title, WhatWhere("", pl, t.enc, t.nc, t.loc), t.loc.T("probate office"))
 			}
 		case model.EventRoleBeneficiary:
-			title = text.JoinSentenceParts("Named as beneficiary in the will of", t.observerContext(ev, true))
+			title = text.JoinSentenceParts(t.loc.T("Named as beneficiary in the will of"), t.observerContext(ev, true))
 		case model.EventRoleExecutor:
-			title = text.JoinSentenceParts("Named as executor in the will of", t.observerContext(ev, true))
+			title = text.JoinSentenceParts(t.loc.T("Named as executor in the will of"), t.observerContext(ev, true))
 		case model.EventRoleWitness:
-			title = text.JoinSentenceParts("Witnessed the will of", t.observerContext(ev, true))
+			title = text.JoinSentenceParts(t.loc.T("Witnessed the will of"), t.observerContext(ev, true))
 		default:
 			t.logger.Warn("unsupported role in will event", "role", ep.Role)
 		}
@@ -319,37 +331,37 @@ func (t *NarrativeTimelineEntryFormatter[T]) residenceEventTitle(seq int, ev *mo
 		return ""
 	}
 
-	title := text.JoinSentenceParts(t.observerContext(ev, false), "recorded", ChooseFrom(seq, "as residing", "as living"))
-	title = WhatWherePov(title, pl, t.enc, t.nc, t.pov)
+	title := text.JoinSentenceParts(t.observerContext(ev, false), t.loc.T("recorded"), ChooseFrom(seq, t.loc.T("as residing"), t.loc.T("as living")))
+	title = WhatWherePov(title, pl, t.enc, t.nc, t.pov, t.loc)
 	return title
 }
 
 func (t *NarrativeTimelineEntryFormatter[T]) musterEventTitle(seq int, ev *model.MusterEvent) string {
-	title := text.JoinSentenceParts(t.observerContext(ev, false), "recorded")
+	title := text.JoinSentenceParts(t.observerContext(ev, false), t.loc.T("recorded"))
 
 	if regiment, ok := ev.GetAttribute(model.EventAttributeRegiment); ok {
 		if battalion, ok := ev.GetAttribute(model.EventAttributeBattalion); ok {
-			title = text.JoinSentenceParts(title, "in the", battalion, "battalion,", regiment)
+			title = text.JoinSentenceParts(title, t.loc.Tf("in the %s battalion, %s", battalion, regiment))
 		} else {
-			title = text.JoinSentenceParts(title, "in the", regiment, "regiment")
+			title = text.JoinSentenceParts(title, t.loc.Tf("in the %s regiment", regiment))
 		}
 	}
-	title = text.JoinSentenceParts(title, "at muster")
+	title = text.JoinSentenceParts(title, t.loc.T("at muster"))
 
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
-		title = WhatWherePov(title+" taken", pl, t.enc, t.nc, t.pov)
+		title = WhatWherePov(text.JoinSentenceParts(title, t.loc.T("taken")), pl, t.enc, t.nc, t.pov, t.loc)
 	}
 	return title
 }
 
 func (t *NarrativeTimelineEntryFormatter[T]) arrivalEventTitle(seq int, ev *model.ArrivalEvent) string {
-	title := text.JoinSentenceParts(t.observerContext(ev, false), "arrived")
+	title := text.JoinSentenceParts(t.observerContext(ev, false), t.loc.T("arrived"))
 
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
 		if pl.SameAs(t.pov.Place) {
-			title = text.JoinSentenceParts(title, "here")
+			title = text.JoinSentenceParts(title, t.loc.T("here"))
 		} else {
 			title = text.JoinSentenceParts(title, t.enc.EncodeModelLinkNamed(pl, t.nc, t.pov).String())
 		}
@@ -358,14 +370,14 @@ func (t *NarrativeTimelineEntryFormatter[T]) arrivalEventTitle(seq int, ev *mode
 }
 
 func (t *NarrativeTimelineEntryFormatter[T]) departureEventTitle(seq int, ev *model.DepartureEvent) string {
-	title := text.JoinSentenceParts(t.observerContext(ev, false), "departed")
+	title := text.JoinSentenceParts(t.observerContext(ev, false), t.loc.T("departed"))
 
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
 		if pl.SameAs(t.pov.Place) {
-			title = text.JoinSentenceParts(title, "from here")
+			title = text.JoinSentenceParts(title, t.loc.T("from here"))
 		} else {
-			title = text.JoinSentenceParts(title, "from", t.enc.EncodeModelLinkNamed(pl, t.nc, t.pov).String())
+			title = text.JoinSentenceParts(title, t.loc.T("from"), t.enc.EncodeModelLinkNamed(pl, t.nc, t.pov).String())
 		}
 	}
 	return title
@@ -374,12 +386,12 @@ func (t *NarrativeTimelineEntryFormatter[T]) departureEventTitle(seq int, ev *mo
 func (t *NarrativeTimelineEntryFormatter[T]) institutionEntryEventTitle(seq int, ev *model.InstitutionEntryEvent) string {
 	title := ev.GetDetail()
 	if title == "" {
-		title = text.JoinSentenceParts(t.observerContext(ev, false), "entered")
+		title = text.JoinSentenceParts(t.observerContext(ev, false), t.loc.T("entered"))
 	}
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
 		if pl.SameAs(t.pov.Place) {
-			title = text.JoinSentenceParts(title, "here")
+			title = text.JoinSentenceParts(title, t.loc.T("here"))
 		} else {
 			title = text.JoinSentenceParts(title, t.enc.EncodeModelLinkNamed(pl, t.nc, t.pov).String())
 		}
@@ -390,12 +402,12 @@ func (t *NarrativeTimelineEntryFormatter[T]) institutionEntryEventTitle(seq int,
 func (t *NarrativeTimelineEntryFormatter[T]) institutionDepartureEventTitle(seq int, ev *model.InstitutionDepartureEvent) string {
 	title := ev.GetDetail()
 	if title == "" {
-		title = text.JoinSentenceParts(t.observerContext(ev, false), "left")
+		title = text.JoinSentenceParts(t.observerContext(ev, false), t.loc.T("left"))
 	}
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
 		if pl.SameAs(t.pov.Place) {
-			title = text.JoinSentenceParts(title, "here")
+			title = text.JoinSentenceParts(title, t.loc.T("here"))
 		} else {
 			title = text.JoinSentenceParts(title, t.enc.EncodeModelLinkNamed(pl, t.nc, t.pov).String())
 		}
@@ -415,11 +427,11 @@ func (t *NarrativeTimelineEntryFormatter[T]) marriageEventTitle(seq int, ev mode
 
 		switch ev.(type) {
 		case *model.MarriageEvent:
-			title = text.JoinSentenceParts(party1Link, "married", party2Link)
+			title = text.JoinSentenceParts(party1Link, t.loc.T("married"), party2Link)
 		case *model.MarriageLicenseEvent:
-			title = text.JoinSentenceParts(model.What(ev), "for the marriage of ", party1Link, "and", party2Link)
+			title = text.JoinSentenceParts(t.loc.What(ev), t.loc.T("for the marriage of"), party1Link, t.loc.T("and"), party2Link)
 		case *model.MarriageBannsEvent:
-			title = text.JoinSentenceParts(model.What(ev), "for the marriage of ", party1Link, "and", party2Link)
+			title = text.JoinSentenceParts(t.loc.What(ev), t.loc.T("for the marriage of"), party1Link, t.loc.T("and"), party2Link)
 		default:
 			panic(fmt.Sprintf("unhandled marriage event type: %T", ev))
 		}
@@ -437,11 +449,11 @@ func (t *NarrativeTimelineEntryFormatter[T]) marriageEventTitle(seq int, ev mode
 			spouseLink := t.enc.EncodeModelLink(t.enc.EncodeText(t.nc.FirstUse(spouse)), spouse).String()
 			switch ev.(type) {
 			case *model.MarriageEvent:
-				title = text.JoinSentenceParts("married", spouseLink)
+				title = text.JoinSentenceParts(t.loc.T("married"), spouseLink)
 			case *model.MarriageLicenseEvent:
-				title = text.JoinSentenceParts(model.What(ev), "to marry", spouseLink)
+				title = text.JoinSentenceParts(t.loc.What(ev), t.loc.T("to marry"), spouseLink)
 			case *model.MarriageBannsEvent:
-				title = text.JoinSentenceParts(model.What(ev), "to marry", spouseLink)
+				title = text.JoinSentenceParts(t.loc.What(ev), t.loc.T("to marry"), spouseLink)
 			default:
 				panic(fmt.Sprintf("unhandled marriage event type: %T", ev))
 			}
@@ -451,17 +463,35 @@ func (t *NarrativeTimelineEntryFormatter[T]) marriageEventTitle(seq int, ev mode
 
 			party1Link := t.enc.EncodeModelLink(t.enc.EncodeText(t.nc.FirstUse(party1)), party1).String()
 			party2Link := t.enc.EncodeModelLink(t.enc.EncodeText(t.nc.FirstUse(party2)), party2).String()
-			title = text.JoinSentenceParts("witness to the marriage of", party1Link, "and", party2Link)
+			title = text.JoinSentenceParts(t.loc.T("witness to the marriage of"), party1Link, t.loc.T("and"), party2Link)
 		}
 	}
 
 	pl := ev.GetPlace()
 	if placeIsKnownAndIsNotSameAsPointOfView(pl, t.pov) {
-		title = WhatWherePov(title, pl, t.enc, t.nc, t.pov)
+		title = WhatWherePov(title, pl, t.enc, t.nc, t.pov, t.loc)
+	}
+	if reg := registrationSentence(ev, t.enc, t.loc); reg != "" {
+		title = text.JoinSentences(title, reg)
 	}
 	return title
 }
 
+// registrationSentence returns a sentence saying which district ev was
+// civilly registered in and the quarter it was indexed in, or an empty string
+// if that is not known.
+func registrationSentence[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], loc locale.Locale) string {
+	reg := ev.GetRegistration()
+	if reg == nil || reg.District.IsUnknown() {
+		return ""
+	}
+	s := loc.Tf("registered in the %s district", enc.EncodeModelLink(enc.EncodeText(reg.District.Name), reg.District).String())
+	if q := reg.QuarterName(); q != "" {
+		s = text.JoinSentenceParts(s, loc.T("in the"), q)
+	}
+	return s
+}
+
 func (t *NarrativeTimelineEntryFormatter[T]) observerContext(ev model.TimelineEvent, prefixRelationWithPronoun bool) string {
 	observer := t.pov.Person
 	switch tev := ev.(type) {
@@ -476,8 +506,12 @@ func (t *NarrativeTimelineEntryFormatter[T]) observerContext(ev model.TimelineEv
 		}
 		name := t.enc.EncodeModelLinkNamed(principal, t.nc, t.pov).String()
 		rel := principal.RelationTo(t.pov.Person, tev.GetDate())
-		if rel != "" && prefixRelationWithPronoun {
-			rel = t.pov.Person.Gender.PossessivePronounSingular() + " " + rel
+		if rel != "" {
+			if prefixRelationWithPronoun {
+				rel = t.loc.PossessivePronoun(t.pov.Person.Gender, rel)
+			} else {
+				rel = t.loc.T(rel)
+			}
 		}
 		return text.AppendAside(rel, name)
 	case model.MultipartyTimelineEvent:
@@ -492,7 +526,7 @@ func (t *NarrativeTimelineEntryFormatter[T]) observerContext(ev model.TimelineEv
 			ppl = append(ppl, t.enc.EncodeModelLink(t.enc.EncodeText(t.nc.FirstUse(p)), p).String())
 		}
 
-		return text.JoinList(ppl)
+		return t.loc.JoinList(ppl)
 
 	case *model.CensusEvent:
 		if tev.DirectlyInvolves(observer) {
@@ -515,7 +549,7 @@ func (t *NarrativeTimelineEntryFormatter[T]) observerContext(ev model.TimelineEv
 			ppl = append(ppl, t.enc.EncodeModelLinkNamed(en.Principal, t.nc, t.pov).String())
 		}
 
-		return text.JoinList(ppl)
+		return t.loc.JoinList(ppl)
 
 	default:
 		return ""
@@ -3,9 +3,9 @@ package narrative
 import (
 	"sort"
 
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/render"
-	"github.com/iand/genster/text"
 )
 
 type FamilyNarrative[T render.EncodedText] struct {
@@ -13,6 +13,7 @@ type FamilyNarrative[T render.EncodedText] struct {
 	FatherStatements []Statement[T]
 	MotherStatements []Statement[T]
 	FamilyStatements []Statement[T]
+	Locale           locale.Locale // the language of the narrative, English if nil
 }
 
 func sortStatements[T render.EncodedText](ss []Statement[T]) {
@@ -39,12 +40,15 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 		POV: &model.POV{
 			Person: father,
 		},
+		Locale: n.Locale,
 	}
 	mintro := IntroGenerator[T]{
 		POV: &model.POV{
 			Person: mother,
 		},
+		Locale: n.Locale,
 	}
+	loc := fintro.Loc()
 
 	if !father.IsUnknown() && !mother.IsUnknown() {
 		// Interleave statements from father and mother of family
@@ -57,7 +61,7 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 			s := n.FatherStatements[fidx]
 			if s.NarrativeSequence() == NarrativeSequenceIntro || s.Start().SortsBefore(n.Family.BestStartDate) {
 				if !headingPrinted {
-					b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Father.PreferredFamiliarName)+" background"), "")
+					b.Heading3(b.EncodeText(loc.Tf("%s background", loc.PossessiveName(n.Family.Father.PreferredFamiliarName))), "")
 					headingPrinted = true
 				}
 				s.RenderDetail(fidx, &fintro, b, nc)
@@ -73,7 +77,7 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 			s := n.MotherStatements[midx]
 			if s.NarrativeSequence() == NarrativeSequenceIntro || s.Start().SortsBefore(n.Family.BestStartDate) {
 				if !headingPrinted {
-					b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Mother.PreferredFamiliarName)+" background"), "")
+					b.Heading3(b.EncodeText(loc.Tf("%s background", loc.PossessiveName(n.Family.Mother.PreferredFamiliarName))), "")
 					headingPrinted = true
 				}
 				s.RenderDetail(midx, &mintro, b, nc)
@@ -88,11 +92,12 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 			POV: &model.POV{
 				Family: n.Family,
 			},
+			Locale: n.Locale,
 		}
 		headingPrinted = false
 		for idx, s := range n.FamilyStatements {
 			if !headingPrinted {
-				b.Heading3(b.EncodeText("Family life"), "")
+				b.Heading3(b.EncodeText(loc.T("Family life")), "")
 				headingPrinted = true
 			}
 			s.RenderDetail(idx, &famIntro, b, nc)
@@ -106,9 +111,9 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 				s := n.MotherStatements[midx]
 				if !headingPrinted {
 					if _, ok := s.(*DeathStatement[T]); ok {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Mother.PreferredFamiliarName)+" death"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s death", loc.PossessiveName(n.Family.Mother.PreferredFamiliarName))), "")
 					} else {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Mother.PreferredFamiliarName)+" later life"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s later life", loc.PossessiveName(n.Family.Mother.PreferredFamiliarName))), "")
 					}
 					headingPrinted = true
 				}
@@ -121,9 +126,9 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 				s := n.FatherStatements[fidx]
 				if !headingPrinted {
 					if _, ok := s.(*DeathStatement[T]); ok {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Father.PreferredFamiliarName)+" death"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s death", loc.PossessiveName(n.Family.Father.PreferredFamiliarName))), "")
 					} else {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Father.PreferredFamiliarName)+" later life"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s later life", loc.PossessiveName(n.Family.Father.PreferredFamiliarName))), "")
 					}
 					headingPrinted = true
 				}
@@ -138,9 +143,9 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 				s := n.FatherStatements[fidx]
 				if !headingPrinted {
 					if _, ok := s.(*DeathStatement[T]); ok {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Father.PreferredGivenName)+" death"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s death", loc.PossessiveName(n.Family.Father.PreferredGivenName))), "")
 					} else {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Father.PreferredGivenName)+" later life"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s later life", loc.PossessiveName(n.Family.Father.PreferredGivenName))), "")
 					}
 					headingPrinted = true
 				}
@@ -153,9 +158,9 @@ func (n *FamilyNarrative[T]) Render(b render.ContentBuilder[T], nc NameChooser)
 				s := n.MotherStatements[midx]
 				if !headingPrinted {
 					if _, ok := s.(*DeathStatement[T]); ok {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Mother.PreferredGivenName)+" death"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s death", loc.PossessiveName(n.Family.Mother.PreferredGivenName))), "")
 					} else {
-						b.Heading3(b.EncodeText(text.MaybePossessiveSuffix(n.Family.Mother.PreferredGivenName)+" later life"), "")
+						b.Heading3(b.EncodeText(loc.Tf("%s later life", loc.PossessiveName(n.Family.Mother.PreferredGivenName))), "")
 					}
 					headingPrinted = true
 				}
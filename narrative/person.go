@@ -1,10 +1,10 @@
 package narrative
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/render"
 	"github.com/iand/genster/text"
@@ -12,6 +12,7 @@ import (
 
 type PersonNarrative[T render.EncodedText] struct {
 	Statements []Statement[T]
+	Locale     locale.Locale // the language of the narrative, English if nil
 }
 
 type IntroGenerator[T render.EncodedText] struct {
@@ -20,6 +21,15 @@ type IntroGenerator[T render.EncodedText] struct {
 	AgeMinSeq        int                 // the minimum sequence that the person's age may be used in an intro
 	LastIntroDate    *model.Date         //  the date that the last intro was requested
 	PeopleIntroduced map[string][]string // a lookup of occupations for people who have been introduced
+	Locale           locale.Locale       // the language of the narrative, English if nil
+}
+
+// Loc returns the locale used for the wording of the narrative.
+func (n *IntroGenerator[T]) Loc() locale.Locale {
+	if n.Locale == nil {
+		return locale.Default
+	}
+	return n.Locale
 }
 
 func (n *IntroGenerator[T]) Default(seq int, dt *model.Date) string {
@@ -43,7 +53,7 @@ func (n *IntroGenerator[T]) Pronoun(seq int, dt *model.Date, principal *model.Pe
 	}()
 	if n.POV.Person == nil {
 		if principal.IsUnknown() {
-			return "they"
+			return n.Loc().SubjectPronoun(model.GenderUnknown)
 		}
 		return principal.PreferredFamiliarName
 	}
@@ -53,37 +63,35 @@ func (n *IntroGenerator[T]) Pronoun(seq int, dt *model.Date, principal *model.Pe
 		return n.POV.Person.PreferredFamiliarName
 	}
 
-	return n.POV.Person.Gender.SubjectPronoun()
+	return n.Loc().SubjectPronoun(n.POV.Person.Gender)
 }
 
 func (n *IntroGenerator[T]) WasWere(principal *model.Person) string {
 	if n.POV.Person == nil && principal.IsUnknown() {
-		return "were"
+		return n.Loc().T("were")
 	}
-	return "was"
+	return n.Loc().T("was")
 }
 
 func (n *IntroGenerator[T]) RelativeTime(seq int, dt *model.Date, includeFullDate bool) string {
 	defer func() {
 		n.LastIntroDate = dt
 	}()
+	loc := n.Loc()
 	if n.POV.Person != nil && seq >= n.AgeMinSeq {
 		if age, ok := n.POV.Person.AgeInYearsAt(dt); ok && age > 0 {
 			n.AgeMinSeq = seq + 2
 			if includeFullDate {
-				return fmt.Sprintf("%s, at the age of %s", dt.When(), text.CardinalNoun(age))
+				return loc.Tf("%s, at the age of %s", loc.When(dt), loc.CardinalNoun(age))
 			}
-			return fmt.Sprintf("at the age of %s", text.CardinalNoun(age))
+			return loc.Tf("at the age of %s", loc.CardinalNoun(age))
 		}
 	}
 
 	if n.LastIntroDate != nil {
 		sincePrev := n.LastIntroDate.IntervalUntil(dt)
 		if years, ok := sincePrev.WholeYears(); ok {
-			dateInYear, ok := dt.DateInYear(true)
-			if ok {
-				dateInYear = "on " + dateInYear
-			}
+			dateInYear, _ := loc.WhenInYear(dt)
 
 			if years < 0 && dt.SortsBefore(n.LastIntroDate) {
 				return ""
@@ -92,34 +100,34 @@ func (n *IntroGenerator[T]) RelativeTime(seq int, dt *model.Date, includeFullDat
 				if isPreciseInterval && days < 5 {
 					return ChooseFrom(seq,
 						dateInYear,
-						dateInYear+", just a few days later",
-						"very shortly after "+dateInYear,
-						"just a few days later "+dateInYear,
+						text.JoinSentenceParts(dateInYear+",", loc.T("just a few days later")),
+						text.JoinSentenceParts(loc.T("very shortly after"), dateInYear),
+						text.JoinSentenceParts(loc.T("just a few days later"), dateInYear),
 					)
 				} else if isPreciseInterval && days < 20 {
 					return ChooseFrom(seq,
-						"shortly after"+dateInYear,
-						"several days later"+dateInYear,
+						text.JoinSentenceParts(loc.T("shortly after"), dateInYear),
+						text.JoinSentenceParts(loc.T("several days later"), dateInYear),
 					)
 				} else if n.LastIntroDate.SameYear(dt) {
 					return ChooseFrom(seq,
-						"later that year"+dateInYear,
-						"the same year"+dateInYear,
-						"later that same year"+dateInYear,
-						"that same year"+dateInYear,
+						text.JoinSentenceParts(loc.T("later that year"), dateInYear),
+						text.JoinSentenceParts(loc.T("the same year"), dateInYear),
+						text.JoinSentenceParts(loc.T("later that same year"), dateInYear),
+						text.JoinSentenceParts(loc.T("that same year"), dateInYear),
 					)
 				} else {
 					return ChooseFrom(seq,
-						"shortly after"+dateInYear,
-						"some time later"+dateInYear,
-						"a short while later"+dateInYear,
+						text.JoinSentenceParts(loc.T("shortly after"), dateInYear),
+						text.JoinSentenceParts(loc.T("some time later"), dateInYear),
+						text.JoinSentenceParts(loc.T("a short while later"), dateInYear),
 					)
 				}
 
 			} else if years == 1 {
 				return ChooseFrom(seq,
-					"the following year, "+dateInYear,
-					"the next year, "+dateInYear,
+					text.JoinSentenceParts(loc.T("the following year,"), dateInYear),
+					text.JoinSentenceParts(loc.T("the next year,"), dateInYear),
 					"",
 				)
 				// } else if years < 5 {
@@ -131,13 +139,13 @@ func (n *IntroGenerator[T]) RelativeTime(seq int, dt *model.Date, includeFullDat
 			} else {
 				if includeFullDate {
 					return ChooseFrom(seq,
-						dt.When(),
-						text.CardinalNoun(years)+" years later, "+dt.When(),
+						loc.When(dt),
+						loc.Tf("%s years later, %s", loc.CardinalNoun(years), loc.When(dt)),
 					)
 				}
 				return ChooseFrom(seq,
 					"",
-					text.CardinalNoun(years)+" years later",
+					loc.Tf("%s years later", loc.CardinalNoun(years)),
 				)
 			}
 		}
@@ -145,7 +153,7 @@ func (n *IntroGenerator[T]) RelativeTime(seq int, dt *model.Date, includeFullDat
 	}
 
 	if includeFullDate {
-		return dt.When()
+		return loc.When(dt)
 	}
 	return ""
 }
@@ -156,7 +164,7 @@ func (n *IntroGenerator[T]) IntroducePerson(seq int, p *model.Person, dt *model.
 	}
 
 	if p.IsUnknown() {
-		return "an unknown person"
+		return n.Loc().T("an unknown person")
 	}
 
 	occ := p.OccupationAt(dt)
@@ -198,7 +206,7 @@ func (n *IntroGenerator[T]) IntroducePerson(seq int, p *model.Person, dt *model.
 	detail := enc.EncodeModelLinkDedupe(enc.EncodeText(name), enc.EncodeText(p.PreferredGivenName), p).String()
 	if occDetail != "" {
 		if hadPreviousOccupation {
-			detail += ", now " + occDetail + ","
+			detail += ", " + n.Loc().T("now") + " " + occDetail + ","
 		} else {
 			detail += ", " + occDetail + ","
 		}
@@ -228,7 +236,8 @@ func (n *PersonNarrative[T]) Render(pov *model.POV, b render.ContentBuilder[T])
 	currentNarrativeSequence := NarrativeSequenceIntro
 	sequenceInNarrative := 0
 	nintro := IntroGenerator[T]{
-		POV: pov,
+		POV:    pov,
+		Locale: n.Locale,
 	}
 	for _, s := range n.Statements {
 		if currentNarrativeSequence != s.NarrativeSequence() {
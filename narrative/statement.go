@@ -1,16 +1,10 @@
 package narrative
 
 import (
-	"bytes"
-	"encoding/base64"
-	"fmt"
-	"image"
-	"image/jpeg"
-	"image/png"
-	"os"
 	"sort"
 	"strings"
 
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/render"
@@ -42,36 +36,35 @@ func (s *IntroStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 	if s.NameChooser == nil {
 		s.NameChooser = nc
 	}
+	loc := intro.Loc()
 
 	var para text.Para
 
 	if s.Principal.BestBirthlikeEvent == nil && s.Principal.Father.IsUnknown() && s.Principal.Mother.IsUnknown() {
-		para.StartSentence("nothing is known about the early life of", s.Principal.PreferredGivenName)
-		s.maybeAppendNickname(&para)
+		para.StartSentence(loc.T("nothing is known about the early life of"), s.Principal.PreferredGivenName)
+		s.maybeAppendNickname(&para, loc)
 		s.maybeAddPossibleBirths(&para, enc, nc, intro)
 	} else {
 		para.StartSentence(s.Principal.PreferredGivenName)
-		s.maybeAppendNickname(&para)
+		s.maybeAppendNickname(&para, loc)
 		if s.Principal.BestBirthlikeEvent != nil {
-			birth := enc.EncodeWithCitations(enc.EncodeText(text.LowerFirst(EventWhatWhenWherePov(s.Principal.BestBirthlikeEvent, enc, s.NameChooser, intro.POV))), s.Principal.BestBirthlikeEvent.GetCitations()).String()
+			birth := enc.EncodeWithCitations(enc.EncodeText(text.LowerFirst(EventWhatWhenWherePov(s.Principal.BestBirthlikeEvent, enc, s.NameChooser, intro.POV, loc))), s.Principal.BestBirthlikeEvent.GetCitations()).String()
 			para.Continue(birth)
 		}
 		s.addParentage(&para, enc, nc, intro, seq)
 		s.maybeAddBaptismAfterBirth(&para, enc, nc, intro)
-		s.maybeAddTwinAssociation(&para, enc)
+		s.maybeAddTwinAssociation(&para, enc, loc)
 	}
 
-	s.maybeAddRelationToKeyPerson(&para, enc)
+	s.maybeAddRelationToKeyPerson(&para, enc, loc)
 	enc.Para(enc.EncodeText(para.Text()))
 
 	if s.Principal.BestBirthlikeEvent != nil {
 		if s.IncludeMedia {
 			if len(s.Principal.BestBirthlikeEvent.GetMediaObjects()) > 0 {
-				// TODO: handle error
 				MediaObjectsAsFigures(s.Principal.BestBirthlikeEvent.GetMediaObjects(), enc, s.CropMediaHighlights)
 			}
 			if len(s.Baptisms) == 1 && s.Baptisms[0] != s.Principal.BestBirthlikeEvent && len(s.Baptisms[0].GetMediaObjects()) > 0 {
-				// TODO: handle error
 				MediaObjectsAsFigures(s.Baptisms[0].GetMediaObjects(), enc, s.CropMediaHighlights)
 			}
 		}
@@ -81,9 +74,9 @@ func (s *IntroStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 	}
 }
 
-func (s *IntroStatement[T]) maybeAppendNickname(para *text.Para) {
+func (s *IntroStatement[T]) maybeAppendNickname(para *text.Para, loc locale.Locale) {
 	if s.Principal.NickName != "" {
-		para.Continue("(known as " + s.Principal.NickName + ")")
+		para.Continue(loc.Tf("(known as %s)", s.Principal.NickName))
 	}
 }
 
@@ -91,51 +84,53 @@ func (s *IntroStatement[T]) maybeAddPossibleBirths(para *text.Para, enc render.C
 	if len(s.PossibleBirths) == 0 {
 		return
 	}
+	loc := intro.Loc()
 	list := []string{}
 	hasCitation := false
 	for _, ev := range s.PossibleBirths {
-		list = append(list, enc.EncodeWithCitations(enc.EncodeText(EventWhenWherePov(ev, enc, nc, intro.POV)), ev.GetCitations()).String())
+		list = append(list, enc.EncodeWithCitations(enc.EncodeText(EventWhenWherePov(ev, enc, nc, intro.POV, loc)), ev.GetCitations()).String())
 		if len(ev.GetCitations()) > 0 {
 			hasCitation = true
 		}
 	}
 	if hasCitation {
-		para.StartSentence("however, there is some evidence that")
+		para.StartSentence(loc.T("however, there is some evidence that"))
 	} else {
-		para.StartSentence("it's possible that")
+		para.StartSentence(loc.T("it's possible that"))
 	}
-	para.Continue(s.Principal.Gender.SubjectPronounWithLink(), "born")
-	para.FinishSentence(text.JoinListOr(list))
+	para.Continue(loc.SubjectPronounWithLink(s.Principal.Gender), loc.T("born"))
+	para.FinishSentence(loc.JoinListOr(list))
 }
 
 func (s *IntroStatement[T]) addParentage(para *text.Para, enc render.ContentBuilder[T], nc NameChooser, intro *IntroGenerator[T], seq int) {
-	rel := PositionInFamily(s.Principal)
+	loc := intro.Loc()
+	rel := PositionInFamily(s.Principal, loc)
 	if rel == "" {
-		rel = text.LowerFirst(s.Principal.Gender.RelationToParentNoun())
+		rel = loc.T(text.LowerFirst(s.Principal.Gender.RelationToParentNoun()))
 	}
-	prefix := "the " + rel + " of "
+	prefix := loc.Definite(rel) + " " + loc.T("of") + " "
 
 	switch {
 	case s.Principal.Father.IsUnknown() && s.Principal.Mother.IsUnknown():
-		para.StartSentence(s.Principal.Gender.PossessivePronounSingular(), "parents are not known")
+		para.StartSentence(loc.PossessivePronoun(s.Principal.Gender, "parents"), loc.T("are not known"))
 	case s.Principal.Father.IsUnknown():
 		para.Continue(prefix + intro.IntroducePerson(seq, s.Principal.Mother, s.Start(), false, enc, nc))
-		para.StartSentence(s.Principal.Gender.PossessivePronounSingular(), "father is not known")
+		para.StartSentence(loc.PossessivePronoun(s.Principal.Gender, "father"), loc.T("is not known"))
 	case s.Principal.Mother.IsUnknown():
 		para.Continue(prefix + intro.IntroducePerson(seq, s.Principal.Father, s.Start(), false, enc, nc))
-		para.StartSentence(s.Principal.Gender.PossessivePronounSingular(), "mother is not known")
+		para.StartSentence(loc.PossessivePronoun(s.Principal.Gender, "mother"), loc.T("is not known"))
 	default:
-		para.Continue(prefix + intro.IntroducePerson(seq, s.Principal.Father, s.Start(), false, enc, nc) + " and " + intro.IntroducePerson(seq, s.Principal.Mother, s.Start(), false, enc, nc))
+		para.Continue(prefix + intro.IntroducePerson(seq, s.Principal.Father, s.Start(), false, enc, nc) + " " + loc.T("and") + " " + intro.IntroducePerson(seq, s.Principal.Mother, s.Start(), false, enc, nc))
 	}
 }
 
-func (s *IntroStatement[T]) maybeAddTwinAssociation(para *text.Para, enc render.ContentBuilder[T]) {
+func (s *IntroStatement[T]) maybeAddTwinAssociation(para *text.Para, enc render.ContentBuilder[T], loc locale.Locale) {
 	for _, as := range s.Principal.Associations {
 		if as.Kind != model.AssociationKindTwin {
 			continue
 		}
 		twinLink := enc.EncodeModelLink(enc.EncodeText(as.Other.PreferredFamiliarName), as.Other)
-		para.StartSentence(s.Principal.Gender.SubjectPronoun(), "was the twin to", enc.EncodeWithCitations(twinLink, as.Citations).String())
+		para.StartSentence(loc.SubjectPronoun(s.Principal.Gender), loc.T("was the twin to"), enc.EncodeWithCitations(twinLink, as.Citations).String())
 		return
 	}
 }
@@ -144,15 +139,17 @@ func (s *IntroStatement[T]) maybeAddBaptismAfterBirth(para *text.Para, enc rende
 	if len(s.Baptisms) != 1 || s.Baptisms[0] == s.Principal.BestBirthlikeEvent {
 		return
 	}
-	bapDetail := AgeWhenWherePov(s.Baptisms[0], enc, nc, intro.POV)
+	loc := intro.Loc()
+	bapDetail := AgeWhenWherePov(s.Baptisms[0], enc, nc, intro.POV, loc)
 	if bapDetail == "" {
 		return
 	}
-	bapCited := enc.EncodeWithCitations(enc.EncodeText(bapDetail), s.Baptisms[0].GetCitations()).String()
-	para.StartSentence(s.Principal.Gender.SubjectPronoun(), "was baptised", bapCited)
+	bapCited := enc.EncodeWithCitations(enc.EncodeText(loc.Predicate(loc.T("was baptised"), bapDetail)), s.Baptisms[0].GetCitations()).String()
+	para.StartSentence(loc.SubjectPronoun(s.Principal.Gender), bapCited)
 }
 
 func (s *IntroStatement[T]) renderMultipleBaptisms(enc render.ContentBuilder[T], nc NameChooser, intro *IntroGenerator[T], seq int) {
+	loc := intro.Loc()
 	var para text.Para
 	para.StartSentence(intro.Pronoun(seq, s.Start(), s.Principal))
 	for i, bev := range s.Baptisms {
@@ -160,31 +157,30 @@ func (s *IntroStatement[T]) renderMultipleBaptisms(enc render.ContentBuilder[T],
 		if s.Baptisms[i] == s.Principal.BestBirthlikeEvent {
 			continue
 		}
-		aww := AgeWhenWherePov(bev, enc, nc, intro.POV)
+		aww := AgeWhenWherePov(bev, enc, nc, intro.POV, loc)
 		if aww == "" {
 			continue
 		}
-		cited := enc.EncodeWithCitations(enc.EncodeText(aww), bev.GetCitations()).String()
 		if i == 0 {
-			para.Continue("was baptised", cited)
+			para.Continue(enc.EncodeWithCitations(enc.EncodeText(loc.Predicate(loc.T("was baptised"), aww)), bev.GetCitations()).String())
 		} else {
-			para.Continue("and again", cited)
+			para.Continue(loc.T("and again"), enc.EncodeWithCitations(enc.EncodeText(aww), bev.GetCitations()).String())
 		}
 	}
 	enc.Para(enc.EncodeText(para.Text()))
 }
 
-func (s *IntroStatement[T]) maybeAddRelationToKeyPerson(para *text.Para, enc render.ContentBuilder[T]) {
+func (s *IntroStatement[T]) maybeAddRelationToKeyPerson(para *text.Para, enc render.ContentBuilder[T], loc locale.Locale) {
 	// ---------------------------------------
 	// Prose relation to key person
 	// ---------------------------------------
 	if !s.SuppressRelation {
 		if s.Principal.RelationToKeyPerson != nil && !s.Principal.RelationToKeyPerson.IsSelf() {
 			para.AddCompleteSentence(
-				s.Principal.Gender.SubjectPronoun(),
-				"is",
-				enc.EncodeModelLink(enc.EncodeText(text.MaybePossessiveSuffix(s.Principal.RelationToKeyPerson.From.PreferredFamiliarName)), s.Principal.RelationToKeyPerson.From).String(),
-				s.Principal.RelationToKeyPerson.Name(),
+				loc.SubjectPronoun(s.Principal.Gender),
+				loc.T("is"),
+				enc.EncodeModelLink(enc.EncodeText(loc.PossessiveName(s.Principal.RelationToKeyPerson.From.PreferredFamiliarName)), s.Principal.RelationToKeyPerson.From).String(),
+				loc.RelationName(s.Principal.RelationToKeyPerson),
 			)
 		}
 	}
@@ -241,6 +237,7 @@ func (s *FamilyStatement[T]) Priority() int {
 }
 
 func (s *FamilyStatement[T]) renderMarried(seq int, intro *IntroGenerator[T], enc render.ContentBuilder[T], nc NameChooser) {
+	loc := intro.Loc()
 	other := s.Family.OtherParent(s.Principal)
 	otherName := intro.IntroducePerson(seq, other, s.Start(), false, enc, nc)
 
@@ -250,26 +247,26 @@ func (s *FamilyStatement[T]) renderMarried(seq int, intro *IntroGenerator[T], en
 	var action string
 	switch s.Family.Bond {
 	case model.FamilyBondMarried:
-		action = "married"
+		action = loc.T("married")
 	case model.FamilyBondLikelyMarried:
-		action = ChooseFrom(seq, "likely married", "probably married")
+		action = ChooseFrom(seq, loc.T("likely married"), loc.T("probably married"))
 	default:
-		action = "met"
+		action = loc.T("met")
 	}
 
 	startDate := s.Family.BestStartDate
 	var event string
 	if !startDate.IsUnknown() {
 		detail.ReplaceSentence(intro.Pronoun(seq, s.Start(), s.Principal))
-		event = action + " " + otherName + " " + NamedWhen(startDate)
+		event = action + " " + otherName + " " + NamedWhen(startDate, loc)
 		if age, ok := s.Principal.AgeInYearsAt(startDate); ok && (age < 18 || age > 45) {
-			event += " " + AgeQualifier(age)
+			event += " " + AgeQualifier(age, loc)
 		}
 	} else {
 		event = action + " " + otherName
 	}
 	if s.Family.BestStartEvent != nil && !s.Family.BestStartEvent.GetPlace().IsUnknown() {
-		event = WhatWherePov(event, s.Family.BestStartEvent.GetPlace(), enc, nc, intro.POV)
+		event = WhatWherePov(event, s.Family.BestStartEvent.GetPlace(), enc, nc, intro.POV, loc)
 	}
 	if s.Family.BestStartEvent != nil {
 		detail.Continue(enc.EncodeWithCitations(enc.EncodeText(event), s.Family.BestStartEvent.GetCitations()).String())
@@ -279,32 +276,32 @@ func (s *FamilyStatement[T]) renderMarried(seq int, intro *IntroGenerator[T], en
 
 	if len(s.Family.Children) == 0 {
 		if s.Principal.Childless {
-			detail.AddCompleteSentence("they had no children")
+			detail.AddCompleteSentence(loc.T("they had no children"))
 		}
 	} else {
-		childCardinal := ChildCardinal(s.Family.Children)
+		childCardinal := ChildCardinal(s.Family.Children, loc)
 		switch len(s.Family.Children) {
 		case 1:
 			detail.Continue(ChooseFrom(seq,
-				" and had "+childCardinal+":",
-				". They had just one child together:",
-				". They had "+childCardinal+":",
+				" "+loc.Tf("and had %s", childCardinal)+":",
+				". "+loc.T("They had just one child together")+":",
+				". "+loc.Tf("They had %s", childCardinal)+":",
 			))
 		case 2:
 			detail.Continue(ChooseFrom(seq,
-				" and had "+childCardinal+":",
-				". They had "+childCardinal+": ",
+				" "+loc.Tf("and had %s", childCardinal)+":",
+				". "+loc.Tf("They had %s", childCardinal)+": ",
 			))
 		default:
 			detail.Continue(ChooseFrom(seq,
-				". They had "+childCardinal+": ",
-				" and went on to have "+childCardinal+" with "+s.Principal.Gender.Opposite().ObjectPronoun()+": ",
-				". They went on to have "+childCardinal+": ",
+				". "+loc.Tf("They had %s", childCardinal)+": ",
+				" "+loc.Tf("and went on to have %s with %s", childCardinal, loc.ObjectPronoun(s.Principal.Gender.Opposite()))+": ",
+				". "+loc.Tf("They went on to have %s", childCardinal)+": ",
 			))
 		}
 	}
 
-	s.renderChildListParagraph(&detail, enc, nc, s.Family.Children)
+	s.renderChildListParagraph(&detail, enc, nc, s.Family.Children, loc)
 }
 
 func (s *FamilyStatement[T]) renderSingleParent(seq int, intro *IntroGenerator[T], enc render.ContentBuilder[T], nc NameChooser) {
@@ -312,6 +309,7 @@ func (s *FamilyStatement[T]) renderSingleParent(seq int, intro *IntroGenerator[T
 		return
 	}
 
+	loc := intro.Loc()
 	other := s.Family.OtherParent(s.Principal)
 	otherKnown := !other.IsUnknown()
 	var otherName string
@@ -333,20 +331,20 @@ func (s *FamilyStatement[T]) renderSingleParent(seq int, intro *IntroGenerator[T
 			detail.AppendAsAside(intro.RelativeTime(seq, c.BestBirthlikeEvent.GetDate(), otherKnown))
 			detail.Continue(intro.Pronoun(seq, c.BestBirthlikeEvent.GetDate(), s.Principal))
 		}
-		detail.Continue("gave birth to a", c.Gender.RelationToParentNoun())
+		detail.Continue(loc.T("gave birth to a " + c.Gender.RelationToParentNoun()))
 		if otherKnown {
 			if !c.Redacted {
 				detail.AppendAsAside(enc.EncodeModelLink(enc.EncodeText(c.PreferredFamiliarName), c).String())
 			}
-			detail.AppendClause("the child of")
+			detail.AppendClause(loc.T("the child of"))
 			detail.Continue(otherName)
 		} else {
 			detail.AppendAsAside(enc.EncodeModelLink(enc.EncodeText(c.PreferredFullName), c).String())
-			detail.Continue(enc.EncodeWithCitations(enc.EncodeText(EventWhenWherePov(c.BestBirthlikeEvent, enc, nc, intro.POV)), c.BestBirthlikeEvent.GetCitations()).String())
+			detail.Continue(enc.EncodeWithCitations(enc.EncodeText(EventWhenWherePov(c.BestBirthlikeEvent, enc, nc, intro.POV, loc)), c.BestBirthlikeEvent.GetCitations()).String())
 			if detail.CurrentSentenceLength() < 60 {
 				detail.Continue(ChooseFrom(seq,
-					"with an unknown "+s.Principal.Gender.Opposite().RelationToChildrenNoun(),
-					"by an unknown "+s.Principal.Gender.Opposite().Noun(),
+					loc.Tf("with an unknown %s", loc.T(s.Principal.Gender.Opposite().RelationToChildrenNoun())),
+					loc.Tf("by an unknown %s", loc.T(s.Principal.Gender.Opposite().Noun())),
 				))
 			}
 		}
@@ -357,14 +355,14 @@ func (s *FamilyStatement[T]) renderSingleParent(seq int, intro *IntroGenerator[T
 			detail.AppendAsAside(intro.RelativeTime(seq, c.BestBirthlikeEvent.GetDate(), false))
 			detail.Continue(intro.Pronoun(seq, c.BestBirthlikeEvent.GetDate(), s.Principal))
 		}
-		detail.Continue("had a", c.Gender.RelationToParentNoun())
+		detail.Continue(loc.T("had a " + c.Gender.RelationToParentNoun()))
 		detail.AppendAsAside(enc.EncodeModelLink(enc.EncodeText(c.PreferredFullName), c).String())
-		detail.Continue("who")
-		detail.Continue(enc.EncodeWithCitations(enc.EncodeText(EventWhatWhenWherePov(c.BestBirthlikeEvent, enc, nc, intro.POV)), c.BestBirthlikeEvent.GetCitations()).String())
+		detail.Continue(loc.RelativePronoun(c.Gender))
+		detail.Continue(enc.EncodeWithCitations(enc.EncodeText(EventWhatWhenWherePov(c.BestBirthlikeEvent, enc, nc, intro.POV, loc)), c.BestBirthlikeEvent.GetCitations()).String())
 		if detail.CurrentSentenceLength() < 60 {
 			detail.Continue(ChooseFrom(seq,
-				"with an unknown "+s.Principal.Gender.Opposite().RelationToChildrenNoun(),
-				"by an unknown "+s.Principal.Gender.Opposite().Noun(),
+				loc.Tf("with an unknown %s", loc.T(s.Principal.Gender.Opposite().RelationToChildrenNoun())),
+				loc.Tf("by an unknown %s", loc.T(s.Principal.Gender.Opposite().Noun())),
 			))
 		}
 
@@ -375,14 +373,14 @@ func (s *FamilyStatement[T]) renderSingleParent(seq int, intro *IntroGenerator[T
 			detail.Continue(intro.Pronoun(seq, c.BestBirthlikeEvent.GetDate(), s.Principal))
 		}
 		detail.Continue(ChooseFrom(seq,
-			"had a child",
-			"had a "+c.Gender.RelationToParentNoun(),
+			loc.T("had a child"),
+			loc.T("had a "+c.Gender.RelationToParentNoun()),
 		))
 		if !c.Redacted {
 			detail.AppendAsAside(enc.EncodeModelLink(enc.EncodeText(c.PreferredFamiliarName), c).String())
 		}
 		if otherKnown {
-			detail.Continue("with", otherName)
+			detail.Continue(loc.T("with"), otherName)
 		}
 
 	default:
@@ -390,31 +388,31 @@ func (s *FamilyStatement[T]) renderSingleParent(seq int, intro *IntroGenerator[T
 		if c.BestBirthlikeEvent != nil {
 			detail.Continue(intro.Pronoun(seq, c.BestBirthlikeEvent.GetDate(), s.Principal))
 		}
-		detail.Continue("had", ChildCardinal(s.Family.Children))
+		detail.Continue(loc.T("had"), ChildCardinal(s.Family.Children, loc))
 		if otherKnown {
-			detail.Continue("with", otherName)
+			detail.Continue(loc.T("with"), otherName)
 		}
 	}
 
 	if oneChild {
-		s.renderSingleChildParagraph(&detail, c, enc, nc)
+		s.renderSingleChildParagraph(&detail, c, enc, nc, loc)
 	} else {
-		s.renderChildListParagraph(&detail, enc, nc, s.Family.Children)
+		s.renderChildListParagraph(&detail, enc, nc, s.Family.Children, loc)
 	}
 }
 
-func (s *FamilyStatement[T]) renderSingleChildParagraph(detail *text.Para, c *model.Person, enc render.ContentBuilder[T], nc NameChooser) {
+func (s *FamilyStatement[T]) renderSingleChildParagraph(detail *text.Para, c *model.Person, enc render.ContentBuilder[T], nc NameChooser, loc locale.Locale) {
 	if c.Redacted {
 		enc.Para(enc.EncodeText(detail.Text()))
 		return
 	}
 	detail.FinishSentenceWithTerminator(":–")
 	enc.Para(enc.EncodeText(detail.Text()))
-	enc.UnorderedList([]T{PersonSummary(c, enc, nc, enc.EncodeText(c.PreferredFamiliarName), false, false, false, true, true)})
+	enc.UnorderedList([]T{PersonSummary(c, enc, nc, enc.EncodeText(c.PreferredFamiliarName), false, false, false, true, true, loc)})
 }
 
-func (s *FamilyStatement[T]) renderChildListParagraph(detail *text.Para, enc render.ContentBuilder[T], nc NameChooser, children []*model.Person) {
-	childList := ChildList(children, enc, nc)
+func (s *FamilyStatement[T]) renderChildListParagraph(detail *text.Para, enc render.ContentBuilder[T], nc NameChooser, children []*model.Person, loc locale.Locale) {
+	childList := ChildList(children, enc, nc, loc)
 	if len(childList) == 0 {
 		enc.Para(enc.EncodeText(detail.Text()))
 		return
@@ -432,7 +430,7 @@ func isFirmBirthdateEvent(ev model.IndividualTimelineEvent) bool {
 	return ev.GetDate().IsFirm()
 }
 
-func ChildCardinal(clist []*model.Person) string {
+func ChildCardinal(clist []*model.Person, loc locale.Locale) string {
 	// TODO: note how many children survived if some died
 	allSameGender := true
 	if clist[0].Redacted {
@@ -452,15 +450,15 @@ func ChildCardinal(clist []*model.Person) string {
 
 	if allSameGender {
 		if clist[0].Gender == model.GenderMale {
-			return text.CardinalWithUnit(len(clist), "son", "sons")
+			return loc.CardinalWithUnit(len(clist), "son", "sons")
 		} else {
-			return text.CardinalWithUnit(len(clist), "daughter", "daughters")
+			return loc.CardinalWithUnit(len(clist), "daughter", "daughters")
 		}
 	}
-	return text.CardinalWithUnit(len(clist), "child", "children")
+	return loc.CardinalWithUnit(len(clist), "child", "children")
 }
 
-func ChildList[T render.EncodedText](clist []*model.Person, enc render.ContentBuilder[T], nc NameChooser) []T {
+func ChildList[T render.EncodedText](clist []*model.Person, enc render.ContentBuilder[T], nc NameChooser, loc locale.Locale) []T {
 	sort.Slice(clist, func(i, j int) bool {
 		var d1, d2 *model.Date
 		if clist[i].BestBirthlikeEvent != nil {
@@ -480,7 +478,7 @@ func ChildList[T render.EncodedText](clist []*model.Person, enc render.ContentBu
 			redactedCount++
 			continue
 		}
-		childSummary := PersonSummary(c, enc, nc, enc.EncodeText(c.PreferredGivenName), true, false, true, true, true)
+		childSummary := PersonSummary(c, enc, nc, enc.EncodeText(c.PreferredGivenName), true, false, true, true, true, loc)
 		if !childSummary.IsZero() {
 			childList = append(childList, childSummary)
 		}
@@ -489,7 +487,7 @@ func ChildList[T render.EncodedText](clist []*model.Person, enc render.ContentBu
 		return childList
 	}
 	if redactedCount > 0 {
-		childList = append(childList, enc.EncodeText(text.CardinalWithUnit(redactedCount, "other child", "other children")+" living or recently died"))
+		childList = append(childList, enc.EncodeText(loc.Tf("%s living or recently died", loc.CardinalWithUnit(redactedCount, "other child", "other children"))))
 	}
 
 	return childList
@@ -516,23 +514,24 @@ func (s *FamilyEndStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T],
 		return
 	}
 
+	loc := intro.Loc()
 	var detail text.Para
 	end := ""
 	switch s.Family.EndReason {
 	case model.FamilyEndReasonDivorce:
-		detail.StartSentence(s.Principal.PreferredFamiliarName, "and", other.PreferredFamiliarName, "divorced", endDate.When())
+		detail.StartSentence(s.Principal.PreferredFamiliarName, loc.T("and"), other.PreferredFamiliarName, loc.Predicate(loc.T("divorced"), loc.When(endDate)))
 	case model.FamilyEndReasonDeath:
-		name := s.Principal.Gender.PossessivePronounSingular() + " " + other.Gender.RelationToSpouseNoun()
+		name := loc.PossessivePronoun(s.Principal.Gender, other.Gender.RelationToSpouseNoun())
 		if !other.IsUnknown() {
 			name = other.PreferredFamiliarName + ", " + name + ", "
 		}
-		detail.StartSentence(PersonDeathSummary(other, enc, nc, enc.EncodeText(name), true, false, true, true).String())
+		detail.StartSentence(PersonDeathSummary(other, enc, nc, enc.EncodeText(name), true, false, true, true, loc).String())
 		// if (other.BestDeathlikeEvent != nil && !other.BestDeathlikeEvent.IsInferred()) && (s.Family.Bond == model.FamilyBondMarried || s.Family.Bond == model.FamilyBondLikelyMarried) {
 		// 	detail.StartSentence(s.Principal.PreferredFamiliarName, "was left a", s.Principal.Gender.WidowWidower())
 		// }
 	case model.FamilyEndReasonUnknown:
 		// TODO: format FamilyEndReasonUnknown
-		end += "the marriage ended " + endDate.When()
+		end += loc.Predicate(loc.T("the marriage ended"), loc.When(endDate))
 	}
 
 	if end != "" {
@@ -578,10 +577,12 @@ func (s *DeathStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 		return
 	}
 
+	loc := intro.Loc()
 	bev := s.Principal.BestDeathlikeEvent
 
-	evDetail := DeathWhat(bev, s.Principal.ModeOfDeath)
+	evDetail := DeathWhat(bev, s.Principal.ModeOfDeath, loc)
 
+	var parts []string
 	if !bev.GetDate().IsUnknown() {
 		if age, ok := s.Principal.AgeInYearsAt(bev.GetDate()); ok {
 			ageDetail := ""
@@ -590,40 +591,39 @@ func (s *DeathStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 					if pi.Y == 0 {
 						if pi.M == 0 {
 							if pi.D == 0 {
-								ageDetail = "shortly"
+								ageDetail = loc.T("shortly")
 							} else if pi.D < 7 {
-								ageDetail = "less than a week"
+								ageDetail = loc.T("less than a week")
 							} else if pi.D < 10 {
-								ageDetail = "just a week"
+								ageDetail = loc.T("just a week")
 							} else {
-								ageDetail = "a couple of weeks"
+								ageDetail = loc.T("a couple of weeks")
 							}
 						} else {
 							if pi.M == 1 {
-								ageDetail = "just a month"
+								ageDetail = loc.T("just a month")
 							} else if pi.M < 4 {
-								ageDetail = "a few months"
+								ageDetail = loc.T("a few months")
 							} else {
-								ageDetail = "less than a year"
+								ageDetail = loc.T("less than a year")
 							}
 						}
-						ageDetail += " after " + s.Principal.Gender.SubjectPronoun() + " was born"
+						ageDetail = loc.Tf("%s after %s was born", ageDetail, loc.SubjectPronoun(s.Principal.Gender))
 					}
 				}
 			}
 
 			if ageDetail == "" {
-				ageDetail = AgeQualifier(age)
+				ageDetail = AgeQualifier(age, loc)
 			}
-			evDetail += " " + ageDetail
+			parts = append(parts, ageDetail)
 		}
-		evDetail += " " + bev.GetDate().When()
+		parts = append(parts, loc.When(bev.GetDate()))
 	} else {
-		evDetail += " on an unknown date"
-	}
-	if !bev.GetPlace().IsUnknown() {
-		evDetail = WhatWherePov(evDetail, bev.GetPlace(), enc, nc, intro.POV)
+		parts = append(parts, loc.T("on an unknown date"))
 	}
+	parts = append(parts, wherePov(bev.GetPlace(), enc, nc, intro.POV, loc))
+	evDetail = loc.Predicate(evDetail, parts...)
 
 	burialRunOnSentence := true
 
@@ -632,8 +632,8 @@ func (s *DeathStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 
 	if s.Principal.CauseOfDeath != nil {
 		para.StartSentence(
-			s.Principal.Gender.PossessivePronounSingular(),
-			"death was attributed to",
+			loc.PossessivePronoun(s.Principal.Gender, "death"),
+			loc.T("was attributed to"),
 			enc.EncodeWithCitations(enc.EncodeText(s.Principal.CauseOfDeath.Detail), s.Principal.CauseOfDeath.Citations).String(),
 		)
 		burialRunOnSentence = false
@@ -678,35 +678,34 @@ func (s *DeathStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 		funeralEvDetail := ""
 		switch funeralEvent.(type) {
 		case *model.BurialEvent:
-			funeralEvDetail = "buried"
+			funeralEvDetail = loc.T("buried")
 		case *model.CremationEvent:
-			funeralEvDetail = "cremated"
+			funeralEvDetail = loc.T("cremated")
 		default:
 			panic("unhandled funeral event")
 		}
 
+		var funeralWhen string
 		interval := bev.GetDate().IntervalUntil(funeralEvent.GetDate())
 		if days, ok := interval.ApproxDays(); ok && days < 15 {
 			switch days {
 			case 0:
-				funeralEvDetail += " the same day"
+				funeralWhen = loc.T("the same day")
 			case 1:
-				funeralEvDetail += " the next day"
+				funeralWhen = loc.T("the next day")
 			default:
-				funeralEvDetail += fmt.Sprintf(" %s days later", text.CardinalNoun(days))
+				funeralWhen = loc.Tf("%s days later", loc.CardinalNoun(days))
 			}
 		} else {
-			funeralEvDetail += " " + funeralEvent.GetDate().When()
-		}
-		if !funeralEvent.GetPlace().IsUnknown() {
-			funeralEvDetail = WhatWherePov(funeralEvDetail, funeralEvent.GetPlace(), enc, nc, intro.POV)
+			funeralWhen = loc.When(funeralEvent.GetDate())
 		}
+		funeralEvDetail = loc.Predicate(funeralEvDetail, funeralWhen, wherePov(funeralEvent.GetPlace(), enc, nc, intro.POV, loc))
 
 		funeralCited := enc.EncodeWithCitations(enc.EncodeText(funeralEvDetail), funeralEvent.GetCitations()).String()
 		if para.IsEmpty() {
-			para.StartSentence(text.UpperFirst(s.Principal.Gender.SubjectPronounWithLink()), funeralCited)
+			para.StartSentence(text.UpperFirst(loc.SubjectPronounWithLink(s.Principal.Gender)), funeralCited)
 		} else {
-			para.Continue("and was", funeralCited)
+			para.Continue(loc.T("and was"), funeralCited)
 		}
 	}
 
@@ -724,9 +723,9 @@ func (s *DeathStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 			possibleSurvivor := lastFamily.OtherParent(s.Principal)
 			if possibleSurvivor != nil && possibleSurvivor.BestDeathlikeEvent != nil && !possibleSurvivor.BestDeathlikeEvent.GetDate().IsUnknown() {
 				if s.Principal.BestDeathlikeEvent.GetDate().SortsBefore(possibleSurvivor.BestDeathlikeEvent.GetDate()) {
-					para.Continue(text.UpperFirst(s.Principal.Gender.SubjectPronounWithLink()), "survived by")
+					para.Continue(text.UpperFirst(loc.SubjectPronounWithLink(s.Principal.Gender)), loc.T("survived by"))
 					if lastFamily.Bond == model.FamilyBondMarried {
-						para.Continue(text.LowerFirst(s.Principal.Gender.PossessivePronounSingular()), text.LowerFirst(possibleSurvivor.Gender.RelationToSpouseNoun()))
+						para.Continue(loc.PossessivePronoun(s.Principal.Gender, text.LowerFirst(possibleSurvivor.Gender.RelationToSpouseNoun())))
 					}
 					para.Continue(intro.IntroducePerson(seq, possibleSurvivor, s.Start(), false, enc, nc))
 				}
@@ -739,30 +738,30 @@ func (s *DeathStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 	}
 
 	if s.IncludeMedia && bev != nil {
-		// TODO: handle error
-		_ = MediaObjectsAsFigures(bev.GetMediaObjects(), enc, s.CropMediaHighlights)
+		MediaObjectsAsFigures(bev.GetMediaObjects(), enc, s.CropMediaHighlights)
 	}
 }
 
 func (s *DeathStatement[T]) RenderDetailUnknown(seq int, intro *IntroGenerator[T], enc render.ContentBuilder[T], nc NameChooser) error {
+	loc := intro.Loc()
 	var para text.Para
-	para.StartSentence("the time and place of", text.MaybePossessiveSuffix(s.Principal.PreferredGivenName), "death is not known")
+	para.StartSentence(loc.Tf("the time and place of %s death is not known", loc.PossessiveName(s.Principal.PreferredGivenName)))
 	if len(s.PossibleDeaths) > 0 {
 		list := []string{}
 		hasCitation := false
 		for _, ev := range s.PossibleDeaths {
-			list = append(list, enc.EncodeWithCitations(enc.EncodeText(EventWhenWherePov(ev, enc, nc, intro.POV)), ev.GetCitations()).String())
+			list = append(list, enc.EncodeWithCitations(enc.EncodeText(EventWhenWherePov(ev, enc, nc, intro.POV, loc)), ev.GetCitations()).String())
 			if len(ev.GetCitations()) > 0 {
 				hasCitation = true
 			}
 		}
 		if hasCitation {
-			para.StartSentence("however, there is some evidence that")
+			para.StartSentence(loc.T("however, there is some evidence that"))
 		} else {
-			para.StartSentence("it's possible that")
+			para.StartSentence(loc.T("it's possible that"))
 		}
-		para.Continue(s.Principal.Gender.SubjectPronoun(), "died")
-		para.FinishSentence(text.JoinListOr(list))
+		para.Continue(loc.SubjectPronoun(s.Principal.Gender), loc.T("died"))
+		para.FinishSentence(loc.JoinListOr(list))
 
 	}
 	enc.Para(enc.EncodeText(para.Text()))
@@ -800,6 +799,7 @@ func (s *CensusStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 		return
 	}
 
+	loc := intro.Loc()
 	year, _ := s.Event.GetDate().Year()
 
 	narrative := ce.Narrative
@@ -810,7 +810,7 @@ func (s *CensusStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 	if narrative != "" {
 		var detail text.Para
 		detail.StartSentence(intro.Pronoun(seq, s.Start(), s.Principal))
-		detail.Continue(enc.EncodeWithCitations(enc.EncodeText(WhatWherePov(fmt.Sprintf("%s recorded in the %d census", intro.WasWere(s.Principal), year), s.Event.GetPlace(), enc, nc, intro.POV)), s.Event.GetCitations()).String()) // fmt.Sprintf("in the %d census", year)
+		detail.Continue(enc.EncodeWithCitations(enc.EncodeText(WhatWherePov(loc.Tf("%s recorded in the %d census", intro.WasWere(s.Principal), year), s.Event.GetPlace(), enc, nc, intro.POV, loc)), s.Event.GetCitations()).String()) // fmt.Sprintf("in the %d census", year)
 		detail.StartSentence(narrative)
 		detail.FinishSentence()
 		enc.Para(enc.EncodeText(detail.Text()))
@@ -820,12 +820,12 @@ func (s *CensusStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 
 	var detail text.Para
 	what := ChooseFrom(seq,
-		fmt.Sprintf("%s %s recorded in the %d census", intro.Pronoun(seq, s.Start(), s.Principal), intro.WasWere(s.Principal), year),
-		fmt.Sprintf("by the time of the %d census %s %s living", year, intro.Pronoun(seq, s.Start(), s.Principal), intro.WasWere(s.Principal)),
-		fmt.Sprintf("in the %d census %s %s living", year, intro.Pronoun(seq, s.Start(), s.Principal), intro.WasWere(s.Principal)),
+		loc.Tf("%s %s recorded in the %d census", intro.Pronoun(seq, s.Start(), s.Principal), intro.WasWere(s.Principal), year),
+		loc.Tf("by the time of the %d census %s %s living", year, intro.Pronoun(seq, s.Start(), s.Principal), intro.WasWere(s.Principal)),
+		loc.Tf("in the %d census %s %s living", year, intro.Pronoun(seq, s.Start(), s.Principal), intro.WasWere(s.Principal)),
 	)
 
-	detail.StartSentence(enc.EncodeWithCitations(enc.EncodeText(WhatWherePov(what, s.Event.GetPlace(), enc, nc, intro.POV)), s.Event.GetCitations()).String()) // fmt.Sprintf("in the %d census", year)
+	detail.StartSentence(enc.EncodeWithCitations(enc.EncodeText(WhatWherePov(what, s.Event.GetPlace(), enc, nc, intro.POV, loc)), s.Event.GetCitations()).String()) // fmt.Sprintf("in the %d census", year)
 
 	var spouse *model.CensusEntry
 	var father *model.CensusEntry
@@ -866,47 +866,47 @@ func (s *CensusStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 	}
 
 	if spouse != nil || father != nil || mother != nil || len(children) != 0 || len(relations) != 0 || len(siblings) != 0 {
-		detail.Continue("with")
-		detail.Continue(s.Principal.Gender.PossessivePronounSingular())
+		detail.Continue(loc.T("with"))
+		g := s.Principal.Gender
 
 		peopleList := make([]string, 0, len(children)+len(relations)+len(siblings)+3)
 
 		if spouse != nil {
 			rel := strings.ToLower(spouse.Principal.RelationTo(s.Principal, s.Event.GetDate()))
-			peopleList = append(peopleList, rel+" "+intro.IntroducePerson(seq, spouse.Principal, s.Start(), false, enc, nc))
+			peopleList = append(peopleList, loc.PossessivePronounWith(g, rel)+" "+intro.IntroducePerson(seq, spouse.Principal, s.Start(), false, enc, nc))
 		}
 
 		if len(children) > 0 {
 			if len(children) == 1 {
 				rel := strings.ToLower(children[0].Principal.RelationTo(s.Principal, s.Event.GetDate()))
-				peopleList = append(peopleList, rel+" "+intro.IntroducePerson(seq, children[0].Principal, s.Start(), true, enc, nc))
+				peopleList = append(peopleList, loc.PossessivePronounWith(g, rel)+" "+intro.IntroducePerson(seq, children[0].Principal, s.Start(), true, enc, nc))
 			} else {
 				ens := make([]string, 0, len(children))
 				for _, en := range children {
 					ens = append(ens, intro.IntroducePerson(seq, en.Principal, s.Start(), true, enc, nc))
 				}
-				peopleList = append(peopleList, text.CardinalNoun(len(ens))+" children "+text.JoinList(ens))
+				peopleList = append(peopleList, loc.PossessivePronounWith(g, loc.CardinalNoun(len(ens))+" "+loc.T("children"))+" "+loc.JoinList(ens))
 			}
 		}
 
 		if father != nil {
-			peopleList = append(peopleList, "father "+intro.IntroducePerson(seq, father.Principal, s.Start(), false, enc, nc))
+			peopleList = append(peopleList, loc.PossessivePronounWith(g, "father")+" "+intro.IntroducePerson(seq, father.Principal, s.Start(), false, enc, nc))
 		}
 		if mother != nil {
-			peopleList = append(peopleList, "mother "+intro.IntroducePerson(seq, mother.Principal, s.Start(), false, enc, nc))
+			peopleList = append(peopleList, loc.PossessivePronounWith(g, "mother")+" "+intro.IntroducePerson(seq, mother.Principal, s.Start(), false, enc, nc))
 		}
 
 		if len(siblings) > 0 {
 			if len(siblings) == 1 {
 				rel := strings.ToLower(siblings[0].Principal.RelationTo(s.Principal, s.Event.GetDate()))
-				peopleList = append(peopleList, rel+" "+enc.EncodeModelLink(enc.EncodeText(siblings[0].Principal.PreferredGivenName), siblings[0].Principal).String())
+				peopleList = append(peopleList, loc.PossessivePronounWith(g, rel)+" "+enc.EncodeModelLink(enc.EncodeText(siblings[0].Principal.PreferredGivenName), siblings[0].Principal).String())
 			} else {
 
 				ens := make([]string, 0, len(siblings))
 				for _, en := range siblings {
 					ens = append(ens, intro.IntroducePerson(seq, en.Principal, s.Start(), true, enc, nc))
 				}
-				peopleList = append(peopleList, text.CardinalNoun(len(ens))+" siblings "+text.JoinList(ens))
+				peopleList = append(peopleList, loc.PossessivePronounWith(g, loc.CardinalNoun(len(ens))+" "+loc.T("siblings"))+" "+loc.JoinList(ens))
 			}
 		}
 
@@ -914,18 +914,17 @@ func (s *CensusStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc
 			ens := make([]string, 0, len(relations))
 			for _, en := range relations {
 				rel := strings.ToLower(en.Principal.RelationTo(s.Principal, s.Event.GetDate()))
-				ens = append(ens, rel+" "+intro.IntroducePerson(seq, en.Principal, s.Start(), true, enc, nc))
+				ens = append(ens, loc.PossessivePronounWith(g, rel)+" "+intro.IntroducePerson(seq, en.Principal, s.Start(), true, enc, nc))
 			}
-			peopleList = append(peopleList, text.JoinList(ens))
+			peopleList = append(peopleList, loc.JoinList(ens))
 		}
 
-		detail.Continue(text.JoinList(peopleList))
+		detail.Continue(loc.JoinList(peopleList))
 	}
 
 	enc.Para(enc.EncodeText(detail.Text()))
 
 	if s.IncludeMedia {
-		// TODO: handle error
 		MediaObjectsAsFigures(s.Event.GetMediaObjects(), enc, s.CropMediaHighlights)
 	}
 }
@@ -966,7 +965,7 @@ func (s *GeneralEventStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T
 	default:
 		// prepend an intro
 		detail.StartSentence(intro.Pronoun(seq, s.Start(), s.Principal))
-		detail.Continue(enc.EncodeWithCitations(enc.EncodeText(EventWhatWhenWherePov(s.Event, enc, nc, intro.POV)), s.Event.GetCitations()).String())
+		detail.Continue(enc.EncodeWithCitations(enc.EncodeText(EventWhatWhenWherePov(s.Event, enc, nc, intro.POV, intro.Loc())), s.Event.GetCitations()).String())
 	}
 
 	narrative := EventNarrativeDetail(s.Event, enc)
@@ -1005,6 +1004,7 @@ type ChildrenStatement[T render.EncodedText] struct {
 var _ Statement[md.Text] = (*ChildrenStatement[md.Text])(nil)
 
 func (s *ChildrenStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc render.ContentBuilder[T], nc NameChooser) {
+	loc := intro.Loc()
 	var detail text.Para
 
 	singleParent := false
@@ -1015,7 +1015,7 @@ func (s *ChildrenStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], e
 
 	if s.Family.Father.IsUnknown() {
 		if s.Family.Mother.IsUnknown() {
-			detail.StartSentence("They")
+			detail.StartSentence(loc.T("They"))
 		} else {
 			detail.StartSentence(s.Family.Mother.PreferredGivenName)
 		}
@@ -1023,35 +1023,35 @@ func (s *ChildrenStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], e
 		if s.Family.Mother.IsUnknown() {
 			detail.StartSentence(s.Family.Father.PreferredGivenName)
 		} else {
-			detail.StartSentence("They")
+			detail.StartSentence(loc.T("They"))
 		}
 	}
 
 	if len(s.Family.Children) == 0 {
 		// single parents already dealt with
 		if (!s.Family.Father.IsUnknown() && s.Family.Father.Childless) || (!s.Family.Mother.IsUnknown() && s.Family.Mother.Childless) {
-			detail.AddCompleteSentence("they had no children")
+			detail.AddCompleteSentence(loc.T("they had no children"))
 		}
 	} else {
 
-		childCardinal := ChildCardinal(s.Family.Children)
+		childCardinal := ChildCardinal(s.Family.Children, loc)
 		if singleParent {
-			detail.Continue("had " + childCardinal)
+			detail.Continue(loc.Tf("had %s", childCardinal))
 		} else {
 			switch len(s.Family.Children) {
 			case 1:
 				detail.Continue(ChooseFrom(seq,
-					"had "+childCardinal+":",
-					"had just one child together:",
-					"had "+childCardinal+":",
+					loc.Tf("had %s", childCardinal)+":",
+					loc.T("had just one child together")+":",
+					loc.Tf("had %s", childCardinal)+":",
 				))
 			default:
-				detail.Continue("had " + childCardinal + ":")
+				detail.Continue(loc.Tf("had %s", childCardinal) + ":")
 			}
 		}
 	}
 
-	childList := ChildList(s.Family.Children, enc, nc)
+	childList := ChildList(s.Family.Children, enc, nc, loc)
 	if len(childList) == 0 {
 		enc.Para(enc.EncodeText(detail.Text()))
 		return
@@ -1087,9 +1087,7 @@ type MediaStatement[T render.EncodedText] struct {
 var _ Statement[md.Text] = (*MediaStatement[md.Text])(nil)
 
 func (s *MediaStatement[T]) RenderDetail(seq int, intro *IntroGenerator[T], enc render.ContentBuilder[T], nc NameChooser) {
-	for _, mo := range s.MediaObjects {
-		enc.Figure(mo.Object.SrcFilePath, mo.Object.Title, enc.EncodeText(mo.Object.Title), mo.Highlight, "")
-	}
+	MediaObjectsAsFigures(s.MediaObjects, enc, false)
 }
 
 func (s *MediaStatement[T]) Start() *model.Date {
@@ -1107,60 +1105,3 @@ func (s *MediaStatement[T]) NarrativeSequence() int {
 func (s *MediaStatement[T]) Priority() int {
 	return 7
 }
-
-func cropMedia(mo *model.MediaObject, region *model.Region) (string, error) {
-	f, err := os.Open(mo.SrcFilePath)
-	if err != nil {
-		return "", fmt.Errorf("open image: %w", err)
-	}
-	defer f.Close()
-	img, format, err := image.Decode(f)
-	if err != nil {
-		return "", fmt.Errorf("decode image: %w", err)
-	}
-
-	if region != nil {
-
-		type subImager interface {
-			SubImage(r image.Rectangle) image.Image
-			Bounds() image.Rectangle
-		}
-		sub, ok := img.(subImager)
-		if !ok {
-			return "", fmt.Errorf("image does not support cropping")
-		}
-		bounds := sub.Bounds()
-		width := bounds.Dx()
-		height := bounds.Dy()
-		crop := image.Rectangle{
-			Min: image.Point{
-				X: int(float64(width) * float64(region.Left) / 100.0),
-				Y: int(float64(height) * (1 - float64(region.Bottom+region.Height)/100.0)),
-			},
-			Max: image.Point{
-				X: int(float64(width) * float64(region.Left+region.Width) / 100.0),
-				Y: int(float64(height) * (1 - float64(region.Bottom)/100.0)),
-			},
-		}
-		img = sub.SubImage(crop)
-	}
-
-	out := new(bytes.Buffer)
-	switch format {
-	case "jpeg":
-		err := jpeg.Encode(out, img, nil)
-		if err != nil {
-			return "", fmt.Errorf("encode image: %w", err)
-		}
-	case "png":
-		err := png.Encode(out, img)
-		if err != nil {
-			return "", fmt.Errorf("encode image: %w", err)
-		}
-	default:
-		return "", fmt.Errorf("unknown image format %q", format)
-	}
-
-	base64Img := base64.StdEncoding.EncodeToString(out.Bytes())
-	return fmt.Sprintf("data:image/png;base64,%s", base64Img), nil
-}
@@ -7,82 +7,83 @@ import (
 
 	"github.com/iand/gdate"
 	"github.com/iand/genster/fact"
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/render"
 	"github.com/iand/genster/text"
 )
 
-func AgeQualifier(age int) string {
+func AgeQualifier(age int, loc locale.Locale) string {
 	if age == 0 {
-		return "as an infant"
+		return loc.T("as an infant")
 	} else if age < 10 {
-		return "as a child"
+		return loc.T("as a child")
 	}
-	return fmt.Sprintf("at the age of %s", text.CardinalNoun(age))
+	return loc.Tf("at the age of %s", loc.CardinalNoun(age))
 }
 
-func WhoWhatWhenWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser) string {
+func WhoWhatWhenWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
 	if ev == nil {
-		return "unknown"
+		return loc.T("unknown")
 	}
 	var who string
 	switch tev := ev.(type) {
 	case model.IndividualTimelineEvent:
 		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetPrincipal())), tev.GetPrincipal()).String()
 	case model.UnionTimelineEvent:
-		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " and " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
+		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " " + loc.T("and") + " " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
 	case model.MultipartyTimelineEvent:
 		var names []string
 		for _, p := range tev.GetPrincipals() {
 			names = append(names, enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(p)), p).String())
 		}
-		who = text.JoinList(names)
+		who = loc.JoinList(names)
 	}
 
-	return text.JoinSentenceParts(who, EventWhatWhenWhere(ev, enc, nc))
+	return text.JoinSentenceParts(who, EventWhatWhenWhere(ev, enc, nc, loc))
 }
 
-func WhoWhatWhenWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
+func WhoWhatWhenWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
 	if ev == nil {
-		return "unknown"
+		return loc.T("unknown")
 	}
 	var who string
 	switch tev := ev.(type) {
 	case model.IndividualTimelineEvent:
 		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetPrincipal())), tev.GetPrincipal()).String()
 	case model.UnionTimelineEvent:
-		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " and " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
+		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " " + loc.T("and") + " " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
 	case model.MultipartyTimelineEvent:
 		var names []string
 		for _, p := range tev.GetPrincipals() {
 			names = append(names, enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(p)), p).String())
 		}
-		who = text.JoinList(names)
+		who = loc.JoinList(names)
 	}
 
-	return text.JoinSentenceParts(who, EventWhatWhenWherePov(ev, enc, nc, pov))
+	return text.JoinSentenceParts(who, EventWhatWhenWherePov(ev, enc, nc, pov, loc))
 }
 
-func Who[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser) string {
+func Who[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
 	var who string
 	switch tev := ev.(type) {
 	case model.IndividualTimelineEvent:
 		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetPrincipal())), tev.GetPrincipal()).String()
 	case model.UnionTimelineEvent:
-		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " and " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
+		who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " " + loc.T("and") + " " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
 	case model.MultipartyTimelineEvent:
 		var names []string
 		for _, p := range tev.GetPrincipals() {
 			names = append(names, enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(p)), p).String())
 		}
-		who = text.JoinList(names)
+		who = loc.JoinList(names)
 	}
 
 	return who
 }
 
-func WhoPov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
+func WhoPov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
 	var who string
 	switch tev := ev.(type) {
 	case model.IndividualTimelineEvent:
@@ -90,7 +91,7 @@ func WhoPov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder
 		if !pov.Person.IsUnknown() && !pov.Person.SameAs(tev.GetPrincipal()) {
 			rel := tev.GetPrincipal().RelationTo(pov.Person, ev.GetDate())
 			if rel != "" {
-				rel = text.MaybePossessiveSuffix(pov.Person.PreferredFullName) + " " + rel
+				rel = loc.PossessiveName(pov.Person.PreferredFullName) + " " + loc.T(rel)
 			}
 
 			who = rel + " " + who
@@ -99,166 +100,172 @@ func WhoPov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder
 		if !pov.Person.IsUnknown() && !tev.GetHusband().SameAs(pov.Person) && !tev.GetWife().SameAs(pov.Person) {
 			husbRel := tev.GetHusband().RelationTo(pov.Person, ev.GetDate())
 			if husbRel != "" {
-				husbRel = text.MaybePossessiveSuffix(pov.Person.PreferredFullName) + " " + husbRel
+				husbRel = loc.PossessiveName(pov.Person.PreferredFullName) + " " + loc.T(husbRel)
 			}
 			wifeRel := tev.GetWife().RelationTo(pov.Person, ev.GetDate())
 			if wifeRel != "" {
-				wifeRel = text.MaybePossessiveSuffix(pov.Person.PreferredFullName) + " " + wifeRel
+				wifeRel = loc.PossessiveName(pov.Person.PreferredFullName) + " " + loc.T(wifeRel)
 			}
-			who = enc.EncodeModelLink(enc.EncodeText(husbRel, nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " and " + enc.EncodeModelLink(enc.EncodeText(wifeRel, nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
+			who = enc.EncodeModelLink(enc.EncodeText(husbRel, nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " " + loc.T("and") + " " + enc.EncodeModelLink(enc.EncodeText(wifeRel, nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
 
 		} else {
-			who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " and " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
+			who = enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetHusband())), tev.GetHusband()).String() + " " + loc.T("and") + " " + enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(tev.GetWife())), tev.GetWife()).String()
 		}
 	case model.MultipartyTimelineEvent:
 		var names []string
 		for _, p := range tev.GetPrincipals() {
 			names = append(names, enc.EncodeModelLink(enc.EncodeText(nc.FirstUse(p)), p).String())
 		}
-		who = text.JoinList(names)
+		who = loc.JoinList(names)
 	}
 
 	return who
 }
 
-func EventWhatWhenWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser) string {
-	return WhatWhenWhere(InferredWhat(ev, ev), ev.GetDate(), ev.GetPlace(), enc, nc)
+func EventWhatWhenWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
+	return WhatWhenWhere(InferredWhat(ev, ev, loc), ev.GetDate(), ev.GetPlace(), enc, nc, loc)
 }
 
-func EventWhatWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser) string {
-	return WhatWhere(InferredWhat(ev, ev), ev.GetPlace(), enc, nc)
+func EventWhatWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
+	return WhatWhere(InferredWhat(ev, ev, loc), ev.GetPlace(), enc, nc, loc)
 }
 
-func EventWhenWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser) string {
-	return WhenWhere(ev.GetDate(), ev.GetPlace(), enc, nc)
+func EventWhenWhere[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
+	return WhenWhere(ev.GetDate(), ev.GetPlace(), enc, nc, loc)
 }
 
-func EventWhatWhenWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
-	return WhatWhenWherePov(InferredWhat(ev, ev), ev.GetDate(), ev.GetPlace(), enc, nc, pov)
+func EventWhatWhenWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
+	return WhatWhenWherePov(InferredWhat(ev, ev, loc), ev.GetDate(), ev.GetPlace(), enc, nc, pov, loc)
 }
 
-func EventWhatWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
-	return WhatWherePov(InferredWhat(ev, ev), ev.GetPlace(), enc, nc, pov)
+func EventWhatWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
+	return WhatWherePov(InferredWhat(ev, ev, loc), ev.GetPlace(), enc, nc, pov, loc)
 }
 
-func EventWhenWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
-	return WhenWherePov(ev.GetDate(), ev.GetPlace(), enc, nc, pov)
+func EventWhenWherePov[T render.EncodedText](ev model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
+	return WhenWherePov(ev.GetDate(), ev.GetPlace(), enc, nc, pov, loc)
 }
 
-func InferredWhat(w model.Whater, ev model.TimelineEvent) string {
+func InferredWhat(w model.Whater, ev model.TimelineEvent, loc locale.Locale) string {
 	if ev.IsInferred() {
-		return "is inferred to " + model.PresentPerfectWhat(w)
+		return loc.Tf("is inferred to %s", loc.PresentPerfectWhat(w))
 	}
 
 	if !ev.GetDate().IsUnknown() {
 		switch ev.GetDate().Derivation {
 		case model.DateDerivationEstimated:
-			return model.PassiveConditionalWhat(w, "probably")
+			return loc.PassiveConditionalWhat(w, "probably")
 		case model.DateDerivationCalculated:
-			return "is calculated to " + model.PresentPerfectWhat(w)
+			return loc.Tf("is calculated to %s", loc.PresentPerfectWhat(w))
 		}
 	}
 
-	return model.PassiveWhat(w)
+	return loc.PassiveWhat(w)
 }
 
-func WhatWhenWhere[T render.EncodedText](what string, dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser) string {
-	return text.JoinSentenceParts(what, WhenWhere(dt, pl, enc, nc))
+func WhatWhenWhere[T render.EncodedText](what string, dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
+	return loc.Predicate(what, WhenWhere(dt, pl, enc, nc, loc))
 }
 
-func WhatWhenWherePov[T render.EncodedText](what string, dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
-	return text.JoinSentenceParts(what, WhenWherePov(dt, pl, enc, nc, pov))
+func WhatWhenWherePov[T render.EncodedText](what string, dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
+	return loc.Predicate(what, WhenWherePov(dt, pl, enc, nc, pov, loc))
 }
 
-func WhatWhere[T render.EncodedText](what string, pl *model.Place, enc render.TextEncoder[T], nc NameChooser) string {
-	if !pl.IsUnknown() {
-		what = text.JoinSentenceParts(what, pl.InAt(), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
+// WhatWhere, like the other What functions, always passes what through
+// Predicate, even when nothing is known to qualify it, so that the wording is
+// completed for locales that split their verbs.
+func WhatWhere[T render.EncodedText](what string, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
+	if pl.IsUnknown() {
+		return loc.Predicate(what)
 	}
-	return what
+	return loc.Predicate(what, loc.InAt(pl), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
+}
+
+func WhatWherePov[T render.EncodedText](what string, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
+	return loc.Predicate(what, wherePov(pl, enc, nc, pov, loc))
 }
 
-func WhatWherePov[T render.EncodedText](what string, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
+// wherePov returns a phrase describing where something happened as seen from
+// pov, or an empty string if pl is unknown.
+func wherePov[T render.EncodedText](pl *model.Place, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
 	if pl.IsUnknown() {
-		return what
+		return ""
 	} else if pl.SameAs(pov.Place) {
-		what = text.JoinSentenceParts(what, "here")
-	} else {
-		what = text.JoinSentenceParts(what, pl.InAt(), enc.EncodeModelLinkNamed(pl, nc, pov).String())
+		return loc.T("here")
 	}
-
-	return what
+	return text.JoinSentenceParts(loc.InAt(pl), enc.EncodeModelLinkNamed(pl, nc, pov).String())
 }
 
-func WhatWhen[T render.EncodedText](what string, dt *model.Date, enc render.TextEncoder[T]) string {
-	if !dt.IsUnknown() {
-		return text.JoinSentenceParts(what, NamedWhen(dt))
+func WhatWhen[T render.EncodedText](what string, dt *model.Date, enc render.TextEncoder[T], loc locale.Locale) string {
+	if dt.IsUnknown() {
+		return loc.Predicate(what)
 	}
-	return what
+	return loc.Predicate(what, NamedWhen(dt, loc))
 }
 
-func WhenWhere[T render.EncodedText](dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser) string {
+func WhenWhere[T render.EncodedText](dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
 	title := ""
 	if !dt.IsUnknown() {
-		title = text.JoinSentenceParts(title, NamedWhen(dt))
+		title = text.JoinSentenceParts(title, NamedWhen(dt, loc))
 	}
 
 	if !pl.IsUnknown() {
-		title = text.JoinSentenceParts(title, pl.InAt(), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
+		title = text.JoinSentenceParts(title, loc.InAt(pl), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
 	}
 	return title
 }
 
-func WhenWherePov[T render.EncodedText](dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
+func WhenWherePov[T render.EncodedText](dt *model.Date, pl *model.Place, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
 	title := ""
 	if !dt.IsUnknown() {
-		title = text.JoinSentenceParts(title, NamedWhen(dt))
+		title = text.JoinSentenceParts(title, NamedWhen(dt, loc))
 	}
 
-	title = WhatWherePov(title, pl, enc, nc, pov)
+	title = WhatWherePov(title, pl, enc, nc, pov, loc)
 	return title
 }
 
-func AgeWhenWhere[T render.EncodedText](ev model.IndividualTimelineEvent, enc render.TextEncoder[T], nc NameChooser) string {
+func AgeWhenWhere[T render.EncodedText](ev model.IndividualTimelineEvent, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
 	title := ""
 
 	date := ev.GetDate()
 	if !date.IsUnknown() {
 		if age, ok := ev.GetPrincipal().AgeInYearsAt(ev.GetDate()); ok {
-			title = text.JoinSentenceParts(title, AgeQualifier(age))
+			title = text.JoinSentenceParts(title, AgeQualifier(age, loc))
 		}
-		title = text.JoinSentenceParts(title, date.When())
+		title = text.JoinSentenceParts(title, loc.When(date))
 	}
 
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
-		title = text.JoinSentenceParts(title, pl.InAt(), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
+		title = text.JoinSentenceParts(title, loc.InAt(pl), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
 	}
 	return title
 }
 
-func AgeWhenWherePov[T render.EncodedText](ev model.IndividualTimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV) string {
+func AgeWhenWherePov[T render.EncodedText](ev model.IndividualTimelineEvent, enc render.TextEncoder[T], nc NameChooser, pov *model.POV, loc locale.Locale) string {
 	title := ""
 
 	date := ev.GetDate()
 	if !date.IsUnknown() {
 		if age, ok := ev.GetPrincipal().AgeInYearsAt(ev.GetDate()); ok {
-			title = text.JoinSentenceParts(title, AgeQualifier(age))
+			title = text.JoinSentenceParts(title, AgeQualifier(age, loc))
 		}
-		title = text.JoinSentenceParts(title, date.When())
+		title = text.JoinSentenceParts(title, loc.When(date))
 	}
 
 	pl := ev.GetPlace()
 	if !pl.IsUnknown() {
-		title = WhatWherePov(title, pl, enc, nc, pov)
+		title = WhatWherePov(title, pl, enc, nc, pov, loc)
 	}
 	return title
 }
 
-func FollowingWhatWhenWhere[T render.EncodedText](what string, dt *model.Date, pl *model.Place, preceding model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser) string {
-	detail := what
+func FollowingWhatWhenWhere[T render.EncodedText](what string, dt *model.Date, pl *model.Place, preceding model.TimelineEvent, enc render.TextEncoder[T], nc NameChooser, loc locale.Locale) string {
+	var parts []string
 
 	if pl.SameAs(preceding.GetPlace()) {
-		detail = text.JoinSentenceParts(detail, "there")
+		parts = append(parts, loc.T("there"))
 	}
 
 	if !dt.IsUnknown() {
@@ -270,67 +277,65 @@ func FollowingWhatWhenWhere[T render.EncodedText](what string, dt *model.Date, p
 			if y == 0 {
 				if m == 0 {
 					if d == 0 {
-						intervalDesc = "the same day"
+						intervalDesc = loc.T("the same day")
 						suppressDate = true
 					} else if d == 1 {
-						intervalDesc = "the next day"
+						intervalDesc = loc.T("the next day")
 						suppressDate = true
 					} else if d == 2 {
-						intervalDesc = "two days later"
+						intervalDesc = loc.T("two days later")
 						suppressDate = true
 					} else if d < 7 {
-						intervalDesc = "a few days later"
+						intervalDesc = loc.T("a few days later")
 					} else if d < 10 {
-						intervalDesc = "just a week later"
+						intervalDesc = loc.T("just a week later")
 					} else {
-						intervalDesc = "a couple of weeks later"
+						intervalDesc = loc.T("a couple of weeks later")
 					}
 				} else {
 					if m == 1 {
-						intervalDesc = "the next month"
+						intervalDesc = loc.T("the next month")
 					} else if m < 4 {
-						intervalDesc = "a few months later"
+						intervalDesc = loc.T("a few months later")
 					} else {
-						intervalDesc = "less than a year later"
+						intervalDesc = loc.T("less than a year later")
 					}
 				}
 			} else {
-				intervalDesc = fmt.Sprintf("%d %s later", y, text.MaybePluralise("year", y))
+				intervalDesc = loc.Tf("%d %s later", y, loc.T(text.MaybePluralise("year", y)))
 			}
 		} else {
 			yrs, ok := in.WholeYears()
 			if ok && yrs > 0 {
-				intervalDesc = fmt.Sprintf("%d %s later", yrs, text.MaybePluralise("year", yrs))
+				intervalDesc = loc.Tf("%d %s later", yrs, loc.T(text.MaybePluralise("year", yrs)))
 			}
 		}
 
-		if intervalDesc != "" {
-			detail = text.JoinSentenceParts(detail, intervalDesc)
-		}
+		parts = append(parts, intervalDesc)
 
 		if !suppressDate {
-			detail = text.JoinSentenceParts(detail, dt.When())
+			parts = append(parts, loc.When(dt))
 		}
 	}
 
 	if !pl.IsUnknown() && !preceding.GetPlace().SameAs(pl) {
-		detail = text.JoinSentenceParts(detail, pl.InAt(), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
+		parts = append(parts, loc.InAt(pl), enc.EncodeModelLinkNamed(pl, nc, &model.POV{}).String())
 	}
 
-	return detail
+	return loc.Predicate(what, parts...)
 }
 
-func DeathWhat(ev model.IndividualTimelineEvent, mode model.ModeOfDeath) string {
+func DeathWhat(ev model.IndividualTimelineEvent, mode model.ModeOfDeath, loc locale.Locale) string {
 	if mode == model.ModeOfDeathNatural {
-		return InferredWhat(ev, ev)
+		return InferredWhat(ev, ev, loc)
 	}
 	switch ev.(type) {
 	case *model.DeathEvent:
-		return InferredWhat(mode, ev)
+		return InferredWhat(mode, ev, loc)
 	case *model.BurialEvent:
-		return text.JoinSentenceParts(model.PassiveWhat(mode), "and", InferredWhat(ev, ev))
+		return text.JoinSentenceParts(loc.PassiveWhat(mode), loc.T("and"), InferredWhat(ev, ev, loc))
 	case *model.CremationEvent:
-		return text.JoinSentenceParts(model.PassiveWhat(mode), "and", InferredWhat(ev, ev))
+		return text.JoinSentenceParts(loc.PassiveWhat(mode), loc.T("and"), InferredWhat(ev, ev, loc))
 	default:
 		panic("unhandled deathlike event in DeathWhat")
 	}
@@ -348,7 +353,7 @@ func WhoDoing[T render.EncodedText](p *model.Person, dt *model.Date, enc render.
 	return detail
 }
 
-func PositionInFamily(p *model.Person) string {
+func PositionInFamily(p *model.Person, loc locale.Locale) string {
 	if p.ParentFamily == nil {
 		return ""
 	}
@@ -368,16 +373,17 @@ func PositionInFamily(p *model.Person) string {
 	} else {
 		children = p.ParentFamily.Children
 	}
+	noun := loc.T(text.LowerFirst(p.Gender.RelationToParentNoun()))
 	if len(children) == 0 {
-		return text.LowerFirst(p.Gender.RelationToParentNoun())
+		return noun
 	}
 
 	if len(children) == 1 {
 		if p.ParentFamily.AllChildrenKnown {
-			return "only " + text.LowerFirst(p.Gender.RelationToParentNoun())
+			return loc.Tf("only %s", noun)
 		}
 
-		return "only known " + text.LowerFirst(p.Gender.RelationToParentNoun())
+		return loc.Tf("only known %s", noun)
 	}
 
 	if !p.ParentFamily.AllChildrenKnown {
@@ -385,7 +391,7 @@ func PositionInFamily(p *model.Person) string {
 	}
 
 	if children[0].SameAs(p) {
-		return "first child"
+		return loc.T("first child")
 	}
 
 	olderSameGender := 0
@@ -410,30 +416,30 @@ func PositionInFamily(p *model.Person) string {
 	}
 
 	if olderSameGender == 0 {
-		return "eldest " + text.LowerFirst(p.Gender.RelationToParentNoun())
+		return loc.Tf("eldest %s", noun)
 	}
 
 	if youngerSameGender == 0 {
-		return "youngest " + text.LowerFirst(p.Gender.RelationToParentNoun())
+		return loc.Tf("youngest %s", noun)
 	}
 
 	if olderSameGenderSurvived != olderSameGender {
-		return text.OrdinalNoun(olderSameGenderSurvived+1) + " surviving " + text.LowerFirst(p.Gender.RelationToParentNoun())
+		return loc.Tf("%s surviving %s", loc.OrdinalNoun(olderSameGenderSurvived+1), noun)
 	}
 
-	return text.OrdinalNoun(olderSameGender+1) + " " + text.LowerFirst(p.Gender.RelationToParentNoun())
+	return loc.OrdinalNoun(olderSameGender+1) + " " + noun
 }
 
-func PersonParentage[T render.EncodedText](p *model.Person, enc render.TextEncoder[T]) string {
-	rel := PositionInFamily(p)
+func PersonParentage[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], loc locale.Locale) string {
+	rel := PositionInFamily(p, loc)
 	if rel == "" {
-		rel = text.LowerFirst(p.Gender.RelationToParentNoun())
+		rel = loc.T(text.LowerFirst(p.Gender.RelationToParentNoun()))
 	}
-	intro := "the " + rel + " of "
+	intro := loc.Definite(rel) + " " + loc.T("of") + " "
 
 	if p.Father.IsUnknown() {
 		if p.Mother.IsUnknown() {
-			return intro + "unknown parents"
+			return intro + loc.T("unknown parents")
 		} else {
 			return intro + enc.EncodeModelLink(enc.EncodeText(p.Mother.PreferredFullName), p.Mother).String()
 		}
@@ -441,12 +447,12 @@ func PersonParentage[T render.EncodedText](p *model.Person, enc render.TextEncod
 		if p.Mother.IsUnknown() {
 			return intro + enc.EncodeModelLink(enc.EncodeText(p.Father.PreferredFullName), p.Father).String()
 		} else {
-			return intro + enc.EncodeModelLink(enc.EncodeText(p.Father.PreferredFullName), p.Father).String() + " and " + enc.EncodeModelLink(enc.EncodeText(p.Mother.PreferredFullName), p.Mother).String()
+			return intro + enc.EncodeModelLink(enc.EncodeText(p.Father.PreferredFullName), p.Father).String() + " " + loc.T("and") + " " + enc.EncodeModelLink(enc.EncodeText(p.Mother.PreferredFullName), p.Mother).String()
 		}
 	}
 }
 
-func PersonSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, includeBirth bool, includeParentage bool, activeTense bool, linkname bool, minimal bool) T {
+func PersonSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, includeBirth bool, includeParentage bool, activeTense bool, linkname bool, minimal bool, loc locale.Locale) T {
 	enc = &PersonLinkingTextEncoder[T]{enc}
 
 	var empty T
@@ -462,7 +468,7 @@ func PersonSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder
 		}
 
 		if p.NickName != "" {
-			name = enc.EncodeText(text.JoinSentenceParts(name.String(), fmt.Sprintf("(known as %s)", p.NickName)))
+			name = enc.EncodeText(text.JoinSentenceParts(name.String(), loc.Tf("(known as %s)", p.NickName)))
 		}
 
 	}
@@ -476,9 +482,9 @@ func PersonSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder
 	if age, ok := p.AgeInYearsAtDeath(); ok && age < 14 {
 		if !name.IsZero() {
 			if age < 1 {
-				para.StartSentence(name.String(), " died in infancy")
+				para.StartSentence(name.String(), loc.T("died in infancy"))
 			} else {
-				para.StartSentence(name.String(), fmt.Sprintf(" died age %s, ", text.CardinalNoun(age)))
+				para.StartSentence(name.String(), loc.Tf("died age %s,", loc.CardinalNoun(age)))
 			}
 			includeAgeAtDeathIfKnown = false
 			name = empty
@@ -486,30 +492,30 @@ func PersonSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder
 		}
 
 		if p.BestBirthlikeEvent != nil && p.BestDeathlikeEvent != nil && p.BestBirthlikeEvent.GetPlace().SameAs(p.BestDeathlikeEvent.GetPlace()) {
-			para.StartSentence(YoungPersonOnePlaceSummary(p, enc, nc, name, includeBirth, includeParentage, activeTense, linkname, minimal).String())
+			para.StartSentence(YoungPersonOnePlaceSummary(p, enc, nc, name, includeBirth, includeParentage, activeTense, linkname, minimal, loc).String())
 			return enc.EncodeText(para.Text())
 		}
 	}
 
 	if includeBirth {
-		birth := PersonBirthSummary(p, enc, nc, name, true, true, includeParentage, activeTense)
+		birth := PersonBirthSummary(p, enc, nc, name, true, true, includeParentage, activeTense, loc)
 		if !birth.IsZero() {
 			para.StartSentence(birth.String())
 			if activeTense {
 				name = empty
 			} else {
-				name = enc.EncodeText(p.Gender.SubjectPronoun())
+				name = enc.EncodeText(loc.SubjectPronoun(p.Gender))
 			}
 		}
 	}
 
-	marrs := PersonMarriageSummary(p, enc, nc, name, false, activeTense)
+	marrs := PersonMarriageSummary(p, enc, nc, name, false, activeTense, loc)
 	if !marrs.IsZero() {
 		para.StartSentence(marrs.String())
 		if activeTense {
 			name = empty
 		} else {
-			name = enc.EncodeText(p.Gender.SubjectPronoun())
+			name = enc.EncodeText(loc.SubjectPronoun(p.Gender))
 		}
 	}
 
@@ -522,19 +528,19 @@ func PersonSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder
 				continue
 			}
 
-			when, ok := ev.GetDate().WhenYear()
+			yr, ok := ev.GetDate().AsYear()
 			if !ok {
 				continue
 			}
-			immPhrases = append(immPhrases, enc.EncodeWithCitations(enc.EncodeText(fmt.Sprintf("%s %s", tev.GetPlace().Name, when)), tev.GetCitations()).String())
+			immPhrases = append(immPhrases, enc.EncodeWithCitations(enc.EncodeText(text.JoinSentenceParts(tev.GetPlace().Name, loc.When(yr))), tev.GetCitations()).String())
 		}
 	}
 
 	if len(immPhrases) > 0 {
-		para.StartSentence("emigrated to", text.JoinList(immPhrases))
+		para.StartSentence(loc.T("emigrated to"), loc.JoinList(immPhrases))
 	}
 
-	death := PersonDeathSummary(p, enc, nc, name, false, activeTense, minimal, includeAgeAtDeathIfKnown)
+	death := PersonDeathSummary(p, enc, nc, name, false, activeTense, minimal, includeAgeAtDeathIfKnown, loc)
 	if !death.IsZero() {
 		para.StartSentence(death.String())
 	}
@@ -545,29 +551,29 @@ func PersonSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder
 
 	finalDetail := ""
 	if p.Unmarried {
-		finalDetail = "never married"
+		finalDetail = loc.T("never married")
 		if p.Childless {
-			finalDetail += " and had no children"
+			finalDetail = loc.T("never married and had no children")
 		}
 	} else {
 		if p.Childless {
-			finalDetail += "had no children"
+			finalDetail = loc.T("had no children")
 		}
 	}
 
 	if finalDetail != "" {
-		para.StartSentence(p.Gender.SubjectPronoun(), finalDetail)
+		para.StartSentence(loc.SubjectPronoun(p.Gender), finalDetail)
 	}
 
 	if para.IsEmpty() {
 		para.StartSentence(name.String())
-		para.StartSentence("nothing else is known about", p.Gender.PossessivePronounSingular(), "life")
+		para.StartSentence(loc.Tf("nothing else is known about %s", loc.PossessivePronoun(p.Gender, "life")))
 	}
 
 	return enc.EncodeText(para.Text())
 }
 
-func YoungPersonOnePlaceSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, includeBirth bool, includeParentage bool, activeTense bool, linkname bool, minimal bool) T {
+func YoungPersonOnePlaceSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, includeBirth bool, includeParentage bool, activeTense bool, linkname bool, minimal bool, loc locale.Locale) T {
 	var para text.Para
 	para.StartSentence(name.String())
 
@@ -580,23 +586,28 @@ func YoungPersonOnePlaceSummary[T render.EncodedText](p *model.Person, enc rende
 
 	var birthWhat string
 	if activeTense {
-		birthWhat = model.What(p.BestBirthlikeEvent)
+		birthWhat = loc.What(p.BestBirthlikeEvent)
 	} else {
-		birthWhat = model.PassiveWhat(p.BestBirthlikeEvent)
+		birthWhat = loc.PassiveWhat(p.BestBirthlikeEvent)
 	}
-	para.Continue(enc.EncodeWithCitations(enc.EncodeText(WhatWhenWhere(birthWhat, p.BestBirthlikeEvent.GetDate(), p.BestBirthlikeEvent.GetPlace(), enc, nc)), p.BestBirthlikeEvent.GetCitations()).String())
+	para.Continue(enc.EncodeWithCitations(enc.EncodeText(WhatWhenWhere(birthWhat, p.BestBirthlikeEvent.GetDate(), p.BestBirthlikeEvent.GetPlace(), enc, nc, loc)), p.BestBirthlikeEvent.GetCitations()).String())
 
 	var deathWhat string
-	deathWhat = model.What(p.BestDeathlikeEvent)
+	deathWhat = loc.What(p.BestDeathlikeEvent)
 
+	var deathWhere string
 	if death != nil {
-		deathWhat = DeathWhat(death, p.ModeOfDeath)
+		deathWhat = DeathWhat(death, p.ModeOfDeath, loc)
 
 		if !p.BestBirthlikeEvent.GetPlace().IsUnknown() {
-			deathWhat += " there"
+			deathWhere = loc.T("there")
 		}
 	}
-	para.Continue("and", enc.EncodeWithCitations(enc.EncodeText(WhatWhen(deathWhat, p.BestDeathlikeEvent.GetDate(), enc)), p.BestDeathlikeEvent.GetCitations()).String())
+	var deathWhen string
+	if !p.BestDeathlikeEvent.GetDate().IsUnknown() {
+		deathWhen = NamedWhen(p.BestDeathlikeEvent.GetDate(), loc)
+	}
+	para.Continue(loc.T("and"), enc.EncodeWithCitations(enc.EncodeText(loc.Predicate(deathWhat, deathWhere, deathWhen)), p.BestDeathlikeEvent.GetCitations()).String())
 
 	if len(p.Associations) > 0 {
 		for _, as := range p.Associations {
@@ -604,14 +615,14 @@ func YoungPersonOnePlaceSummary[T render.EncodedText](p *model.Person, enc rende
 				continue
 			}
 			twinLink := enc.EncodeModelLink(enc.EncodeText(as.Other.PreferredFamiliarName), as.Other)
-			para.StartSentence(p.Gender.SubjectPronoun(), "was the twin to", enc.EncodeWithCitations(twinLink, as.Citations).String())
+			para.StartSentence(loc.SubjectPronoun(p.Gender), loc.T("was the twin to"), enc.EncodeWithCitations(twinLink, as.Citations).String())
 		}
 	}
 
 	return enc.EncodeText(para.Text())
 }
 
-func PersonBirthSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, allowInferred bool, includeBirthDate bool, includeParentage bool, activeTense bool) T {
+func PersonBirthSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, allowInferred bool, includeBirthDate bool, includeParentage bool, activeTense bool, loc locale.Locale) T {
 	var empty T
 	var birth *model.BirthEvent
 	var bev model.IndividualTimelineEvent
@@ -643,7 +654,7 @@ func PersonBirthSummary[T render.EncodedText](p *model.Person, enc render.TextEn
 
 	tense := func(st string) T {
 		if activeTense {
-			return enc.EncodeText(text.StripWasIs(st))
+			return enc.EncodeText(loc.ActiveTense(st))
 		}
 		return enc.EncodeText(st)
 	}
@@ -659,17 +670,17 @@ func PersonBirthSummary[T render.EncodedText](p *model.Person, enc render.TextEn
 		if birth != nil {
 			if _, ok := bev.(*model.BaptismEvent); ok {
 				if yrs, ok := birth.GetDate().WholeYearsUntil(bev.GetDate()); ok && yrs > 1 {
-					para.Continue(tense("was born").String(), birth.GetDate().When(), "and")
+					para.Continue(tense(loc.Predicate(loc.PassiveWhat(birth), loc.When(birth.GetDate()))).String(), loc.T("and"))
 				}
 			}
 		}
-		para.Continue(enc.EncodeWithCitations(tense(EventWhatWhenWhere(bev, enc, nc)), bev.GetCitations()).String())
+		para.Continue(enc.EncodeWithCitations(tense(EventWhatWhenWhere(bev, enc, nc, loc)), bev.GetCitations()).String())
 	} else {
-		para.Continue(enc.EncodeWithCitations(tense(EventWhatWhere(bev, enc, nc)), bev.GetCitations()).String())
+		para.Continue(enc.EncodeWithCitations(tense(EventWhatWhere(bev, enc, nc, loc)), bev.GetCitations()).String())
 	}
 
 	if includeParentage {
-		para.AppendClause(PersonParentage(p, enc))
+		para.AppendClause(PersonParentage(p, enc, loc))
 	}
 
 	if len(p.Associations) > 0 {
@@ -678,7 +689,7 @@ func PersonBirthSummary[T render.EncodedText](p *model.Person, enc render.TextEn
 				continue
 			}
 			twinLink := enc.EncodeModelLink(enc.EncodeText(as.Other.PreferredFamiliarName), as.Other)
-			para.Continue(text.UpperFirst(p.Gender.SubjectPronoun()), "was the twin to", enc.EncodeWithCitations(twinLink, as.Citations).String())
+			para.Continue(text.UpperFirst(loc.SubjectPronoun(p.Gender)), loc.T("was the twin to"), enc.EncodeWithCitations(twinLink, as.Citations).String())
 		}
 	}
 
@@ -694,7 +705,7 @@ type DeathSummarizer struct {
 	AgeInYearsAtDeath int
 }
 
-func PersonDeathSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, allowInferred bool, activeTense bool, minimal bool, includeAge bool) T {
+func PersonDeathSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, allowInferred bool, activeTense bool, minimal bool, includeAge bool, loc locale.Locale) T {
 	var empty T
 	// var death *model.DeathEvent
 	var bev model.IndividualTimelineEvent
@@ -738,7 +749,7 @@ func PersonDeathSummary[T render.EncodedText](p *model.Person, enc render.TextEn
 
 	tense := func(st string) T {
 		if activeTense {
-			return enc.EncodeText(text.StripWasIs(st))
+			return enc.EncodeText(loc.ActiveTense(st))
 		}
 		return enc.EncodeText(st)
 	}
@@ -746,37 +757,37 @@ func PersonDeathSummary[T render.EncodedText](p *model.Person, enc render.TextEn
 	var para text.Para
 	para.StartSentence(name.String())
 	// deathWhat := model.PassiveWhat(bev)
-	deathWhat := DeathWhat(bev, p.ModeOfDeath)
+	deathWhat := DeathWhat(bev, p.ModeOfDeath, loc)
 
-	para.Continue(enc.EncodeWithCitations(tense(WhatWhenWhere(deathWhat, bev.GetDate(), bev.GetPlace(), enc, nc)), bev.GetCitations()).String())
+	para.Continue(enc.EncodeWithCitations(tense(WhatWhenWhere(deathWhat, bev.GetDate(), bev.GetPlace(), enc, nc, loc)), bev.GetCitations()).String())
 
 	if includeAge && !usingBurial {
 		if age, ok := p.AgeInYearsAt(bev.GetDate()); ok {
 			if age < 1 {
 				page, ok := p.PreciseAgeAt(bev.GetDate())
 				if !ok {
-					para.Continue("in infancy")
+					para.Continue(loc.T("in infancy"))
 				} else {
-					para.Continue("aged", page.Rough())
+					para.Continue(loc.T("aged"), page.Rough())
 				}
 			} else {
-				para.Continue(fmt.Sprintf("at the age of %s", text.CardinalNoun(age)))
+				para.Continue(loc.Tf("at the age of %s", loc.CardinalNoun(age)))
 			}
 		}
 	}
 
 	if p.CauseOfDeath != nil && !minimal {
-		para.StartSentence(p.Gender.PossessivePronounSingular(), "death was attributed to", enc.EncodeWithCitations(enc.EncodeText(p.CauseOfDeath.Detail), p.CauseOfDeath.Citations).String())
+		para.StartSentence(loc.PossessivePronoun(p.Gender, "death"), loc.T("was attributed to"), enc.EncodeWithCitations(enc.EncodeText(p.CauseOfDeath.Detail), p.CauseOfDeath.Citations).String())
 	}
 
 	return enc.EncodeText(para.Text())
 }
 
-func PersonMarriageSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, allowInferred bool, activeTense bool) T {
+func PersonMarriageSummary[T render.EncodedText](p *model.Person, enc render.TextEncoder[T], nc NameChooser, name T, allowInferred bool, activeTense bool, loc locale.Locale) T {
 	var empty T
 	tense := func(st string) T {
 		if activeTense {
-			return enc.EncodeText(text.StripWasIs(st))
+			return enc.EncodeText(loc.ActiveTense(st))
 		}
 		return enc.EncodeText(st)
 	}
@@ -804,11 +815,11 @@ func PersonMarriageSummary[T render.EncodedText](p *model.Person, enc render.Tex
 		f := fams[0]
 		other := f.OtherParent(p)
 		if f.BestStartEvent == nil {
-			what := "married " + enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other).String()
+			what := loc.T("married") + " " + enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other).String()
 			marrs = append(marrs, what)
 		} else {
-			what := f.BestStartEvent.What() + " " + enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other).String()
-			marrs = append(marrs, enc.EncodeWithCitations(tense(WhatWhenWhere(what, f.BestStartEvent.GetDate(), f.BestStartEvent.GetPlace(), enc, nc)), f.BestStartEvent.GetCitations()).String())
+			what := loc.What(f.BestStartEvent) + " " + enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other).String()
+			marrs = append(marrs, enc.EncodeWithCitations(tense(WhatWhenWhere(what, f.BestStartEvent.GetDate(), f.BestStartEvent.GetPlace(), enc, nc, loc)), f.BestStartEvent.GetCitations()).String())
 		}
 	} else {
 		for i, f := range fams {
@@ -816,7 +827,7 @@ func PersonMarriageSummary[T render.EncodedText](p *model.Person, enc render.Tex
 			if f.BestStartEvent == nil {
 				what := ""
 				if i == 0 {
-					what = "married "
+					what = loc.T("married") + " "
 				}
 				what += enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other).String()
 				marrs = append(marrs, what)
@@ -824,11 +835,11 @@ func PersonMarriageSummary[T render.EncodedText](p *model.Person, enc render.Tex
 				y, _ := f.BestStartEvent.GetDate().AsYear()
 
 				if i == 0 {
-					what := f.BestStartEvent.What() + " " + enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other).String()
-					marrs = append(marrs, enc.EncodeWithCitations(tense(WhatWhenWhere(what, y, f.BestStartEvent.GetPlace(), enc, nc)), f.BestStartEvent.GetCitations()).String())
+					what := loc.What(f.BestStartEvent) + " " + enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other).String()
+					marrs = append(marrs, enc.EncodeWithCitations(tense(WhatWhenWhere(what, y, f.BestStartEvent.GetPlace(), enc, nc, loc)), f.BestStartEvent.GetCitations()).String())
 				} else {
 					what := enc.EncodeModelLink(enc.EncodeText(other.PreferredFamiliarFullName), other)
-					marrs = append(marrs, enc.EncodeWithCitations(tense(WhatWhenWhere(what.String(), y, f.BestStartEvent.GetPlace(), enc, nc)), f.BestStartEvent.GetCitations()).String())
+					marrs = append(marrs, enc.EncodeWithCitations(tense(WhatWhenWhere(what.String(), y, f.BestStartEvent.GetPlace(), enc, nc, loc)), f.BestStartEvent.GetCitations()).String())
 				}
 			}
 		}
@@ -836,7 +847,7 @@ func PersonMarriageSummary[T render.EncodedText](p *model.Person, enc render.Tex
 
 	var para text.Para
 	para.StartSentence(name.String())
-	para.Continue(text.JoinList(marrs))
+	para.Continue(loc.JoinList(marrs))
 	return enc.EncodeText(para.Text())
 }
 
@@ -1519,13 +1530,13 @@ func GenerateOlb(p *model.Person) string {
 	return olb
 }
 
-func NamedWhen(dt *model.Date) string {
-	if p, ok := dt.Date.(*gdate.Precise); ok {
+func NamedWhen(dt *model.Date, loc locale.Locale) string {
+	if _, ok := dt.Date.(*gdate.Precise); ok {
 		name := fact.LookupNamedDay(dt)
 		if name != fact.NamedDayNone {
-			return fmt.Sprintf("on %s, %s", name.String(), p.String())
+			return loc.WhenNamedDay(name.String(), dt)
 		}
 	}
 
-	return dt.When()
+	return loc.When(dt)
 }
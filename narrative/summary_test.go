@@ -1 +1,31 @@
 package narrative
+
+import (
+	"testing"
+
+	"github.com/iand/genster/locale"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/render/md"
+)
+
+func TestGermanWhatUnknownPlaceAndDate(t *testing.T) {
+	loc := locale.German{}
+	enc := &md.Document{}
+	for _, tt := range []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "what where", got: WhatWhere(loc.T("was baptised"), model.UnknownPlace(), enc, nil, loc), want: "wurde getauft"},
+		{name: "what where pov", got: WhatWherePov(loc.T("buried"), model.UnknownPlace(), enc, nil, &model.POV{}, loc), want: "begraben"},
+		{name: "what when", got: WhatWhen(loc.T("divorced"), model.UnknownDate(), enc, loc), want: "wurden geschieden"},
+		{name: "what when where", got: WhatWhenWhere(loc.T("was baptised"), model.UnknownDate(), model.UnknownPlace(), enc, nil, loc), want: "wurde getauft"},
+		{name: "census", got: WhatWherePov(loc.Tf("%s %s recorded in the %d census", "er", "war", 1881), nil, enc, nil, &model.POV{}, loc), want: "er war in der Volkszählung von 1881 verzeichnet"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, wanted %q", tt.got, tt.want)
+			}
+		})
+	}
+}
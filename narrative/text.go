@@ -1,7 +1,6 @@
 package narrative
 
 import (
-	"fmt"
 	"sort"
 	"strings"
 
@@ -53,18 +52,22 @@ func EncodeText[T render.EncodedText](t model.Text, enc render.TextEncoder[T]) s
 	return formatted.String()
 }
 
-func MediaObjectsAsFigures[T render.EncodedText](mos []*model.CitedMediaObject, enc render.ContentBuilder[T], cropMediaHighlights bool) error {
+// MediaObjectsAsFigures writes a figure for each of mos using the derivatives
+// written for publishing. When cropMediaHighlights is true the figure shows
+// only the highlighted region of the image, otherwise the highlight is shaded
+// on the whole image.
+func MediaObjectsAsFigures[T render.EncodedText](mos []*model.CitedMediaObject, enc render.ContentBuilder[T], cropMediaHighlights bool) {
 	for _, mo := range mos {
-		mediaPath := mo.Object.SrcFilePath
-		if cropMediaHighlights {
-			var err error
-			mediaPath, err = cropMedia(mo.Object, mo.Highlight)
-			if err != nil {
-				return fmt.Errorf("crop media: %w", err)
-			}
+		images := mo.Object.Derivatives
+		highlight := mo.Highlight
+		if cropMediaHighlights && len(mo.Derivatives) > 0 {
+			images = mo.Derivatives
+			highlight = nil
 		}
-		enc.Figure(mediaPath, mo.Object.Title, enc.EncodeText(mo.Object.Title), mo.Highlight, "")
+		link := mo.Object.SrcFilePath
+		if len(images) > 0 {
+			link = images[len(images)-1].Link
+		}
+		enc.ResponsiveFigure(link, images, mo.Object.Title, enc.EncodeText(mo.Object.Title), highlight, "")
 	}
-
-	return nil
 }
@@ -0,0 +1,326 @@
+// Package occupation normalises the occupations written in historic records,
+// such as "ag lab" or "coal hewer", to a standard title, a HISCO code and a
+// social class band.
+package occupation
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/iand/genster/model"
+)
+
+// defaultDictionary is the built in list of occupations.
+//
+//go:embed dictionary.csv
+var defaultDictionary string
+
+// An Entry is an occupation in the dictionary.
+type Entry struct {
+	Code    string                // HISCO code, empty if not known
+	Title   string                // normalised title, to be used in a sentence as `a {title}`
+	Class   model.SocialClass     // social class band
+	Group   model.OccupationGroup // general class of the occupation, unknown if not given
+	Aliases []string              // other wordings found in records, such as "ag lab"
+	From    int                   // first year the wording had this meaning, 0 if always
+	To      int                   // last year the wording had this meaning, 0 if still
+}
+
+// UsedIn reports whether the entry applies to a record made in the year yr.
+// An entry limited to a period of years does not apply when the year is not
+// known, which is given as 0.
+func (e *Entry) UsedIn(yr int) bool {
+	if yr == 0 {
+		return e.From == 0 && e.To == 0
+	}
+	if e.From != 0 && yr < e.From {
+		return false
+	}
+	if e.To != 0 && yr > e.To {
+		return false
+	}
+	return true
+}
+
+// A Dictionary maps the wording of occupations found in records to entries.
+type Dictionary struct {
+	entries []*Entry
+	byName  map[string][]*Entry // latest added first
+}
+
+// dictionaryColumns are the columns of a dictionary file. Only title is
+// required.
+var dictionaryColumns = []string{"code", "title", "class", "group", "aliases", "from", "to"}
+
+var dictionaryClasses = map[string]model.SocialClass{
+	"i":   model.SocialClassProfessional,
+	"ii":  model.SocialClassIntermediate,
+	"iii": model.SocialClassSkilled,
+	"iv":  model.SocialClassPartlySkilled,
+	"v":   model.SocialClassUnskilled,
+}
+
+var dictionaryGroups = map[string]model.OccupationGroup{
+	string(model.OccupationGroupLabouring):  model.OccupationGroupLabouring,
+	string(model.OccupationGroupIndustrial): model.OccupationGroupIndustrial,
+	string(model.OccupationGroupMaritime):   model.OccupationGroupMaritime,
+	string(model.OccupationGroupCrafts):     model.OccupationGroupCrafts,
+	string(model.OccupationGroupClerical):   model.OccupationGroupClerical,
+	string(model.OccupationGroupCommercial): model.OccupationGroupCommercial,
+	string(model.OccupationGroupMilitary):   model.OccupationGroupMilitary,
+	string(model.OccupationGroupPolice):     model.OccupationGroupPolice,
+	string(model.OccupationGroupService):    model.OccupationGroupService,
+}
+
+// Default returns the built in dictionary of common British occupations of
+// the eighteenth and nineteenth centuries.
+func Default() *Dictionary {
+	d, err := ReadDictionary(strings.NewReader(defaultDictionary), ',')
+	if err != nil {
+		panic(fmt.Sprintf("read default occupation dictionary: %v", err))
+	}
+	return d
+}
+
+// LoadDictionary reads a dictionary from a CSV file, or a tab separated file
+// if the name ends in .tsv. The first row names the columns: code, title,
+// class, group and aliases. The class is a roman numeral from I to V, the
+// group is one of the occupation groups, such as crafts or maritime, and
+// aliases are separated by semicolons. The optional from and to columns give
+// the first and last years in which the wordings had the meaning of the entry.
+func LoadDictionary(filename string) (*Dictionary, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open occupation dictionary: %w", err)
+	}
+	defer f.Close()
+
+	comma := ','
+	if strings.EqualFold(filepath.Ext(filename), ".tsv") {
+		comma = '\t'
+	}
+	d, err := ReadDictionary(f, comma)
+	if err != nil {
+		return nil, fmt.Errorf("read occupation dictionary %s: %w", filename, err)
+	}
+	return d, nil
+}
+
+// ReadDictionary reads a dictionary from r whose fields are separated by
+// comma. See LoadDictionary for the format.
+func ReadDictionary(r io.Reader, comma rune) (*Dictionary, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := make(map[string]int)
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		known := false
+		for _, c := range dictionaryColumns {
+			if h == c {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown column %q", h)
+		}
+		cols[h] = i
+	}
+	if _, ok := cols["title"]; !ok {
+		return nil, fmt.Errorf("missing title column")
+	}
+
+	d := &Dictionary{byName: make(map[string][]*Entry)}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		e := &Entry{
+			Code:  field("code"),
+			Title: strings.ToLower(field("title")),
+		}
+		if e.Title == "" {
+			return nil, fmt.Errorf("line %d: missing title", line)
+		}
+		if s := field("class"); s != "" {
+			c, ok := dictionaryClasses[strings.ToLower(s)]
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown class %q", line, s)
+			}
+			e.Class = c
+		}
+		if s := field("group"); s != "" {
+			g, ok := dictionaryGroups[strings.ToLower(s)]
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown group %q", line, s)
+			}
+			e.Group = g
+		}
+		if e.From, err = optionalYear(field("from")); err != nil {
+			return nil, fmt.Errorf("line %d: from: %w", line, err)
+		}
+		if e.To, err = optionalYear(field("to")); err != nil {
+			return nil, fmt.Errorf("line %d: to: %w", line, err)
+		}
+		if e.From != 0 && e.To != 0 && e.To < e.From {
+			return nil, fmt.Errorf("line %d: to is before from", line)
+		}
+		for _, v := range strings.Split(field("aliases"), ";") {
+			if v = strings.TrimSpace(v); v != "" {
+				e.Aliases = append(e.Aliases, v)
+			}
+		}
+		d.Add(e)
+	}
+
+	return d, nil
+}
+
+// Add adds e to the dictionary. Its title and aliases replace any earlier
+// entry with the same wording in the years that e applies to.
+func (d *Dictionary) Add(e *Entry) {
+	if d.byName == nil {
+		d.byName = make(map[string][]*Entry)
+	}
+	d.entries = append(d.entries, e)
+	for _, n := range append([]string{e.Title}, e.Aliases...) {
+		key := Key(n)
+		if key == "" {
+			continue
+		}
+		d.byName[key] = append([]*Entry{e}, d.byName[key]...)
+	}
+}
+
+// Entries returns the entries of the dictionary in the order they were added.
+func (d *Dictionary) Entries() []*Entry {
+	if d == nil {
+		return nil
+	}
+	return d.entries
+}
+
+// headSeparator separates the main occupation in a record from the ones that
+// follow or describe it, as in "baker and grocer", "coal miner (hewer)" or
+// "farmer of 120 acres".
+var headSeparator = regexp.MustCompile(`\s+and\s+|\s+of\s+|&|[(),;/]`)
+
+// qualifierWords are words that show an occupation is not an occupation at
+// all, as in "private means".
+var qualifierWords = map[string]bool{"means": true, "duties": true}
+
+// relationWords are the relations that follow a possessive, as in
+// "labourer's wife", or precede "of", as in "wife of labourer".
+var relationWords = map[string]bool{
+	"son": true, "sons": true, "daughter": true, "daughters": true,
+	"wife": true, "widow": true, "husband": true, "child": true,
+}
+
+// Lookup finds the entry for an occupation as worded in a record. If the
+// whole wording is not in the dictionary then the wording before any "and",
+// comma or bracket is tried, since the main occupation is usually written
+// first, so "coal miner (hewer)" matches "coal miner", "baker and grocer"
+// matches "baker" and "farmer of 120 acres" matches "farmer". Wording that describes a relative's occupation or a means
+// of support, such as "farmer's son", "wife of labourer" or "private means",
+// is not matched unless the dictionary holds it exactly. The year the
+// occupation was recorded, or 0 if it is not known, chooses between entries
+// for wordings whose meaning changed, such as "constable".
+func (d *Dictionary) Lookup(s string, yr int) (*Entry, bool) {
+	if d == nil {
+		return nil, false
+	}
+	key := Key(s)
+	if key == "" {
+		return nil, false
+	}
+	if e, ok := d.find(key, yr); ok {
+		return e, true
+	}
+	if isQualified(key) {
+		return nil, false
+	}
+
+	head := Key(headSeparator.Split(strings.ToLower(s), 2)[0])
+	if head == "" || head == key {
+		return nil, false
+	}
+	return d.find(head, yr)
+}
+
+// find returns the latest added entry for key that applies in the year yr.
+func (d *Dictionary) find(key string, yr int) (*Entry, bool) {
+	for _, e := range d.byName[key] {
+		if e.UsedIn(yr) {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// isQualified reports whether key contains words that qualify an occupation
+// so that it does not describe the person's own work.
+func isQualified(key string) bool {
+	words := strings.Fields(key)
+	for i, w := range words {
+		if qualifierWords[w] {
+			return true
+		}
+		if w == "s" && i > 0 && i+1 < len(words) && relationWords[words[i+1]] {
+			return true
+		}
+		if w == "of" && i > 0 && relationWords[words[i-1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the form of an occupation used to look it up in a dictionary:
+// lower case words without punctuation, so that "Ag. Lab." and "ag lab"
+// are the same.
+func Key(s string) string {
+	s = strings.ReplaceAll(strings.ToLower(s), "&", " and ")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func optionalYear(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
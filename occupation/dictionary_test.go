@@ -0,0 +1,142 @@
+package occupation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iand/genster/model"
+)
+
+func TestDefaultLookup(t *testing.T) {
+	d := Default()
+	for _, tt := range []struct {
+		s         string
+		yr        int
+		wantTitle string
+		wantCode  string
+		wantClass model.SocialClass
+		wantGroup model.OccupationGroup
+	}{
+		{s: "ag lab", wantTitle: "agricultural labourer", wantCode: "62105", wantClass: model.SocialClassPartlySkilled, wantGroup: model.OccupationGroupLabouring},
+		{s: "Ag. Lab.", wantTitle: "agricultural labourer", wantCode: "62105", wantClass: model.SocialClassPartlySkilled, wantGroup: model.OccupationGroupLabouring},
+		{s: "coal hewer", wantTitle: "coal miner", wantCode: "71110", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupIndustrial},
+		{s: "dressmaker", wantTitle: "dressmaker", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupCrafts},
+		{s: "Cordwainer", wantTitle: "shoemaker", wantCode: "80110", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupCrafts},
+		{s: "coal miner (hewer)", wantTitle: "coal miner", wantCode: "71110", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupIndustrial},
+		{s: "baker & grocer", wantTitle: "baker", wantCode: "77610", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupCrafts},
+		{s: "farmer of 120 acres", wantTitle: "farmer", wantCode: "61110", wantClass: model.SocialClassIntermediate, wantGroup: model.OccupationGroupLabouring},
+		{s: "Farmer of 120 acres employing 3 labourers", wantTitle: "farmer", wantCode: "61110", wantClass: model.SocialClassIntermediate, wantGroup: model.OccupationGroupLabouring},
+		{s: "miner", wantTitle: "miner", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupIndustrial},
+		{s: "fitter", wantTitle: "fitter", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupIndustrial},
+		{s: "carrier", wantTitle: "carrier", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupLabouring},
+		{s: "clerk in holy orders", wantTitle: "clergyman", wantClass: model.SocialClassProfessional},
+		{s: "physician", wantTitle: "physician", wantClass: model.SocialClassProfessional},
+		{s: "Doctor of Medicine", wantTitle: "physician", wantClass: model.SocialClassProfessional},
+		{s: "surgeon", wantTitle: "surgeon", wantClass: model.SocialClassProfessional},
+		{s: "police constable", wantTitle: "policeman", wantCode: "58220", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupPolice},
+		{s: "constable", yr: 1851, wantTitle: "policeman", wantCode: "58220", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupPolice},
+		{s: "constable", yr: 1790, wantTitle: "parish constable"},
+		{s: "policeman", yr: 1790, wantTitle: "policeman", wantCode: "58220", wantClass: model.SocialClassSkilled, wantGroup: model.OccupationGroupPolice},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			e, ok := d.Lookup(tt.s, tt.yr)
+			if !ok {
+				t.Fatalf("no entry found")
+			}
+			if e.Title != tt.wantTitle {
+				t.Errorf("title: got %q, wanted %q", e.Title, tt.wantTitle)
+			}
+			if e.Code != tt.wantCode {
+				t.Errorf("code: got %q, wanted %q", e.Code, tt.wantCode)
+			}
+			if e.Class != tt.wantClass {
+				t.Errorf("class: got %v, wanted %v", e.Class, tt.wantClass)
+			}
+			if e.Group != tt.wantGroup {
+				t.Errorf("group: got %q, wanted %q", e.Group, tt.wantGroup)
+			}
+		})
+	}
+
+	for _, s := range []string{
+		"", "astronaut", "pauper", "constable",
+		"Private means", "Domestic duties", "Farmer's son", "Labourer's wife",
+		"wife of labourer", "daughter of farmer", "widow of mariner", "retired farmer and grazier's widow",
+	} {
+		if e, ok := d.Lookup(s, 0); ok {
+			t.Errorf("Lookup(%q): got %q, wanted no entry", s, e.Title)
+		}
+	}
+}
+
+func TestReadDictionary(t *testing.T) {
+	const data = `code,title,class,group,aliases
+# a comment
+95410,carpenter,III,crafts,chippy;wright
+,hurdle maker,,,
+`
+	d, err := ReadDictionary(strings.NewReader(data), ',')
+	if err != nil {
+		t.Fatalf("ReadDictionary: %v", err)
+	}
+	if got := len(d.Entries()); got != 2 {
+		t.Fatalf("got %d entries, wanted 2", got)
+	}
+	e, ok := d.Lookup("Wright", 0)
+	if !ok || e.Title != "carpenter" {
+		t.Errorf("Lookup(Wright): got %v, %v, wanted carpenter", e, ok)
+	}
+	e, ok = d.Lookup("hurdle maker", 0)
+	if !ok || e.Class != model.SocialClassUnknown || e.Group != model.OccupationGroupUnknown {
+		t.Errorf("Lookup(hurdle maker): got %+v, wanted entry with unknown class and group", e)
+	}
+}
+
+func TestReadDictionaryErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		data string
+	}{
+		{name: "unknown column", data: "title,trade\nbaker,bread\n"},
+		{name: "missing title column", data: "code,class\n77610,III\n"},
+		{name: "missing title", data: "code,title\n77610,\n"},
+		{name: "unknown class", data: "title,class\nbaker,VI\n"},
+		{name: "unknown group", data: "title,group\nbaker,bakery\n"},
+		{name: "bad year", data: "title,from\nbaker,c1800\n"},
+		{name: "to before from", data: "title,from,to\nbaker,1850,1800\n"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadDictionary(strings.NewReader(tt.data), ','); err == nil {
+				t.Errorf("got no error")
+			}
+		})
+	}
+}
+
+func TestAddReplacesWording(t *testing.T) {
+	d := Default()
+	d.Add(&Entry{Title: "farm bailiff", Class: model.SocialClassIntermediate, Aliases: []string{"farm servant"}})
+	e, ok := d.Lookup("farm servant", 0)
+	if !ok || e.Title != "farm bailiff" {
+		t.Errorf("got %v, wanted farm bailiff", e)
+	}
+}
+
+func TestAddReplacesWordingInYears(t *testing.T) {
+	d := Default()
+	d.Add(&Entry{Title: "watchman", Aliases: []string{"constable"}, From: 1820, To: 1840})
+	for _, tt := range []struct {
+		yr   int
+		want string
+	}{
+		{yr: 1810, want: "parish constable"},
+		{yr: 1825, want: "watchman"},
+		{yr: 1835, want: "watchman"},
+		{yr: 1845, want: "policeman"},
+	} {
+		e, ok := d.Lookup("constable", tt.yr)
+		if !ok || e.Title != tt.want {
+			t.Errorf("Lookup(constable, %d): got %v, wanted %s", tt.yr, e, tt.want)
+		}
+	}
+}
@@ -17,6 +17,14 @@ const (
 	PlaceKindCountry  PlaceKind = "country"
 	PlaceKindUKNation PlaceKind = "uknation"
 	PlaceKindAddress  PlaceKind = "address"
+
+	PlaceKindCounty               PlaceKind = "county"
+	PlaceKindRegistrationDistrict PlaceKind = "registration district"
+	PlaceKindParish               PlaceKind = "parish"
+	PlaceKindCity                 PlaceKind = "city"
+	PlaceKindTown                 PlaceKind = "town"
+	PlaceKindVillage              PlaceKind = "village"
+	PlaceKindHamlet               PlaceKind = "hamlet"
 )
 
 type PlaceName struct {
@@ -28,6 +36,27 @@ type PlaceName struct {
 	Unknown    bool
 	ChildHints []Hint
 	PartOf     []*PlaceName
+
+	From      int     // first year the place existed, zero if not known
+	To        int     // last year the place existed, zero if it still exists or is not known
+	Located   bool    // true if Latitude and Longitude are known
+	Latitude  float64 // latitude of the centre in decimal degrees
+	Longitude float64 // longitude of the centre in decimal degrees
+
+	Districts []DistrictPeriod // registration districts the place belonged to, by period
+}
+
+// A DistrictPeriod records the registration district a place belonged to
+// during a period.
+type DistrictPeriod struct {
+	District *PlaceName
+	From     int // first year the place was in the district, zero if from the start of civil registration
+	To       int // last year the place was in the district, zero if until it was abolished
+}
+
+// Covers reports whether the year yr is within the period.
+func (dp DistrictPeriod) Covers(yr int) bool {
+	return (dp.From == 0 || yr >= dp.From) && (dp.To == 0 || yr <= dp.To)
 }
 
 func (c *PlaceName) IsUnknown() bool {
@@ -66,6 +95,60 @@ func (pn *PlaceName) FindContainerKind(kind PlaceKind) (*PlaceName, bool) {
 	return nil, false
 }
 
+// ExistedIn reports whether the place existed in the year yr.
+func (pn *PlaceName) ExistedIn(yr int) bool {
+	if pn.From != 0 && yr < pn.From {
+		return false
+	}
+	if pn.To != 0 && yr > pn.To {
+		return false
+	}
+	return true
+}
+
+// RegistrationDistrict returns the registration district that pn belonged to
+// in the year yr. Districts listed for the place are preferred, then
+// registration districts it is part of, then the district of the places that
+// contain it.
+func (pn *PlaceName) RegistrationDistrict(yr int) (*PlaceName, bool) {
+	if pn.Kind == PlaceKindRegistrationDistrict {
+		return pn, pn.ExistedIn(yr)
+	}
+	for _, dp := range pn.Districts {
+		if dp.Covers(yr) && dp.District.ExistedIn(yr) {
+			return dp.District, true
+		}
+	}
+	for _, po := range pn.PartOf {
+		if po.Kind == PlaceKindRegistrationDistrict && po.ExistedIn(yr) {
+			return po, true
+		}
+	}
+	for _, po := range pn.PartOf {
+		if po.Kind == PlaceKindRegistrationDistrict {
+			continue
+		}
+		if rd, ok := po.RegistrationDistrict(yr); ok {
+			return rd, true
+		}
+	}
+	return nil, false
+}
+
+// Within reports whether pn is other or is contained by it, directly or
+// through its containers.
+func (pn *PlaceName) Within(other *PlaceName) bool {
+	if pn == other {
+		return true
+	}
+	for _, po := range pn.PartOf {
+		if po.Within(other) {
+			return true
+		}
+	}
+	return false
+}
+
 func UnknownPlaceName() *PlaceName {
 	return &PlaceName{
 		Name:      "unknown",
@@ -0,0 +1,394 @@
+package place
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A Gazetteer is a list of historic places, such as counties, registration
+// districts and ancient parishes, with the places that contain them, the
+// years they existed and their coordinates. It is used to classify place
+// names that are not in the built in lists of countries and nations.
+type Gazetteer struct {
+	places []*PlaceName
+	byID   map[string]*PlaceName
+	byName map[string][]*PlaceName
+}
+
+// gazetteerColumns are the columns of a gazetteer file. Only id, kind and
+// name are required.
+var gazetteerColumns = []string{"id", "kind", "name", "aliases", "parents", "from", "to", "latitude", "longitude", "districts"}
+
+// gazetteerKinds are the kinds of place that may appear in a gazetteer.
+var gazetteerKinds = map[string]PlaceKind{
+	string(PlaceKindCountry):              PlaceKindCountry,
+	"nation":                              PlaceKindUKNation,
+	string(PlaceKindCounty):               PlaceKindCounty,
+	string(PlaceKindRegistrationDistrict): PlaceKindRegistrationDistrict,
+	string(PlaceKindParish):               PlaceKindParish,
+	string(PlaceKindCity):                 PlaceKindCity,
+	string(PlaceKindTown):                 PlaceKindTown,
+	string(PlaceKindVillage):              PlaceKindVillage,
+	string(PlaceKindHamlet):               PlaceKindHamlet,
+}
+
+// LoadGazetteer reads a gazetteer from a CSV file, or a tab separated file if
+// the name ends in .tsv. The first row names the columns: id, kind, name,
+// aliases, parents, from, to, latitude and longitude. Aliases and parents are
+// lists separated by semicolons. A parent is either the id of another place
+// in the gazetteer or the name of a country or nation, such as England.
+//
+// The optional districts column lists the registration districts a place
+// belonged to, separated by semicolons, each as the id of the district
+// followed by the years it applied, such as rd-linton:1837-1851 or
+// rd-chesterton:1852-. A missing year leaves the period open at that end.
+func LoadGazetteer(filename string) (*Gazetteer, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open gazetteer: %w", err)
+	}
+	defer f.Close()
+
+	comma := ','
+	if strings.EqualFold(filepath.Ext(filename), ".tsv") {
+		comma = '\t'
+	}
+	g, err := ReadGazetteer(f, comma)
+	if err != nil {
+		return nil, fmt.Errorf("read gazetteer %s: %w", filename, err)
+	}
+	return g, nil
+}
+
+// ReadGazetteer reads a gazetteer from r whose fields are separated by comma.
+// See LoadGazetteer for the format.
+func ReadGazetteer(r io.Reader, comma rune) (*Gazetteer, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	cols := make(map[string]int)
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		known := false
+		for _, c := range gazetteerColumns {
+			if h == c {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown column %q", h)
+		}
+		cols[h] = i
+	}
+	for _, c := range []string{"id", "kind", "name"} {
+		if _, ok := cols[c]; !ok {
+			return nil, fmt.Errorf("missing %s column", c)
+		}
+	}
+
+	g := &Gazetteer{
+		byID:   make(map[string]*PlaceName),
+		byName: make(map[string][]*PlaceName),
+	}
+	parents := make(map[*PlaceName][]string)
+	districts := make(map[*PlaceName][]string)
+	lines := make(map[*PlaceName]int)
+
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		pn := &PlaceName{
+			ID:      field("id"),
+			Name:    field("name"),
+			Aliases: splitList(field("aliases")),
+		}
+		if pn.ID == "" {
+			return nil, fmt.Errorf("line %d: missing id", line)
+		}
+		if _, exists := g.byID[pn.ID]; exists {
+			return nil, fmt.Errorf("line %d: duplicate id %q", line, pn.ID)
+		}
+		if pn.Name == "" {
+			return nil, fmt.Errorf("line %d: missing name", line)
+		}
+		kind, ok := gazetteerKinds[strings.ToLower(field("kind"))]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown kind %q", line, field("kind"))
+		}
+		pn.Kind = kind
+
+		if pn.From, err = optionalInt(field("from")); err != nil {
+			return nil, fmt.Errorf("line %d: from: %w", line, err)
+		}
+		if pn.To, err = optionalInt(field("to")); err != nil {
+			return nil, fmt.Errorf("line %d: to: %w", line, err)
+		}
+		if pn.From != 0 && pn.To != 0 && pn.To < pn.From {
+			return nil, fmt.Errorf("line %d: to is before from", line)
+		}
+
+		lat, lon := field("latitude"), field("longitude")
+		if lat != "" || lon != "" {
+			if pn.Latitude, err = strconv.ParseFloat(lat, 64); err != nil {
+				return nil, fmt.Errorf("line %d: latitude: %w", line, err)
+			}
+			if pn.Longitude, err = strconv.ParseFloat(lon, 64); err != nil {
+				return nil, fmt.Errorf("line %d: longitude: %w", line, err)
+			}
+			pn.Located = true
+		}
+
+		g.places = append(g.places, pn)
+		g.byID[pn.ID] = pn
+		parents[pn] = splitList(field("parents"))
+		districts[pn] = splitList(field("districts"))
+		lines[pn] = line
+	}
+
+	// parents are resolved once every place has been read so they may be
+	// listed in any order
+	for _, pn := range g.places {
+		for _, ref := range parents[pn] {
+			if po, ok := g.byID[ref]; ok {
+				pn.PartOf = append(pn.PartOf, po)
+				continue
+			}
+			if po, ok := LookupPlaceName(ref); ok {
+				pn.PartOf = append(pn.PartOf, po)
+				continue
+			}
+			return nil, fmt.Errorf("line %d: unknown parent %q", lines[pn], ref)
+		}
+		for _, ref := range districts[pn] {
+			dp, err := g.parseDistrictPeriod(ref)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: districts: %w", lines[pn], err)
+			}
+			pn.Districts = append(pn.Districts, dp)
+		}
+	}
+
+	for _, pn := range g.places {
+		if containsSelf(pn, pn.PartOf, map[*PlaceName]bool{}) {
+			return nil, fmt.Errorf("line %d: %s is contained by itself", lines[pn], pn.ID)
+		}
+		for _, n := range append([]string{pn.Name}, pn.Aliases...) {
+			key := strings.ToLower(Clean(n))
+			g.byName[key] = append(g.byName[key], pn)
+		}
+	}
+
+	return g, nil
+}
+
+// parseDistrictPeriod parses a district period of the form id:from-to where
+// the years are optional.
+func (g *Gazetteer) parseDistrictPeriod(s string) (DistrictPeriod, error) {
+	var dp DistrictPeriod
+	id, years, _ := strings.Cut(s, ":")
+	rd, ok := g.byID[strings.TrimSpace(id)]
+	if !ok {
+		return dp, fmt.Errorf("unknown district %q", id)
+	}
+	if rd.Kind != PlaceKindRegistrationDistrict {
+		return dp, fmt.Errorf("%s is not a registration district", id)
+	}
+	dp.District = rd
+
+	from, to, _ := strings.Cut(years, "-")
+	var err error
+	if dp.From, err = optionalInt(strings.TrimSpace(from)); err != nil {
+		return dp, fmt.Errorf("%s: %w", s, err)
+	}
+	if dp.To, err = optionalInt(strings.TrimSpace(to)); err != nil {
+		return dp, fmt.Errorf("%s: %w", s, err)
+	}
+	if dp.From != 0 && dp.To != 0 && dp.To < dp.From {
+		return dp, fmt.Errorf("%s: end is before start", s)
+	}
+	return dp, nil
+}
+
+// RegistrationDistrictsIn returns the registration districts in the gazetteer
+// that existed in the year yr and whose name or alias appears as whole words
+// in s, such as the detail of a citation of a civil registration index.
+func (g *Gazetteer) RegistrationDistrictsIn(s string, yr int) []*PlaceName {
+	if g == nil {
+		return nil
+	}
+	text := " " + words(s) + " "
+
+	var rds []*PlaceName
+	for _, pn := range g.places {
+		if pn.Kind != PlaceKindRegistrationDistrict || !pn.ExistedIn(yr) {
+			continue
+		}
+		for _, n := range append([]string{pn.Name}, pn.Aliases...) {
+			if strings.Contains(text, " "+words(n)+" ") {
+				rds = append(rds, pn)
+				break
+			}
+		}
+	}
+	return rds
+}
+
+// Len returns the number of places in the gazetteer.
+func (g *Gazetteer) Len() int {
+	if g == nil {
+		return 0
+	}
+	return len(g.places)
+}
+
+// Lookup returns the places in the gazetteer with the name or alias v.
+func (g *Gazetteer) Lookup(v string) []*PlaceName {
+	if g == nil {
+		return nil
+	}
+	return g.byName[strings.ToLower(Clean(v))]
+}
+
+// Resolve finds the most specific part of a comma separated place name that
+// names a place in the gazetteer, using the more general parts that follow it
+// to choose between places of the same name. It returns the place and the
+// more specific parts of the name that precede it, or false if no part of
+// the name is in the gazetteer.
+func (g *Gazetteer) Resolve(name string) (*PlaceName, []string, bool) {
+	if g == nil {
+		return nil, nil, false
+	}
+	parts := splitPlaceName(name)
+
+	var context *PlaceName // the most specific place resolved so far
+	var match *PlaceName   // the most specific gazetteer place resolved so far
+	matchIndex := -1
+	for i := len(parts) - 1; i >= 0; i-- {
+		candidates := g.Lookup(parts[i])
+		if len(candidates) == 0 {
+			if pn, ok := LookupPlaceName(parts[i]); ok {
+				candidates = []*PlaceName{pn}
+			}
+		}
+
+		var found *PlaceName
+		for _, c := range candidates {
+			if context != nil && !c.Within(context) {
+				continue
+			}
+			if found != nil && found != c {
+				// ambiguous without more context
+				found = nil
+				break
+			}
+			found = c
+		}
+		if found == nil {
+			if context != nil {
+				// the chain of places is broken so anything more specific is
+				// not part of the gazetteer
+				break
+			}
+			continue
+		}
+		context = found
+		if found.ID != "" {
+			match = found
+			matchIndex = i
+		}
+	}
+
+	if match == nil {
+		return nil, nil, false
+	}
+	return match, parts[:matchIndex], true
+}
+
+// ClassifyName classifies a place name as ClassifyName does, but also
+// recognises the places in the gazetteer. It may be called on a nil
+// Gazetteer.
+func (g *Gazetteer) ClassifyName(name string, hints ...Hint) *PlaceName {
+	match, rest, ok := g.Resolve(name)
+	if !ok {
+		return ClassifyName(name, hints...)
+	}
+	if len(rest) == 0 {
+		return match
+	}
+	return &PlaceName{
+		Name:   Clean(name),
+		Kind:   PlaceKindUnknown,
+		PartOf: []*PlaceName{match},
+	}
+}
+
+// words returns the lower case words of s separated by single spaces,
+// ignoring punctuation other than hyphens.
+func words(s string) string {
+	s = strings.NewReplacer("'", "", "’", "").Replace(s)
+	return strings.ToLower(strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+	}), " "))
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ";") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func optionalInt(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+func containsSelf(pn *PlaceName, partOf []*PlaceName, seen map[*PlaceName]bool) bool {
+	for _, po := range partOf {
+		if po == pn {
+			return true
+		}
+		if seen[po] {
+			continue
+		}
+		seen[po] = true
+		if containsSelf(pn, po.PartOf, seen) {
+			return true
+		}
+	}
+	return false
+}
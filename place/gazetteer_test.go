@@ -0,0 +1,187 @@
+package place
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testGazetteer = `id,kind,name,aliases,parents,from,to,latitude,longitude
+# counties
+cam,county,Cambridgeshire,Cambs,England,,,52.2,0.1
+sfk,county,Suffolk,,England,,,52.2,1.0
+# registration districts
+rd-chesterton,registration district,Chesterton,,cam,1837,1974,,
+# parishes
+harston,parish,Harston,,cam;rd-chesterton,,,52.14,0.08
+newton-cam,parish,Newton,,cam,,,,
+newton-sfk,parish,Newton,Newton by Sudbury,sfk,,,,
+`
+
+func TestResolve(t *testing.T) {
+	g, err := ReadGazetteer(strings.NewReader(testGazetteer), ',')
+	if err != nil {
+		t.Fatalf("ReadGazetteer: %v", err)
+	}
+
+	testCases := []struct {
+		in     string
+		wantID string
+		rest   string
+	}{
+		{in: "Harston, Cambridgeshire, England", wantID: "harston"},
+		{in: "Harston, Cambs", wantID: "harston"},
+		{in: "Harston", wantID: "harston"},
+		{in: "Harston, Chesterton, Cambridgeshire", wantID: "harston"},
+		{in: "Church Farm, Harston, Cambridgeshire, England", wantID: "harston", rest: "Church Farm"},
+		{in: "Newton, Suffolk, England", wantID: "newton-sfk"},
+		{in: "Newton by Sudbury", wantID: "newton-sfk"},
+		{in: "Newton, Cambridgeshire", wantID: "newton-cam"},
+		{in: "Newton, England", wantID: ""}, // ambiguous
+		{in: "Harston, Suffolk, England", wantID: "sfk", rest: "Harston"},
+		{in: "Paris, France", wantID: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			pn, rest, ok := g.Resolve(tc.in)
+			if tc.wantID == "" {
+				if ok {
+					t.Errorf("got %s, wanted no match", pn.ID)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("got no match, wanted %s", tc.wantID)
+			}
+			if pn.ID != tc.wantID {
+				t.Errorf("got %s, want %s", pn.ID, tc.wantID)
+			}
+			if got := strings.Join(rest, ", "); got != tc.rest {
+				t.Errorf("got rest %q, want %q", got, tc.rest)
+			}
+		})
+	}
+}
+
+func TestGazetteerClassifyName(t *testing.T) {
+	g, err := ReadGazetteer(strings.NewReader(testGazetteer), ',')
+	if err != nil {
+		t.Fatalf("ReadGazetteer: %v", err)
+	}
+
+	pn := g.ClassifyName("Harston, Cambridgeshire, England")
+	if pn.Kind != PlaceKindParish {
+		t.Errorf("got kind %s, want %s", pn.Kind, PlaceKindParish)
+	}
+	if !pn.Located || pn.Latitude != 52.14 {
+		t.Errorf("got location %v,%v, want 52.14,0.08", pn.Latitude, pn.Longitude)
+	}
+	if c, ok := pn.FindContainerKind(PlaceKindUKNation); !ok || c.Name != "England" {
+		t.Errorf("did not find nation England")
+	}
+	if c, ok := pn.FindContainerKind(PlaceKindCountry); !ok || c.Name != "United Kingdom" {
+		t.Errorf("did not find country United Kingdom")
+	}
+
+	rd, _, _ := g.Resolve("Chesterton")
+	if rd.ExistedIn(1830) || !rd.ExistedIn(1900) || rd.ExistedIn(1980) {
+		t.Errorf("Chesterton existed in wrong years, want 1837 to 1974")
+	}
+
+	// names not in the gazetteer are classified as before
+	if pn := g.ClassifyName("Wales"); pn.Kind != PlaceKindUKNation {
+		t.Errorf("got kind %s, want %s", pn.Kind, PlaceKindUKNation)
+	}
+	var nilg *Gazetteer
+	if pn := nilg.ClassifyName("Scotland"); pn.Kind != PlaceKindUKNation {
+		t.Errorf("nil gazetteer: got kind %s, want %s", pn.Kind, PlaceKindUKNation)
+	}
+}
+
+func TestReadGazetteerErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   string
+	}{
+		{name: "missing_name_column", in: "id,kind\na,county\n"},
+		{name: "unknown_column", in: "id,kind,name,size\na,county,A,1\n"},
+		{name: "unknown_kind", in: "id,kind,name\na,duchy,A\n"},
+		{name: "duplicate_id", in: "id,kind,name\na,county,A\na,county,B\n"},
+		{name: "unknown_parent", in: "id,kind,name,parents\na,parish,A,b\n"},
+		{name: "cycle", in: "id,kind,name,parents\na,parish,A,b\nb,county,B,a\n"},
+		{name: "bad_year", in: "id,kind,name,from\na,parish,A,early\n"},
+		{name: "to_before_from", in: "id,kind,name,from,to\na,parish,A,1900,1800\n"},
+		{name: "missing_longitude", in: "id,kind,name,latitude\na,parish,A,52.1\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ReadGazetteer(strings.NewReader(tc.in), ','); err == nil {
+				t.Errorf("got no error")
+			}
+		})
+	}
+}
+
+func TestRegistrationDistrict(t *testing.T) {
+	const gaz = `id,kind,name,parents,from,to,districts
+cam,county,Cambridgeshire,England,,,
+rd-linton,registration district,Linton,cam,1837,1934,
+rd-chesterton,registration district,Chesterton,cam,1837,1974,
+rd-kl,registration district,King's Lynn,England,1837,,
+harston,parish,Harston,cam,,,rd-linton:-1851;rd-chesterton:1852-
+hauxton,parish,Hauxton,cam;rd-chesterton,,,
+newton,hamlet,Newton,harston,,,
+`
+	g, err := ReadGazetteer(strings.NewReader(gaz), ',')
+	if err != nil {
+		t.Fatalf("ReadGazetteer: %v", err)
+	}
+
+	testCases := []struct {
+		place string
+		year  int
+		want  string
+	}{
+		{place: "Harston", year: 1840, want: "rd-linton"},
+		{place: "Harston", year: 1851, want: "rd-linton"},
+		{place: "Harston", year: 1852, want: "rd-chesterton"},
+		{place: "Harston", year: 1980, want: ""},
+		{place: "Hauxton", year: 1900, want: "rd-chesterton"},
+		{place: "Newton", year: 1845, want: "rd-linton"},
+		{place: "Newton", year: 1860, want: "rd-chesterton"},
+		{place: "Cambridgeshire", year: 1860, want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s_%d", tc.place, tc.year), func(t *testing.T) {
+			pn, _, ok := g.Resolve(tc.place)
+			if !ok {
+				t.Fatalf("did not resolve %s", tc.place)
+			}
+			got := ""
+			if rd, ok := pn.RegistrationDistrict(tc.year); ok {
+				got = rd.ID
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	var got []string
+	for _, rd := range g.RegistrationDistrictsIn("Births Jun 1850 HARSTON Linton 14 123; see also Kings Lynn", 1850) {
+		got = append(got, rd.ID)
+	}
+	if want := "rd-linton,rd-kl"; strings.Join(got, ",") != want {
+		t.Errorf("RegistrationDistrictsIn got %v, want %s", got, want)
+	}
+	if rds := g.RegistrationDistrictsIn("Linton 14 123", 1950); len(rds) != 0 {
+		t.Errorf("RegistrationDistrictsIn found abolished district")
+	}
+
+	if _, err := ReadGazetteer(strings.NewReader("id,kind,name,districts\na,parish,A,cam\ncam,county,C,\n"), ','); err == nil {
+		t.Errorf("got no error for a district that is not a registration district")
+	}
+}
@@ -173,6 +173,27 @@ func (e *Content) DefinitionList(markups [][2]Text) {
 	e.maintext.WriteString("</dl>\n")
 }
 
+// Table writes a table with a single header row followed by the supplied rows.
+func (e *Content) Table(headings []Text, rows [][]Text) {
+	e.maintext.WriteString("<table>\n<thead>\n<tr>")
+	for _, h := range headings {
+		e.maintext.WriteString("<th>")
+		h.ToHTML(&e.maintext)
+		e.maintext.WriteString("</th>")
+	}
+	e.maintext.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range rows {
+		e.maintext.WriteString("<tr>")
+		for _, cell := range row {
+			e.maintext.WriteString("<td>")
+			cell.ToHTML(&e.maintext)
+			e.maintext.WriteString("</td>")
+		}
+		e.maintext.WriteString("</tr>\n")
+	}
+	e.maintext.WriteString("</tbody>\n</table>\n")
+}
+
 func (e *Content) ResetSeenLinks() {
 	e.seenLinks = make(map[string]bool)
 }
@@ -385,6 +406,97 @@ func (e *Content) Figure(link string, alt string, caption Text, highlight *model
 	e.maintext.WriteString("</figure>\n")
 }
 
+// ResponsiveFigure writes a figure whose image is chosen by the browser from
+// images, which must be ordered smallest first. The medium size is used as
+// the default source and gives the width and height of the image. The figure
+// links to link, which is usually the largest of images or the page of the
+// media object.
+func (e *Content) ResponsiveFigure(link string, images []model.MediaDerivative, alt string, caption Text, highlight *model.Region, downloadName string) {
+	if len(images) == 0 {
+		e.Figure(link, alt, caption, highlight, downloadName)
+		return
+	}
+	def := images[len(images)-1]
+	for _, im := range images {
+		if im.Size == "medium" {
+			def = im
+		}
+	}
+	img := responsiveImg(images, def, fmt.Sprintf("(max-width: %dpx) 100vw, %dpx", def.Width, def.Width), alt)
+
+	e.maintext.WriteString("<figure>")
+	if highlight == nil {
+		e.maintext.WriteString(fmt.Sprintf("<a href=\"%s\" data-dimbox=\"figures\">%s</a>", html.EscapeString(link), img))
+	} else {
+		e.maintext.WriteString("<div class=\"shade\">")
+		e.maintext.WriteString(fmt.Sprintf("<a href=\"%s\" data-dimbox=\"figures\">", html.EscapeString(link)))
+		e.maintext.WriteString(fmt.Sprintf("<span class=\"shade\" style=\"bottom: %d%%;left: %d%%;width: %d%%;height: %d%%;\"></span>", highlight.Bottom, highlight.Left, highlight.Width, highlight.Height))
+		e.maintext.WriteString(img)
+		e.maintext.WriteString("</a></div>")
+	}
+
+	e.maintext.WriteString("<figcaption>")
+	e.maintext.WriteString("<p>")
+	caption.ToHTML(&e.maintext)
+	if downloadName != "" {
+		e.maintext.WriteString(fmt.Sprintf(" (<a href=\"%s\" download=\"%s\">Download this image</a>)", html.EscapeString(link), html.EscapeString(downloadName)))
+	}
+	e.maintext.WriteString("</p>")
+	e.maintext.WriteString("</figcaption>")
+	e.maintext.WriteString("</figure>\n")
+}
+
+// A GalleryItem is an image shown in a gallery.
+type GalleryItem struct {
+	Link      string                  // link to the image shown in the lightbox, usually the largest size
+	Images    []model.MediaDerivative // sizes of the image, smallest first
+	Alt       string                  // alternative text for the image
+	Title     string                  // caption shown in the lightbox
+	Caption   Text                    // caption shown beneath the thumbnail
+	Highlight *model.Region           // region of the image to shade, nil for none
+}
+
+// Gallery writes a grid of thumbnails that open in the lightbox, which lets
+// the reader step through the images in order.
+func (e *Content) Gallery(items []GalleryItem) {
+	e.maintext.WriteString("<div class=\"gallery\">\n")
+	for _, it := range items {
+		e.maintext.WriteString("<figure>")
+		if it.Highlight != nil {
+			e.maintext.WriteString("<div class=\"shade\">")
+		}
+		e.maintext.WriteString(fmt.Sprintf("<a href=\"%s\" data-dimbox=\"gallery\" data-dimbox-caption=\"%s\">", html.EscapeString(it.Link), html.EscapeString(it.Title)))
+		if it.Highlight != nil {
+			e.maintext.WriteString(fmt.Sprintf("<span class=\"shade\" style=\"bottom: %d%%;left: %d%%;width: %d%%;height: %d%%;\"></span>", it.Highlight.Bottom, it.Highlight.Left, it.Highlight.Width, it.Highlight.Height))
+		}
+		if len(it.Images) > 0 {
+			e.maintext.WriteString(responsiveImg(it.Images, it.Images[0], fmt.Sprintf("%dpx", it.Images[0].Width), it.Alt))
+		} else {
+			e.maintext.WriteString(fmt.Sprintf("<img src=\"%s\" alt=\"%s\" loading=\"lazy\">", html.EscapeString(it.Link), html.EscapeString(it.Alt)))
+		}
+		e.maintext.WriteString("</a>")
+		if it.Highlight != nil {
+			e.maintext.WriteString("</div>")
+		}
+		e.maintext.WriteString("<figcaption>")
+		it.Caption.ToHTML(&e.maintext)
+		e.maintext.WriteString("</figcaption>")
+		e.maintext.WriteString("</figure>\n")
+	}
+	e.maintext.WriteString("</div>\n")
+}
+
+// responsiveImg returns an img element showing def by default and offering
+// each of images in its srcset.
+func responsiveImg(images []model.MediaDerivative, def model.MediaDerivative, sizes string, alt string) string {
+	srcset := make([]string, 0, len(images))
+	for _, im := range images {
+		srcset = append(srcset, fmt.Sprintf("%s %dw", im.Link, im.Width))
+	}
+	return fmt.Sprintf("<img src=\"%s\" srcset=\"%s\" sizes=\"%s\" width=\"%d\" height=\"%d\" alt=\"%s\" loading=\"lazy\">",
+		html.EscapeString(def.Link), html.EscapeString(strings.Join(srcset, ", ")), html.EscapeString(sizes), def.Width, def.Height, html.EscapeString(alt))
+}
+
 func (e *Content) Timeline(rows []render.TimelineRow[Text]) {
 	e.maintext.WriteString("<dl class=\"timeline\">\n")
 	yr := ""
@@ -44,6 +44,7 @@ type ContentBuilder[T EncodedText] interface {
 	BlockQuote(T)
 	Timeline([]TimelineRow[T])
 	Figure(link string, alt string, caption T, highlight *model.Region, downloadName string)
+	ResponsiveFigure(link string, images []model.MediaDerivative, alt string, caption T, highlight *model.Region, downloadName string)
 	FactList([]FactEntry[T])
 }
 
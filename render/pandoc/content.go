@@ -247,3 +247,12 @@ func (w *Content) Figure(link string, alt string, caption Text, highlight *model
 	w.main.WriteString("![" + caption.String() + "](" + link + ")")
 	w.main.WriteString("\n")
 }
+
+// ResponsiveFigure writes a figure using the largest of images since pandoc
+// output has no use for alternate sizes.
+func (w *Content) ResponsiveFigure(link string, images []model.MediaDerivative, alt string, caption Text, highlight *model.Region, downloadName string) {
+	if len(images) > 0 {
+		link = images[len(images)-1].Link
+	}
+	w.Figure(link, alt, caption, highlight, downloadName)
+}
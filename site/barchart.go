@@ -0,0 +1,145 @@
+package site
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+
+	"github.com/iand/genster/chart"
+)
+
+// A barSeries is a named set of values drawn as bars of a single colour in a bar chart.
+type barSeries struct {
+	Name   string
+	Color  string
+	Values []float64 // one value per category
+}
+
+// Dimensions of generated bar charts, in pixels.
+const (
+	barChartWidth       = 720
+	barChartHeight      = 320
+	barChartMarginLeft  = 48
+	barChartMarginRight = 16
+	barChartMarginTop   = 16
+	barChartAxisHeight  = 56 // space below the plot for category labels
+	barChartKeyHeight   = 24 // space below the axis labels for the series key
+	barChartFontSize    = 11
+)
+
+// barChartColors are used for series that do not specify a colour.
+var barChartColors = []string{"#4e79a7", "#e15759", "#76b7b2", "#f28e2b", "#59a14f"}
+
+// barChartSVG renders a grouped bar chart as a standalone SVG document with one group
+// of bars for each category. The chart uses only static SVG so it needs no scripts to
+// be displayed.
+func barChartSVG(categories []string, series []barSeries) string {
+	height := barChartHeight
+	if len(series) > 1 {
+		height += barChartKeyHeight
+	}
+	plotWidth := float64(barChartWidth - barChartMarginLeft - barChartMarginRight)
+	plotHeight := float64(barChartHeight - barChartMarginTop - barChartAxisHeight)
+	plotBottom := float64(barChartMarginTop) + plotHeight
+
+	maxValue := 0.0
+	for _, s := range series {
+		for _, v := range s.Values {
+			maxValue = max(maxValue, v)
+		}
+	}
+	step := niceStep(maxValue / 4)
+	top := step * math.Ceil(maxValue/step)
+	if top == 0 {
+		top = 1
+	}
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "<svg width=\"%d\" height=\"%d\" viewBox=\"0 0 %[1]d %[2]d\" xmlns=\"http://www.w3.org/2000/svg\" font-family=\"sans-serif\" font-size=\"%d\">\n", barChartWidth, height, barChartFontSize)
+
+	// gridlines and value labels
+	for v := 0.0; v <= top+step/2; v += step {
+		y := plotBottom - v/top*plotHeight
+		fmt.Fprintf(buf, "<line x1=\"%d\" y1=\"%s\" x2=\"%d\" y2=\"%[2]s\" stroke=\"#dddddd\"/>\n", barChartMarginLeft, chart.SVGNum(y), barChartWidth-barChartMarginRight)
+		fmt.Fprintf(buf, "<text x=\"%d\" y=\"%s\" text-anchor=\"end\" dominant-baseline=\"middle\">%s</text>\n", barChartMarginLeft-6, chart.SVGNum(y), chart.SVGNum(v))
+	}
+
+	if len(categories) > 0 && len(series) > 0 {
+		groupWidth := plotWidth / float64(len(categories))
+		barWidth := groupWidth * 0.8 / float64(len(series))
+
+		// rotate labels when they would otherwise collide
+		rotate := false
+		for _, c := range categories {
+			if float64(len(c)*barChartFontSize)*0.6 > groupWidth {
+				rotate = true
+				break
+			}
+		}
+
+		for i, c := range categories {
+			gx := float64(barChartMarginLeft) + float64(i)*groupWidth
+			for j, s := range series {
+				if i >= len(s.Values) || s.Values[i] <= 0 {
+					continue
+				}
+				h := s.Values[i] / top * plotHeight
+				x := gx + groupWidth*0.1 + float64(j)*barWidth
+				fmt.Fprintf(buf, "<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"><title>%s: %s</title></rect>\n", chart.SVGNum(x), chart.SVGNum(plotBottom-h), chart.SVGNum(barWidth), chart.SVGNum(h), seriesColor(s, j), html.EscapeString(seriesLabel(s, c)), chart.SVGNum(s.Values[i]))
+			}
+
+			lx := gx + groupWidth/2
+			ly := plotBottom + 14
+			if rotate {
+				fmt.Fprintf(buf, "<text x=\"%s\" y=\"%s\" text-anchor=\"end\" transform=\"rotate(-45 %[1]s %[2]s)\">%s</text>\n", chart.SVGNum(lx), chart.SVGNum(ly), html.EscapeString(c))
+			} else {
+				fmt.Fprintf(buf, "<text x=\"%s\" y=\"%s\" text-anchor=\"middle\">%s</text>\n", chart.SVGNum(lx), chart.SVGNum(ly), html.EscapeString(c))
+			}
+		}
+	}
+
+	fmt.Fprintf(buf, "<line x1=\"%d\" y1=\"%s\" x2=\"%d\" y2=\"%[2]s\" stroke=\"#333333\"/>\n", barChartMarginLeft, chart.SVGNum(plotBottom), barChartWidth-barChartMarginRight)
+
+	if len(series) > 1 {
+		x := float64(barChartMarginLeft)
+		y := float64(barChartHeight + barChartKeyHeight/2)
+		for j, s := range series {
+			fmt.Fprintf(buf, "<rect x=\"%s\" y=\"%s\" width=\"12\" height=\"12\" fill=\"%s\"/>\n", chart.SVGNum(x), chart.SVGNum(y-6), seriesColor(s, j))
+			fmt.Fprintf(buf, "<text x=\"%s\" y=\"%s\" dominant-baseline=\"middle\">%s</text>\n", chart.SVGNum(x+16), chart.SVGNum(y), html.EscapeString(s.Name))
+			x += 16 + float64(len(s.Name)*barChartFontSize)*0.6 + 24
+		}
+	}
+
+	fmt.Fprintln(buf, "</svg>")
+	return buf.String()
+}
+
+func seriesColor(s barSeries, idx int) string {
+	if s.Color != "" {
+		return s.Color
+	}
+	return barChartColors[idx%len(barChartColors)]
+}
+
+func seriesLabel(s barSeries, category string) string {
+	if s.Name == "" {
+		return category
+	}
+	return category + " " + s.Name
+}
+
+// niceStep rounds v up to 1, 2 or 5 times a power of ten so that axis gridlines
+// fall on round numbers.
+func niceStep(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	mag := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if m*mag >= v {
+			return m * mag
+		}
+	}
+	return 10 * mag
+}
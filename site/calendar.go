@@ -71,7 +71,7 @@ func (c *Calendar) RenderPage(s *Site) (render.Document[md.Text], error) {
 		case *model.MarriageEvent:
 			evd.text = doc.EncodeModelLink(doc.EncodeText(tev.Husband.PreferredUniqueName), tev.Husband).String() + " and " + doc.EncodeModelLink(doc.EncodeText(tev.Wife.PreferredUniqueName), tev.Wife).String() + " were married."
 		default:
-			evd.text = narrative.EventWhatWhenWhere(tev, doc, narrative.DefaultNameChooser{})
+			evd.text = narrative.EventWhatWhenWhere(tev, doc, narrative.DefaultNameChooser{}, s.Locale)
 		}
 
 		if tev, ok := ev.(model.IndividualTimelineEvent); ok {
@@ -3,6 +3,7 @@ package site
 import (
 	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/narrative"
@@ -49,11 +50,29 @@ func RenderCitationPage(s *Site, c *model.GeneralCitation) (render.Document[md.T
 		doc.Para(doc.EncodeText("Cited from " + sourceDesc))
 	}
 
+	// source pages are not published so the diary entries, stories and
+	// questions that refer to the source are listed with each of its citations
+	var links []model.Link
+	if c.Source != nil && c.Source.Title != "" {
+		for _, l := range writeContentLinks(doc, c.Source.Title, c.Source.Links) {
+			if l.Category == model.LinkCategoryDiary {
+				doc.AddLink(l.Title, l.URL, l.Category)
+				continue
+			}
+			links = append(links, l)
+		}
+	}
+
 	for _, cmo := range c.MediaObjects {
 		link := s.LinkFor(cmo.Object)
 		if link != "" {
 			doc.EmptyPara()
-			doc.Figure(link, cmo.Object.ID, doc.EncodeText(cmo.Object.Title), cmo.Highlight, filepath.Base(cmo.Object.SrcFilePath))
+			doc.ResponsiveFigure(link, cmo.Object.Derivatives, cmo.Object.ID, doc.EncodeText(cmo.Object.Title), cmo.Highlight, filepath.Base(cmo.Object.SrcFilePath))
+		}
+	}
+	if c.Source != nil {
+		if link := s.GalleryLinkFor(c.Source); link != "" {
+			doc.Para(doc.EncodeLink(doc.EncodeText("See all images from this source"), link))
 		}
 	}
 
@@ -87,6 +106,13 @@ func RenderCitationPage(s *Site, c *model.GeneralCitation) (render.Document[md.T
 		}
 	}
 
+	if len(links) > 0 {
+		doc.Heading3("Links", "")
+		for _, l := range links {
+			doc.Para(doc.EncodeLink(doc.EncodeText(l.Title), l.URL))
+		}
+	}
+
 	peopleInCitations := make(map[*model.Person]bool)
 
 	var cites string
@@ -97,7 +123,13 @@ func RenderCitationPage(s *Site, c *model.GeneralCitation) (render.Document[md.T
 			continue
 		}
 
-		events = append(events, md.Text(narrative.WhoWhatWhenWhere(ev, doc, narrative.FullNameChooser{})))
+		line := narrative.WhoWhatWhenWhere(ev, doc, narrative.FullNameChooser{}, s.Locale)
+		// occupations are described by their normalised title so quote the
+		// wording of the record when it differs
+		if oev, ok := ev.(*model.OccupationEvent); ok && oev.Occupation.Detail != "" && !strings.EqualFold(oev.Occupation.Detail, oev.Occupation.Name) {
+			line = text.JoinSentenceParts(line, doc.EncodeText(s.Locale.Tf("(the record reads “%s”)", oev.Occupation.Detail)).String())
+		}
+		events = append(events, md.Text(line))
 		for _, p := range ev.GetParticipants() {
 			peopleInCitations[p.Person] = true
 		}
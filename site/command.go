@@ -14,10 +14,12 @@ import (
 	"github.com/iand/genster/debug"
 	"github.com/iand/genster/gedcom"
 	"github.com/iand/genster/gramps"
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/tree"
 	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
 )
 
 var Command = &cli.Command{
@@ -61,6 +63,11 @@ var Command = &cli.Command{
 			Value:       "/",
 			Destination: &genopts.basePath,
 		},
+		&cli.StringFlag{
+			Name:        "site-url",
+			Usage:       "Absolute URL of the published site (e.g. https://example.com), used to give schema.org descriptions absolute identifiers. They are left out if not set. The family site never uses it.",
+			Destination: &genopts.siteURL,
+		},
 		&cli.StringFlag{
 			Name:    "identity-map",
 			Aliases: []string{"m"},
@@ -78,6 +85,11 @@ var Command = &cli.Command{
 			Value:       false,
 			Destination: &genopts.includePrivate,
 		},
+		&cli.StringFlag{
+			Name:        "family-output",
+			Usage:       "Directory in which to write a second copy of the site that includes living people, for publishing as a family-only site.",
+			Destination: &genopts.familyDir,
+		},
 		&cli.BoolFlag{
 			Name:        "debug",
 			Usage:       "Include debug info as inline comments.",
@@ -106,12 +118,30 @@ var Command = &cli.Command{
 			Usage:       "Path to the content directory whose diary, stories, and questions sub-folders are walked for person references.",
 			Destination: &genopts.contentDir,
 		},
+		&cli.StringFlag{
+			Name:        "todo-order",
+			Usage:       "Order of the people in the todo list. One of 'name' (alphabetical) or 'completeness' (least well researched first).",
+			Value:       TodoOrderName,
+			Destination: &genopts.todoOrder,
+		},
+		&cli.BoolFlag{
+			Name:        "json",
+			Usage:       "Write a JSON document alongside each person, place, citation and family page, plus one for each source and an index.json for the tree.",
+			Value:       false,
+			Destination: &genopts.jsonData,
+		},
 		&cli.BoolFlag{
 			Name:        "include-drafts",
 			Usage:       "Include draft content pages when walking for person references.",
 			Value:       false,
 			Destination: &genopts.includeDrafts,
 		},
+		&cli.BoolFlag{
+			Name:        "new-style",
+			Usage:       "Follow dates recorded under the Julian calendar with their Gregorian calendar equivalent.",
+			Value:       false,
+			Destination: &genopts.newStyle,
+		},
 	}, logging.Flags...),
 }
 
@@ -122,8 +152,10 @@ var genopts struct {
 	rootDir            string
 	keyIndividual      string
 	includePrivate     bool
+	familyDir          string
 
 	basePath           string
+	siteURL            string
 	inspect            string
 	treeConfig         string
 	verbose            bool
@@ -133,58 +165,129 @@ var genopts struct {
 	experimentFamilies bool
 	contentDir         string
 	includeDrafts      bool
+	todoOrder          string
+	jsonData           bool
+	newStyle           bool
 }
 
 func gen(ctx context.Context, cc *cli.Command) error {
 	logging.Setup()
 
+	if genopts.familyDir != "" {
+		if genopts.includePrivate {
+			return fmt.Errorf("--include-private cannot be used with --family-output; the family site always includes living people")
+		}
+		if genopts.familyDir == genopts.rootDir {
+			return fmt.Errorf("--family-output must be a different directory to --output")
+		}
+	}
+
+	if err := generateSite(genopts.rootDir, genopts.includePrivate, genopts.siteURL); err != nil {
+		return err
+	}
+
+	if genopts.familyDir != "" && genopts.inspect == "" {
+		// The tree is redacted during generation so it is loaded afresh
+		// for the family site.
+		logging.Info("generating family site", "dir", genopts.familyDir)
+		if err := generateSite(genopts.familyDir, true, ""); err != nil {
+			return fmt.Errorf("family site: %w", err)
+		}
+	}
+
+	if genopts.inspect == "" {
+		for _, kind := range []string{"charts", "media"} {
+			if err := pruneCache(kind, cacheMaxAge); err != nil {
+				logging.Warn("could not prune cache", "cache", kind, "err", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadTree reads the tree configuration file and loads the tree it describes
+// from the GEDCOM or Gramps file, whichever is given.
+func loadTree(gedcomFile, grampsFile, grampsDatabaseName, configFile string) (*tree.Tree, *tree.Config, error) {
 	var l tree.Loader
 	var err error
 
-	if genopts.gedcomFile != "" {
-		l, err = gedcom.NewLoader(genopts.gedcomFile)
+	if gedcomFile != "" {
+		l, err = gedcom.NewLoader(gedcomFile)
 		if err != nil {
-			return fmt.Errorf("load gedcom: %w", err)
+			return nil, nil, fmt.Errorf("load gedcom: %w", err)
 		}
-	} else if genopts.grampsFile != "" {
-		l, err = gramps.NewLoader(genopts.grampsFile, genopts.grampsDatabaseName)
+	} else if grampsFile != "" {
+		l, err = gramps.NewLoader(grampsFile, grampsDatabaseName)
 		if err != nil {
-			return fmt.Errorf("load gedcom: %w", err)
+			return nil, nil, fmt.Errorf("load gramps: %w", err)
 		}
 	} else {
-		return fmt.Errorf("no gedcom or gramps file specified")
+		return nil, nil, fmt.Errorf("no gedcom or gramps file specified")
 	}
 
-	treeCfg, err := tree.ReadConfig(genopts.treeConfig)
+	treeCfg, err := tree.ReadConfig(configFile)
 	if err != nil {
-		return fmt.Errorf("read tree config: %w", err)
+		return nil, nil, fmt.Errorf("read tree config: %w", err)
 	}
 
 	t, err := tree.LoadTree(treeCfg, l)
 	if err != nil {
-		return fmt.Errorf("load tree: %w", err)
+		return nil, nil, fmt.Errorf("load tree: %w", err)
+	}
+	return t, treeCfg, nil
+}
+
+// generateSite loads the tree and writes the pages of the site to rootDir.
+// When includePrivate is false living people are redacted. siteURL is the
+// absolute URL the site is published at, empty if not known.
+func generateSite(rootDir string, includePrivate bool, siteURL string) error {
+	t, treeCfg, err := loadTree(genopts.gedcomFile, genopts.grampsFile, genopts.grampsDatabaseName, genopts.treeConfig)
+	if err != nil {
+		return err
 	}
 
 	if genopts.contentDir != "" {
-		pageMap, err := walkContentPages(genopts.contentDir, genopts.includeDrafts)
+		cl, err := walkContentPages(genopts.contentDir, genopts.includeDrafts)
 		if err != nil {
 			return fmt.Errorf("walk content pages: %w", err)
 		}
-		for _, p := range t.People {
-			if pages, ok := pageMap[p.GrampsID]; ok {
-				p.Links = append(p.Links, pages...)
-			}
-			if pages, ok := pageMap[p.Slug]; ok {
-				p.Links = append(p.Links, pages...)
-			}
-		}
+		cl.apply(t)
 	}
 
+	t.ShowNewStyle = genopts.newStyle
+
 	s := NewSite(genopts.basePath, t)
-	s.IncludePrivate = genopts.includePrivate
+	s.IncludePrivate = includePrivate
 	s.IncludeDebugInfo = genopts.debug
 	s.ExperimentFamilies = genopts.experimentFamilies
 	s.MapTilerAPIKey = os.Getenv("MAPTILER_API_KEY")
+	s.PersonCharts = treeCfg.PersonCharts
+	s.RecordSets = treeCfg.RecordSets
+	if s.RecordSets == nil {
+		s.RecordSets = tree.DefaultRecordSets()
+	}
+	loc, err := locale.For(treeCfg.Language)
+	if err != nil {
+		return fmt.Errorf("tree language: %w", err)
+	}
+	s.Locale = loc
+	hpcfgs := treeCfg.HintProviders
+	if hpcfgs == nil {
+		hpcfgs = tree.DefaultHintProviders()
+	}
+	for _, cfg := range hpcfgs {
+		s.HintProviders = append(s.HintProviders, NewURLHintProvider(cfg, t.SurnameGroups))
+	}
+	s.JSONData = genopts.jsonData
+	s.SiteURL = siteURL
+
+	switch genopts.todoOrder {
+	case TodoOrderName, TodoOrderCompleteness:
+		s.TodoOrder = genopts.todoOrder
+	default:
+		return fmt.Errorf("unsupported todo order: %s", genopts.todoOrder)
+	}
 
 	// Look for key individual, assume id is a genster id first
 	keyIndividual, ok := t.GetPerson(genopts.keyIndividual)
@@ -260,8 +363,8 @@ func gen(ctx context.Context, cc *cli.Command) error {
 		}
 	}
 
-	if genopts.rootDir != "" {
-		if err := s.WritePages(genopts.rootDir); err != nil {
+	if rootDir != "" {
+		if err := s.WritePages(rootDir); err != nil {
 			return fmt.Errorf("write pages: %w", err)
 		}
 	}
@@ -274,10 +377,24 @@ var (
 	reISODate    = regexp.MustCompile(`^(\d\d\d\d)-(\d\d)-(\d\d)$`)
 	reFMTitle    = regexp.MustCompile(`(?m)^title:\s*["']?(.+?)["']?\s*$`)
 	reFMDraft    = regexp.MustCompile(`(?m)^draft:\s*["']?(\w+)["']?\s*$`)
-	reFMPeople   = regexp.MustCompile(`(?m)^people:\s*\n((?:[ \t]*-[ \t]+\S[^\n]*\n?)*)`)
 	rePeopleItem = regexp.MustCompile(`(?m)^[ \t]*-[ \t]+/r/([^\s]+)`)
+	reFMSearches = regexp.MustCompile(`(?m)^searches:`)
+
+	// reFMAliasFields match the front-matter fields that list the /r/
+	// aliases of the people, places and sources a page is about.
+	reFMAliasFields = map[string]*regexp.Regexp{
+		"people":  fmListFieldRE("people"),
+		"places":  fmListFieldRE("places"),
+		"sources": fmListFieldRE("sources"),
+	}
 )
 
+// fmListFieldRE returns a regular expression matching the YAML sequence held
+// in the front-matter field called name.
+func fmListFieldRE(name string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^` + name + `:\s*\n((?:[ \t]*-[ \t]+\S[^\n]*\n?)*)`)
+}
+
 var isoMonths = map[string]string{
 	"01": "Jan", "02": "Feb", "03": "Mar", "04": "Apr",
 	"05": "May", "06": "Jun", "07": "Jul", "08": "Aug",
@@ -292,20 +409,16 @@ func isoToHuman(s string) (string, bool) {
 	return m[3] + " " + isoMonths[m[2]] + " " + m[1], true
 }
 
-// fmPeopleIDs extracts /r/<id> aliases from the people: YAML front-matter
-// field. Returns a slice of alias strings (without the /r/ prefix).
-func fmPeopleIDs(content []byte) []string {
-	// Locate the front-matter block between leading --- delimiters.
-	if !bytes.HasPrefix(content, []byte("---")) {
-		return nil
-	}
-	end := bytes.Index(content[3:], []byte("\n---"))
-	if end < 0 {
+// fmAliasIDs extracts /r/<id> aliases from the people:, places: or sources:
+// YAML front-matter field, as named by field. Returns a slice of alias strings
+// (without the /r/ prefix).
+func fmAliasIDs(content []byte, field string) []string {
+	fm := fmBlock(content)
+	if fm == nil {
 		return nil
 	}
-	fm := content[3 : end+3]
 
-	m := reFMPeople.FindSubmatch(fm)
+	m := reFMAliasFields[field].FindSubmatch(fm)
 	if m == nil {
 		return nil
 	}
@@ -317,6 +430,47 @@ func fmPeopleIDs(content []byte) []string {
 	return ids
 }
 
+// fmBlock returns the front-matter block between the leading --- delimiters
+// of content, or nil if content has no front-matter.
+func fmBlock(content []byte) []byte {
+	if !bytes.HasPrefix(content, []byte("---")) {
+		return nil
+	}
+	end := bytes.Index(content[3:], []byte("\n---"))
+	if end < 0 {
+		return nil
+	}
+	return content[3 : end+3]
+}
+
+// An fmSearch is an entry in the searches: front-matter field of a diary
+// entry, which logs a search of a record set.
+type fmSearch struct {
+	Repository string   `yaml:"repository"`
+	RecordSet  string   `yaml:"recordset"`
+	From       int      `yaml:"from"`
+	To         int      `yaml:"to"`
+	People     []string `yaml:"people"`
+	Result     string   `yaml:"result"`
+	Citations  []string `yaml:"citations"`
+	Notes      string   `yaml:"notes"`
+}
+
+// fmSearches parses the searches: front-matter field of content.
+func fmSearches(content []byte) ([]fmSearch, error) {
+	fm := fmBlock(content)
+	if fm == nil || !reFMSearches.Match(fm) {
+		return nil, nil
+	}
+	var v struct {
+		Searches []fmSearch `yaml:"searches"`
+	}
+	if err := yaml.Unmarshal(fm, &v); err != nil {
+		return nil, err
+	}
+	return v.Searches, nil
+}
+
 // fmDraft reports whether the content's front-matter has draft set to a
 // truthy value (true, "true", "yes", or "1").
 func fmDraft(content []byte) bool {
@@ -371,19 +525,155 @@ type contentSection struct {
 	isoTitleOnly    bool // when true, title is always derived from ISO date (diary)
 }
 
+// contentLinks holds links to the diary, story and question pages that refer
+// to people, places and sources. Each map is keyed by the /r/ alias (without
+// the prefix) used to refer to the subject.
+type contentLinks struct {
+	People  map[string][]model.Link
+	Places  map[string][]model.Link
+	Sources map[string][]model.Link
+
+	// Searches are the searches logged in the front-matter of diary entries.
+	Searches []loggedSearch
+}
+
+// A loggedSearch is a search from a research log together with a link to the
+// page that logged it.
+type loggedSearch struct {
+	fmSearch
+	Link model.Link
+}
+
+func newContentLinks() *contentLinks {
+	return &contentLinks{
+		People:  make(map[string][]model.Link),
+		Places:  make(map[string][]model.Link),
+		Sources: make(map[string][]model.Link),
+	}
+}
+
+// each calls fn for each of the link maps in cl.
+func (cl *contentLinks) each(fn func(result map[string][]model.Link)) {
+	fn(cl.People)
+	fn(cl.Places)
+	fn(cl.Sources)
+}
+
+// apply adds the links in cl to the people, places and sources of t. People
+// are matched by Gramps ID or slug, places by Gramps ID and sources by Gramps
+// ID or genster ID. Sources are not published as pages so build cannot check
+// them, instead a warning is logged for each source alias that matches no
+// source in the tree.
+func (cl *contentLinks) apply(t *tree.Tree) {
+	for _, p := range t.People {
+		if pages, ok := cl.People[p.GrampsID]; ok && p.GrampsID != "" {
+			p.Links = append(p.Links, pages...)
+		}
+		if pages, ok := cl.People[p.Slug]; ok && p.Slug != "" {
+			p.Links = append(p.Links, pages...)
+		}
+	}
+	for _, pl := range t.Places {
+		if pages, ok := cl.Places[pl.GrampsID]; ok && pl.GrampsID != "" {
+			pl.Links = append(pl.Links, pages...)
+		}
+	}
+
+	found := make(map[string]bool)
+	for _, so := range t.Sources {
+		for _, id := range []string{so.GrampsID, so.ID} {
+			if pages, ok := cl.Sources[id]; ok && id != "" && !found[id] {
+				found[id] = true
+				so.Links = append(so.Links, pages...)
+				break
+			}
+		}
+	}
+	for id, pages := range cl.Sources {
+		if !found[id] {
+			logging.Warn("unknown source in front matter", "alias", "/r/"+id, "url", pages[0].URL)
+		}
+	}
+
+	cl.applySearches(t)
+}
+
+// applySearches adds the searches logged in the research log to the people
+// they were made for. People are matched by Gramps ID or slug and citations by
+// Gramps ID. Searches with an unknown result are skipped.
+func (cl *contentLinks) applySearches(t *tree.Tree) {
+	if len(cl.Searches) == 0 {
+		return
+	}
+	people := make(map[string]*model.Person)
+	for _, p := range t.People {
+		if p.GrampsID != "" {
+			people[p.GrampsID] = p
+		}
+		if p.Slug != "" {
+			people[p.Slug] = p
+		}
+	}
+	citations := make(map[string]*model.GeneralCitation)
+	for _, c := range t.Citations {
+		if c.GrampsID != "" {
+			citations[c.GrampsID] = c
+		}
+	}
+
+	for _, ls := range cl.Searches {
+		sr := &model.Search{
+			Repository: ls.Repository,
+			RecordSet:  ls.RecordSet,
+			From:       ls.From,
+			To:         ls.To,
+			Result:     model.SearchResult(strings.ToLower(ls.Result)),
+			Notes:      ls.Notes,
+			Link:       ls.Link,
+		}
+		switch sr.Result {
+		case model.SearchResultFound, model.SearchResultNil, model.SearchResultPartial:
+		default:
+			logging.Warn("unknown search result in research log", "result", ls.Result, "url", ls.Link.URL)
+			continue
+		}
+
+		for _, alias := range ls.People {
+			p, ok := people[strings.TrimPrefix(alias, "/r/")]
+			if !ok {
+				logging.Warn("unknown person in research log", "alias", alias, "url", ls.Link.URL)
+				continue
+			}
+			sr.People = append(sr.People, p)
+		}
+		for _, alias := range ls.Citations {
+			c, ok := citations[strings.TrimPrefix(alias, "/r/")]
+			if !ok {
+				logging.Warn("unknown citation in research log", "alias", alias, "url", ls.Link.URL)
+				continue
+			}
+			sr.Citations = append(sr.Citations, c)
+		}
+
+		for _, p := range sr.People {
+			p.Searches = append(p.Searches, sr)
+		}
+	}
+}
+
 // walkContentPages walks the diary, stories, and questions subdirectories
-// under contentDir looking for links to person pages. It returns a map of
-// person IDs/slugs to a slice of links, each tagged with its section category.
+// under contentDir looking for links to person, place and source pages. It
+// returns the links found, each tagged with its section category.
 // Diary links always use DD MMM YYYY titles and are sorted reverse-chronologically.
 // Pages with draft:true in their front-matter are skipped unless includeDrafts is true.
-func walkContentPages(contentDir string, includeDrafts bool) (map[string][]model.Link, error) {
+func walkContentPages(contentDir string, includeDrafts bool) (*contentLinks, error) {
 	sections := []contentSection{
 		{"diary", "/diary/", model.LinkCategoryDiary, model.LinkCategoryDiary, true},
 		{"stories", "/stories/", model.LinkCategoryStorySubject, model.LinkCategoryStoryMention, false},
 		{"questions", "/questions/", model.LinkCategoryQuestionSubject, model.LinkCategoryQuestionMention, false},
 	}
 
-	result := make(map[string][]model.Link)
+	result := newContentLinks()
 	for _, sec := range sections {
 		dir := filepath.Join(contentDir, sec.subdir)
 		if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -394,45 +684,51 @@ func walkContentPages(contentDir string, includeDrafts bool) (map[string][]model
 		}
 		if sec.isoTitleOnly {
 			// Sort diary links newest-first by URL (ISO dates sort lexicographically).
-			for id, links := range result {
-				slices.SortFunc(links, func(a, b model.Link) int {
-					return strings.Compare(b.URL, a.URL)
-				})
-				result[id] = links
-			}
+			result.each(func(result map[string][]model.Link) {
+				for id, links := range result {
+					slices.SortFunc(links, func(a, b model.Link) int {
+						return strings.Compare(b.URL, a.URL)
+					})
+					result[id] = links
+				}
+			})
 		}
 	}
 
 	// Deduplicate: a person may be referenced by both GrampsID and slug, or
 	// the same alias may appear more than once in a file. Keep the first
-	// occurrence of each URL within each person's slice.
-	for id, links := range result {
-		seen := make(map[string]bool, len(links))
-		deduped := links[:0]
-		for _, l := range links {
-			if !seen[l.URL] {
-				seen[l.URL] = true
-				deduped = append(deduped, l)
+	// occurrence of each URL within each subject's slice.
+	result.each(func(result map[string][]model.Link) {
+		for id, links := range result {
+			seen := make(map[string]bool, len(links))
+			deduped := links[:0]
+			for _, l := range links {
+				if !seen[l.URL] {
+					seen[l.URL] = true
+					deduped = append(deduped, l)
+				}
 			}
+			result[id] = deduped
 		}
-		result[id] = deduped
-	}
+	})
 
 	return result, nil
 }
 
 // walkSectionPages walks a single content section directory (e.g. diary/,
-// stories/, questions/), finds .md files that reference person aliases, and
-// records links for those people in result. When isoTitleOnly is true the
+// stories/, questions/), finds .md files that reference person, place or
+// source aliases, and records links for them in result. When isoTitleOnly is true the
 // title is always derived from the ISO date in the filename stem (diary
 // behaviour); entries whose stem is not an ISO date are skipped. When false
 // the title comes from front-matter, falling back to the stem.
 //
-// People listed in the front-matter people: field are assigned subjectCategory.
-// People linked anywhere in the body with (/r/...) are assigned mentionCategory.
-// When subjectCategory == mentionCategory (e.g. diary) all references use a
-// single category.
-func walkSectionPages(dir, urlBase string, subjectCategory, mentionCategory model.LinkCategory, isoTitleOnly, includeDrafts bool, result map[string][]model.Link) error {
+// People, places and sources listed in the front-matter people:, places: and
+// sources: fields are assigned subjectCategory. Aliases linked anywhere in the
+// body with (/r/...) are assigned mentionCategory and recorded for both people
+// and places, since an alias alone does not say which it refers to. When
+// subjectCategory == mentionCategory (e.g. diary) all references use a single
+// category.
+func walkSectionPages(dir, urlBase string, subjectCategory, mentionCategory model.LinkCategory, isoTitleOnly, includeDrafts bool, result *contentLinks) error {
 	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
 			return nil
@@ -482,19 +778,34 @@ func walkSectionPages(dir, urlBase string, subjectCategory, mentionCategory mode
 			}
 		}
 
-		// People in front-matter people: field are subjects of this page.
-		for _, id := range fmPeopleIDs(content) {
-			l := baseLink
-			l.Category = subjectCategory
-			result[id] = append(result[id], l)
+		// Subjects listed in the front-matter are subjects of this page.
+		subject := baseLink
+		subject.Category = subjectCategory
+		for _, id := range fmAliasIDs(content, "people") {
+			result.People[id] = append(result.People[id], subject)
+		}
+		for _, id := range fmAliasIDs(content, "places") {
+			result.Places[id] = append(result.Places[id], subject)
+		}
+		for _, id := range fmAliasIDs(content, "sources") {
+			result.Sources[id] = append(result.Sources[id], subject)
+		}
+
+		searches, err := fmSearches(content)
+		if err != nil {
+			return fmt.Errorf("parse searches in %s: %w", path, err)
+		}
+		for _, sr := range searches {
+			result.Searches = append(result.Searches, loggedSearch{fmSearch: sr, Link: subject})
 		}
 
-		// People linked in the body with (/r/...) are mentions.
+		// People and places linked in the body with (/r/...) are mentions.
+		mention := baseLink
+		mention.Category = mentionCategory
 		for _, match := range reAliasLink.FindAllSubmatch(content, -1) {
 			id := string(match[1])
-			l := baseLink
-			l.Category = mentionCategory
-			result[id] = append(result[id], l)
+			result.People[id] = append(result.People[id], mention)
+			result.Places[id] = append(result.Places[id], mention)
 		}
 
 		return nil
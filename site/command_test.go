@@ -0,0 +1,143 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/tree"
+)
+
+func TestWalkContentPagesSubjects(t *testing.T) {
+	contentDir := t.TempDir()
+	files := map[string]string{
+		"diary/2024-03-01.md": "---\npeople:\n  - /r/I0001\nplaces:\n  - /r/P0001\nsources:\n  - /r/S0001\n---\n\nVisited [the church](/r/P0002).\n",
+		"stories/harston.md":  "---\ntitle: Life in Harston\nplaces:\n  - /r/P0001\n---\n\nJohn [Smith](/r/I0001) lived here.\n",
+	}
+	for name, content := range files {
+		fname := filepath.Join(contentDir, name)
+		if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fname, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cl, err := walkContentPages(contentDir, false)
+	if err != nil {
+		t.Fatalf("walkContentPages: %v", err)
+	}
+
+	tr := tree.NewTree("t", nil, nil)
+	john := &model.Person{ID: "p1", GrampsID: "I0001"}
+	harston := &model.Place{ID: "pl1", GrampsID: "P0001"}
+	church := &model.Place{ID: "pl2", GrampsID: "P0002"}
+	register := &model.Source{ID: "s1", GrampsID: "S0001"}
+	tr.People[john.ID] = john
+	tr.Places[harston.ID] = harston
+	tr.Places[church.ID] = church
+	tr.Sources[register.ID] = register
+	cl.apply(tr)
+
+	diary := model.Link{Title: "01 Mar 2024", URL: "/diary/2024-03-01/", Category: model.LinkCategoryDiary}
+	for _, tt := range []struct {
+		name string
+		got  []model.Link
+		want []model.Link
+	}{
+		{
+			name: "person",
+			got:  john.Links,
+			want: []model.Link{
+				diary,
+				{Title: "Life in Harston", URL: "/stories/harston/", Category: model.LinkCategoryStoryMention},
+			},
+		},
+		{
+			name: "place subject",
+			got:  harston.Links,
+			want: []model.Link{
+				diary,
+				{Title: "Life in Harston", URL: "/stories/harston/", Category: model.LinkCategoryStorySubject},
+			},
+		},
+		{
+			name: "place mention",
+			got:  church.Links,
+			want: []model.Link{diary},
+		},
+		{
+			name: "source",
+			got:  register.Links,
+			want: []model.Link{diary},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.got); diff != "" {
+				t.Errorf("links mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWalkContentPagesSearches(t *testing.T) {
+	contentDir := t.TempDir()
+	fname := filepath.Join(contentDir, "diary", "2024-03-01.md")
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nsearches:\n" +
+		"  - repository: FreeBMD\n    recordset: GRO births\n    from: 1837\n    to: 1845\n    people:\n      - /r/I0001\n    result: nil\n" +
+		"  - repository: FindMyPast\n    recordset: Baptisms\n    people:\n      - /r/john-smith\n    result: found\n    citations:\n      - /r/C0001\n" +
+		"  - recordset: Burials\n    people:\n      - /r/I0001\n    result: maybe\n" +
+		"---\n\nSearched for John.\n"
+	if err := os.WriteFile(fname, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cl, err := walkContentPages(contentDir, false)
+	if err != nil {
+		t.Fatalf("walkContentPages: %v", err)
+	}
+
+	tr := tree.NewTree("t", nil, nil)
+	john := &model.Person{ID: "p1", GrampsID: "I0001", Slug: "john-smith"}
+	cit := &model.GeneralCitation{ID: "c1", GrampsID: "C0001"}
+	tr.People[john.ID] = john
+	tr.Citations[cit.ID] = cit
+	cl.apply(tr)
+
+	diary := model.Link{Title: "01 Mar 2024", URL: "/diary/2024-03-01/", Category: model.LinkCategoryDiary}
+	want := []*model.Search{
+		{Repository: "FreeBMD", RecordSet: "GRO births", From: 1837, To: 1845, Result: model.SearchResultNil, People: []*model.Person{john}, Link: diary},
+		{Repository: "FindMyPast", RecordSet: "Baptisms", Result: model.SearchResultFound, People: []*model.Person{john}, Citations: []*model.GeneralCitation{cit}, Link: diary},
+	}
+	if len(john.Searches) != len(want) {
+		t.Fatalf("got %d searches, wanted %d", len(john.Searches), len(want))
+	}
+	for i := range want {
+		got := john.Searches[i]
+		if got.Repository != want[i].Repository || got.RecordSet != want[i].RecordSet || got.From != want[i].From || got.To != want[i].To || got.Result != want[i].Result || got.Link != want[i].Link {
+			t.Errorf("search %d: got %+v, wanted %+v", i, got, want[i])
+		}
+		if len(got.People) != 1 || got.People[0] != john {
+			t.Errorf("search %d: got people %v, wanted john", i, got.People)
+		}
+		if len(got.Citations) != len(want[i].Citations) {
+			t.Errorf("search %d: got %d citations, wanted %d", i, len(got.Citations), len(want[i].Citations))
+		}
+	}
+
+	if !searchedWithNilResult(john, "GRO births", 1840) {
+		t.Errorf("GRO births in 1840 not reported as searched with a nil result")
+	}
+	if searchedWithNilResult(john, "GRO births", 1850) {
+		t.Errorf("GRO births in 1850 reported as searched with a nil result")
+	}
+	if searchedWithNilResult(john, "Baptisms", 1840) {
+		t.Errorf("found search reported as a nil result")
+	}
+}
@@ -1,6 +1,7 @@
 package site
 
 import (
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/narrative"
 	"github.com/iand/genster/render"
@@ -16,18 +17,24 @@ func RenderFamilyPage(s *Site, f *model.Family) (render.Document[md.Text], error
 	doc.Title(f.PreferredUniqueName)
 	// if p.Redacted {
 	// }
-	n := BuildFamilyNarrative(f, false)
+	n := BuildFamilyNarrative(f, false, s.Locale)
 
 	nc := &narrative.DefaultNameChooser{}
 
 	n.Render(doc, nc)
 
+	if link := s.GalleryLinkFor(f); link != "" {
+		doc.Heading2("Images", "")
+		doc.Para(doc.EncodeLink(doc.EncodeText("See all images of this family"), link))
+	}
+
 	return doc, nil
 }
 
-func BuildFamilyNarrative(f *model.Family, inlineMedia bool) *narrative.FamilyNarrative[md.Text] {
+func BuildFamilyNarrative(f *model.Family, inlineMedia bool, loc locale.Locale) *narrative.FamilyNarrative[md.Text] {
 	n := &narrative.FamilyNarrative[md.Text]{
 		Family: f,
+		Locale: loc,
 	}
 
 	var timeline []model.TimelineEvent
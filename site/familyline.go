@@ -24,7 +24,7 @@ func RenderFamilyLinePage(s *Site, fl *model.FamilyLine) (render.Document[md.Tex
 	nc := &narrative.DefaultNameChooser{}
 	for _, f := range fl.Families {
 		doc.Heading2(doc.EncodeText(f.PreferredUniqueName), f.ID)
-		n := BuildFamilyNarrative(f, false)
+		n := BuildFamilyNarrative(f, false, s.Locale)
 		n.Render(doc, nc)
 	}
 	return doc, nil
@@ -89,7 +89,7 @@ func RenderFamilyLineEventsPage(s *Site, fl *model.FamilyLine) (render.Document[
 		}
 
 		fmtr := &citationIDFormatter[md.Text]{
-			inner: narrative.NewNarrativeTimelineEntryFormatter(pov, doc, logging.Default(), &narrative.TimelineNameChooser{}, false),
+			inner: narrative.NewNarrativeTimelineEntryFormatter(pov, doc, logging.Default(), &narrative.TimelineNameChooser{}, false, s.Locale),
 		}
 
 		if err := RenderTimeline(t, pov, doc, fmtr); err != nil {
@@ -5,6 +5,9 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
+
+	"github.com/iand/genster/logging"
 )
 
 func CreateFile(fname string) (*os.File, error) {
@@ -45,3 +48,97 @@ func CopyFile(dst, src string) error {
 
 	return nil
 }
+
+// cacheDir returns the directory used to cache generated files of the given
+// kind, such as charts or media derivatives. The cache is kept in the system's
+// temporary directory when there is no home directory.
+func cacheDir(kind string) string {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			base = filepath.Join(home, ".cache")
+		} else {
+			base = os.TempDir()
+		}
+	}
+	return filepath.Join(base, "genster", kind)
+}
+
+// writeCacheFile creates the cache file fname with the output of write. The
+// output is written to a temporary file in the same directory that is renamed
+// into place once complete, so an interrupted build never leaves a truncated
+// file that a later build would take to be cached.
+func writeCacheFile(fname string, write func(w io.Writer) error) error {
+	dir := filepath.Dir(fname)
+	if err := os.MkdirAll(dir, 0o777); err != nil {
+		return fmt.Errorf("create path: %w", err)
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(fname)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	if err := write(f); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+	if err := os.Rename(f.Name(), fname); err != nil {
+		return fmt.Errorf("rename temporary file: %w", err)
+	}
+	return nil
+}
+
+// cacheMaxAge is how long a cached file is kept after it was last used. The
+// cache is shared by the public and family sites and by every tree built on
+// the machine, so files not used by one build may still be wanted by another.
+const cacheMaxAge = 30 * 24 * time.Hour
+
+// touchCacheFile marks the cache file fname as used by the current build.
+func touchCacheFile(fname string) {
+	now := time.Now()
+	if err := os.Chtimes(fname, now, now); err != nil {
+		logging.Debug("could not touch cache file", "file", fname, "err", err)
+	}
+}
+
+// pruneCache removes the files in the cache of the given kind that have not
+// been written or used for longer than maxAge, including temporary files left
+// by an interrupted build.
+func pruneCache(kind string, maxAge time.Duration) error {
+	dir := cacheDir(kind)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read cache: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	removed := 0
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+			return fmt.Errorf("remove cached file: %w", err)
+		}
+		removed++
+	}
+	if removed > 0 {
+		logging.Info("removed unused cached files", "cache", kind, "count", removed)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package site
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteCacheFile(t *testing.T) {
+	dir := t.TempDir()
+	fname := filepath.Join(dir, "charts", "chart.svg")
+
+	errWrite := errors.New("interrupted")
+	err := writeCacheFile(fname, func(w io.Writer) error {
+		io.WriteString(w, "<svg")
+		return errWrite
+	})
+	if !errors.Is(err, errWrite) {
+		t.Fatalf("got error %v, wanted %v", err, errWrite)
+	}
+	if _, err := os.Stat(fname); !os.IsNotExist(err) {
+		t.Errorf("cache file exists after a failed write")
+	}
+	entries, err := os.ReadDir(filepath.Dir(fname))
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("got %d files left in cache directory, wanted none", len(entries))
+	}
+
+	if err := writeCacheFile(fname, func(w io.Writer) error {
+		_, err := io.WriteString(w, "<svg></svg>")
+		return err
+	}); err != nil {
+		t.Fatalf("write cache file: %v", err)
+	}
+	data, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("read cache file: %v", err)
+	}
+	if got, want := string(data), "<svg></svg>"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestPruneCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	dir := cacheDir("charts")
+	if err := os.MkdirAll(dir, 0o777); err != nil {
+		t.Fatalf("create cache: %v", err)
+	}
+
+	old := time.Now().Add(-2 * cacheMaxAge)
+	for _, name := range []string{"unused.svg", "used.svg", "new.svg", "interrupted.svg.123.tmp"} {
+		fname := filepath.Join(dir, name)
+		if err := os.WriteFile(fname, []byte("<svg></svg>"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		if name != "new.svg" {
+			if err := os.Chtimes(fname, old, old); err != nil {
+				t.Fatalf("set time of %s: %v", name, err)
+			}
+		}
+	}
+	touchCacheFile(filepath.Join(dir, "used.svg"))
+
+	if err := pruneCache("charts", cacheMaxAge); err != nil {
+		t.Fatalf("prune cache: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	if diff := cmp.Diff([]string{"new.svg", "used.svg"}, got); diff != "" {
+		t.Errorf("cache mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := pruneCache("media", cacheMaxAge); err != nil {
+		t.Errorf("prune missing cache: %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package site
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/render/md"
+	"github.com/iand/genster/text"
+)
+
+// A galleryEntry is an image shown in a gallery page together with what is
+// known about it.
+type galleryEntry struct {
+	cmo      *model.CitedMediaObject
+	date     *model.Date            // date of the event the image records, nil if not known
+	people   []*model.Person        // people shown in or named by the image
+	citation *model.GeneralCitation // citation the image was found through, nil if none
+}
+
+// galleryEntries collects gallery entries, ignoring images that are not
+// published and images already collected with the same highlighted region.
+type galleryEntries struct {
+	s       *Site
+	seen    map[string]bool
+	entries []*galleryEntry
+}
+
+func (s *Site) newGalleryEntries() *galleryEntries {
+	return &galleryEntries{s: s, seen: make(map[string]bool)}
+}
+
+func (g *galleryEntries) add(cmos []*model.CitedMediaObject, date *model.Date, people []*model.Person, c *model.GeneralCitation) {
+	for _, cmo := range cmos {
+		if _, ok := g.s.PublishSet.MediaObjects[cmo.Object.ID]; !ok {
+			continue
+		}
+		key := cmo.Object.ID
+		if cmo.Highlight != nil {
+			key += fmt.Sprintf("|%d,%d,%d,%d", cmo.Highlight.Left, cmo.Highlight.Bottom, cmo.Highlight.Width, cmo.Highlight.Height)
+		}
+		if g.seen[key] {
+			continue
+		}
+		g.seen[key] = true
+		g.entries = append(g.entries, &galleryEntry{cmo: cmo, date: date, people: people, citation: c})
+	}
+}
+
+// addEvents adds the images attached to each of evs and to their citations.
+func (g *galleryEntries) addEvents(evs []model.TimelineEvent) {
+	for _, ev := range evs {
+		people := eventPeople(ev)
+		g.add(ev.GetMediaObjects(), ev.GetDate(), people, nil)
+		for _, c := range ev.GetCitations() {
+			cited := c.PeopleCited
+			if len(cited) == 0 {
+				cited = people
+			}
+			g.add(c.MediaObjects, ev.GetDate(), cited, c)
+		}
+	}
+}
+
+// eventPeople returns the principals of ev followed by its other
+// participants, each listed once.
+func eventPeople(ev model.TimelineEvent) []*model.Person {
+	var people []*model.Person
+	add := func(p *model.Person) {
+		if p == nil {
+			return
+		}
+		for _, q := range people {
+			if q.SameAs(p) {
+				return
+			}
+		}
+		people = append(people, p)
+	}
+	switch tev := ev.(type) {
+	case model.IndividualTimelineEvent:
+		add(tev.GetPrincipal())
+	case model.UnionTimelineEvent:
+		add(tev.GetHusband())
+		add(tev.GetWife())
+	}
+	for _, ep := range ev.GetParticipants() {
+		add(ep.Person)
+	}
+	return people
+}
+
+// sorted returns the entries in date order, with undated images last.
+func (g *galleryEntries) sorted() []*galleryEntry {
+	sort.SliceStable(g.entries, func(i, j int) bool {
+		return g.entries[i].date.SortsBefore(g.entries[j].date)
+	})
+	return g.entries
+}
+
+// personGalleryEntries returns the images linked to p, directly or through
+// the citations of the events in their timeline.
+func (s *Site) personGalleryEntries(p *model.Person) []*galleryEntry {
+	g := s.newGalleryEntries()
+	g.add(p.Gallery, nil, []*model.Person{p}, nil)
+	g.addEvents(p.Timeline)
+	return g.sorted()
+}
+
+// familyGalleryEntries returns the images linked to the events of f or to
+// their citations.
+func (s *Site) familyGalleryEntries(f *model.Family) []*galleryEntry {
+	g := s.newGalleryEntries()
+	g.addEvents(f.Timeline)
+	return g.sorted()
+}
+
+// sourceGalleryEntries returns the images attached to the published citations
+// of so.
+func (s *Site) sourceGalleryEntries(so *model.Source) []*galleryEntry {
+	g := s.newGalleryEntries()
+	for _, c := range s.PublishSet.Citations {
+		if c.Source != so {
+			continue
+		}
+		var date *model.Date
+		if len(c.EventsCited) > 0 {
+			date = c.EventsCited[0].GetDate()
+		}
+		g.add(c.MediaObjects, date, c.PeopleCited, c)
+	}
+	// citations are held in a map so sort by citation before date for a stable order
+	sort.SliceStable(g.entries, func(i, j int) bool {
+		return g.entries[i].citation.ID < g.entries[j].citation.ID
+	})
+	return g.sorted()
+}
+
+// WriteGalleryPages writes a gallery page listing the images of each
+// published person, family and source that has any, and records the links
+// to them for GalleryLinkFor.
+func (s *Site) WriteGalleryPages(contentDir string) error {
+	s.galleryLinks = make(map[any]string)
+
+	for _, p := range s.PublishSet.People {
+		if s.LinkFor(p) == "" || p.Redacted {
+			continue
+		}
+		entries := s.personGalleryEntries(p)
+		if len(entries) == 0 {
+			continue
+		}
+		doc := s.renderGalleryPage("Images of "+p.PreferredUniqueName, p.PreferredFullName, p, entries)
+		if err := writePage(doc, contentDir, galleryFile(fmt.Sprintf(s.PersonFilePattern, p.ID))); err != nil {
+			return fmt.Errorf("write person gallery page: %w", err)
+		}
+		s.galleryLinks[p] = galleryLink(s.LinkFor(p))
+	}
+
+	if s.ExperimentFamilies {
+		for _, f := range s.PublishSet.Families {
+			if s.LinkFor(f) == "" {
+				continue
+			}
+			entries := s.familyGalleryEntries(f)
+			if len(entries) == 0 {
+				continue
+			}
+			doc := s.renderGalleryPage("Images of "+f.PreferredUniqueName, f.PreferredUniqueName, f, entries)
+			if err := writePage(doc, contentDir, galleryFile(fmt.Sprintf(s.FamilyFilePattern, f.ID))); err != nil {
+				return fmt.Errorf("write family gallery page: %w", err)
+			}
+			s.galleryLinks[f] = galleryLink(s.LinkFor(f))
+		}
+	}
+
+	for _, so := range s.PublishSet.Sources {
+		entries := s.sourceGalleryEntries(so)
+		if len(entries) == 0 {
+			continue
+		}
+		title := "Images from " + so.Title
+		if so.Title == "" {
+			title = "Images from an untitled source"
+		}
+		// source pages are not published so the gallery does not link back to one
+		doc := s.renderGalleryPage(title, "", nil, entries)
+		if err := writePage(doc, contentDir, galleryFile(fmt.Sprintf(s.SourceFilePattern, so.ID))); err != nil {
+			return fmt.Errorf("write source gallery page: %w", err)
+		}
+		s.galleryLinks[so] = galleryLink(fmt.Sprintf(s.SourceLinkPattern, so.ID))
+	}
+
+	return nil
+}
+
+// GalleryLinkFor returns the link to the gallery page of v, or an empty string
+// if it has none.
+func (s *Site) GalleryLinkFor(v any) string {
+	return s.galleryLinks[v]
+}
+
+// galleryFile returns the name of the gallery page belonging to the page
+// written to fname.
+func galleryFile(fname string) string {
+	return path.Join(path.Dir(fname), "gallery", path.Base(fname))
+}
+
+// galleryLink returns the link to the gallery page belonging to the page at
+// link.
+func galleryLink(link string) string {
+	return strings.TrimSuffix(link, "/") + "/gallery/"
+}
+
+// renderGalleryPage renders a gallery page of entries with the given title,
+// linking back to the page of subject, called name, if it is not nil.
+func (s *Site) renderGalleryPage(title string, name string, subject any, entries []*galleryEntry) *md.Document {
+	doc := s.NewDocument()
+	doc.Layout(PageLayoutGallery.String())
+	doc.SetSitemapDisable()
+	doc.Title(title)
+
+	if subject != nil {
+		doc.Para(doc.EncodeText("Images linked to ") + doc.EncodeModelLink(doc.EncodeText(name), subject) + doc.EncodeText(", directly or through the sources cited for them."))
+	}
+
+	items := make([]md.GalleryItem, 0, len(entries))
+	for _, e := range entries {
+		mo := e.cmo.Object
+		link := s.LinkFor(mo)
+		if len(mo.Derivatives) > 0 {
+			link = mo.Derivatives[len(mo.Derivatives)-1].Link
+		}
+
+		alt := mo.Title
+		if alt == "" {
+			alt = "Image"
+		}
+		lightbox := alt
+		caption := doc.EncodeText(alt)
+		if !e.date.IsUnknown() {
+			lightbox += ", " + e.date.String()
+			caption += doc.EncodeText(", " + e.date.String())
+		}
+		caption = md.Text(text.FinishSentence(string(caption)))
+
+		var shown []string
+		for _, p := range e.people {
+			if p.IsUnknown() {
+				continue
+			}
+			shown = append(shown, string(doc.EncodeModelLink(doc.EncodeText(p.PreferredFullName), p)))
+		}
+		if len(shown) > 0 {
+			caption += doc.EncodeText(" Shows ") + md.Text(text.JoinList(shown)) + doc.EncodeText(".")
+		}
+		if e.citation != nil {
+			caption += doc.EncodeText(" From ") + doc.EncodeModelLink(doc.EncodeText(e.citation.String()), e.citation) + doc.EncodeText(".")
+		}
+
+		items = append(items, md.GalleryItem{
+			Link:      link,
+			Images:    mo.Derivatives,
+			Alt:       alt,
+			Title:     lightbox,
+			Caption:   caption,
+			Highlight: e.cmo.Highlight,
+		})
+	}
+	doc.Gallery(items)
+
+	return doc
+}
@@ -0,0 +1,91 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/genster/model"
+)
+
+func TestPersonGalleryEntries(t *testing.T) {
+	portrait := &model.MediaObject{ID: "m1", Title: "Portrait"}
+	register := &model.MediaObject{ID: "m2", Title: "Baptism register"}
+	census := &model.MediaObject{ID: "m3", Title: "Census page"}
+	private := &model.MediaObject{ID: "m4", Title: "Private letter"}
+
+	p := &model.Person{ID: "p1"}
+	sibling := &model.Person{ID: "p2"}
+	bapt := &model.BaptismEvent{
+		GeneralEvent: model.GeneralEvent{
+			Date: model.PreciseDate(1850, 6, 1),
+			Citations: []*model.GeneralCitation{
+				{ID: "c1", MediaObjects: []*model.CitedMediaObject{{Object: register, Highlight: &model.Region{Left: 10, Bottom: 20, Width: 30, Height: 10}}}},
+			},
+		},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+	}
+	censusEv := &model.CensusEvent{
+		GeneralEvent: model.GeneralEvent{
+			Date: model.PreciseDate(1841, 6, 6),
+			Citations: []*model.GeneralCitation{
+				{ID: "c2", PeopleCited: []*model.Person{p, sibling}, MediaObjects: []*model.CitedMediaObject{{Object: census}, {Object: private}}},
+				// the same page cited again is only shown once
+				{ID: "c3", MediaObjects: []*model.CitedMediaObject{{Object: census}}},
+			},
+		},
+		Entries: []*model.CensusEntry{{Principal: p}},
+	}
+	p.Gallery = []*model.CitedMediaObject{{Object: portrait}}
+	p.Timeline = []model.TimelineEvent{bapt, censusEv}
+
+	s := &Site{
+		PublishSet: &PublishSet{
+			MediaObjects: map[string]*model.MediaObject{"m1": portrait, "m2": register, "m3": census},
+		},
+	}
+
+	type entry struct {
+		ID       string
+		People   []string
+		Citation string
+	}
+	var got []entry
+	for _, e := range s.personGalleryEntries(p) {
+		en := entry{ID: e.cmo.Object.ID}
+		for _, ep := range e.people {
+			en.People = append(en.People, ep.ID)
+		}
+		if e.citation != nil {
+			en.Citation = e.citation.ID
+		}
+		got = append(got, en)
+	}
+	want := []entry{
+		{ID: "m3", People: []string{"p1", "p2"}, Citation: "c2"},
+		{ID: "m2", People: []string{"p1"}, Citation: "c1"},
+		{ID: "m1", People: []string{"p1"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("entries mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGalleryFile(t *testing.T) {
+	testCases := []struct {
+		fname string
+		link  string
+		want  string
+		wantL string
+	}{
+		{fname: "person/abc/index.md", link: "/trees/t/person/abc/", want: "person/abc/gallery/index.md", wantL: "/trees/t/person/abc/gallery/"},
+		{fname: "family/xyz/index.md", link: "/trees/t/family/xyz", want: "family/xyz/gallery/index.md", wantL: "/trees/t/family/xyz/gallery/"},
+	}
+	for _, tc := range testCases {
+		if got := galleryFile(tc.fname); got != tc.want {
+			t.Errorf("galleryFile(%q) = %q, want %q", tc.fname, got, tc.want)
+		}
+		if got := galleryLink(tc.link); got != tc.wantL {
+			t.Errorf("galleryLink(%q) = %q, want %q", tc.link, got, tc.wantL)
+		}
+	}
+}
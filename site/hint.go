@@ -0,0 +1,156 @@
+package site
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/tree"
+)
+
+// A HintProvider builds links to searches of an external record collection
+// that may hold a record of an event in a person's life.
+type HintProvider interface {
+	Hints(p *model.Person, ev model.TimelineEvent) []model.Link
+}
+
+// NewURLHintProvider returns a HintProvider that fills in the URL template
+// described by cfg, using sg to find the variants of a person's surname.
+func NewURLHintProvider(cfg *tree.HintProviderConfig, sg *tree.SurnameGroups) HintProvider {
+	return &urlHintProvider{cfg: cfg, surnames: sg}
+}
+
+type urlHintProvider struct {
+	cfg      *tree.HintProviderConfig
+	surnames *tree.SurnameGroups
+}
+
+func (h *urlHintProvider) Hints(p *model.Person, ev model.TimelineEvent) []model.Link {
+	typ, ok := h.cfg.Events[ev.Type()]
+	if !ok {
+		return nil
+	}
+	if p.PreferredFamilyName == "" || p.PreferredFamilyName == model.UnknownNamePlaceholder {
+		return nil
+	}
+	yr, ok := ev.GetDate().Year()
+	if !ok || !h.cfg.CoversYear(yr) {
+		return nil
+	}
+	pl := ev.GetPlace()
+	if len(h.cfg.Countries) > 0 && !placeInCountry(pl, h.cfg.CoversCountry) {
+		return nil
+	}
+
+	given := p.PreferredGivenName
+	if given == model.UnknownNamePlaceholder {
+		given = ""
+	}
+	district := ""
+	if reg := ev.GetRegistration(); reg != nil && !reg.District.IsUnknown() {
+		district = reg.District.Name
+	} else if !pl.IsUnknown() && pl.District != nil {
+		district = pl.District.Name
+	}
+
+	surnames := []string{p.PreferredFamilyName}
+	if h.cfg.Variants && h.surnames != nil {
+		if g, ok := h.surnames.Lookup(p.PreferredFamilyName); ok {
+			for _, n := range append([]string{g.Surname}, g.Names...) {
+				if n != p.PreferredFamilyName {
+					surnames = append(surnames, n)
+				}
+			}
+		}
+	}
+
+	links := make([]model.Link, 0, len(surnames))
+	for _, surname := range surnames {
+		r := strings.NewReplacer(
+			"{surname}", url.QueryEscape(surname),
+			"{given}", url.QueryEscape(given),
+			"{year}", strconv.Itoa(yr),
+			"{from}", strconv.Itoa(yr-h.cfg.Span),
+			"{to}", strconv.Itoa(yr+h.cfg.Span),
+			"{span}", strconv.Itoa(h.cfg.Span),
+			"{type}", url.QueryEscape(typ),
+			"{district}", url.QueryEscape(district),
+		)
+		links = append(links, model.Link{
+			Title:    fmt.Sprintf("%s %s search for %s", h.cfg.Name, ev.Type(), surname),
+			URL:      r.Replace(h.cfg.URL),
+			Category: model.LinkCategorySearch,
+		})
+	}
+	return links
+}
+
+// researchHints returns links to searches of the site's hint providers that
+// may find a record of ev for p.
+func (s *Site) researchHints(p *model.Person, ev model.TimelineEvent) []model.Link {
+	var links []model.Link
+	for _, hp := range s.HintProviders {
+		links = append(links, hp.Hints(p, ev)...)
+	}
+	return links
+}
+
+// maxPersonHints is the most links to searches of external record collections
+// listed for a single person.
+const maxPersonHints = 12
+
+// personResearchHints returns links to searches that may find records of the
+// birth, marriages and death of p. Searches with the same URL are listed once.
+// The links are interleaved so that the first search for each event, which
+// uses the person's preferred surname, is kept when the list is cut short
+// at maxPersonHints.
+func (s *Site) personResearchHints(p *model.Person) []model.Link {
+	var perEvent [][]model.Link
+	for _, kind := range []string{"birth", "marriage", "death"} {
+		for _, ev := range recordSetEvents(p, kind) {
+			if hints := s.researchHints(p, ev); len(hints) > 0 {
+				perEvent = append(perEvent, hints)
+			}
+		}
+	}
+
+	var links []model.Link
+	seen := make(map[string]bool)
+	for i := 0; ; i++ {
+		more := false
+		for _, hints := range perEvent {
+			if i >= len(hints) {
+				continue
+			}
+			more = true
+			if seen[hints[i].URL] {
+				continue
+			}
+			seen[hints[i].URL] = true
+			links = append(links, hints[i])
+			if len(links) == maxPersonHints {
+				return links
+			}
+		}
+		if !more {
+			return links
+		}
+	}
+}
+
+// placeInCountry reports whether the nation or country of pl is accepted by
+// covers.
+func placeInCountry(pl *model.Place, covers func(string) bool) bool {
+	if pl.IsUnknown() {
+		return false
+	}
+	if !pl.UKNationName.IsUnknown() && covers(pl.UKNationName.Name) {
+		return true
+	}
+	if !pl.CountryName.IsUnknown() && covers(pl.CountryName.Name) {
+		return true
+	}
+	return false
+}
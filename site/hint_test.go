@@ -0,0 +1,133 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/place"
+	"github.com/iand/genster/tree"
+)
+
+func TestURLHintProvider(t *testing.T) {
+	sg := &tree.SurnameGroups{}
+	sg.AddGroup("Smith", []string{"Smyth"})
+
+	cfg := &tree.HintProviderConfig{
+		Name:      "FreeBMD",
+		URL:       "https://example.com/search?type={type}&surname={surname}&given={given}&start={from}&end={to}&district={district}",
+		Events:    map[string]string{"birth": "Births"},
+		From:      1837,
+		Countries: []string{"England", "Wales"},
+		Span:      2,
+		Variants:  true,
+	}
+	hp := NewURLHintProvider(cfg, sg)
+
+	royston := &model.Place{ID: "pl2", Name: "Royston"}
+	england := &model.Place{ID: "pl1", District: royston, UKNationName: &place.PlaceName{Name: "England"}}
+	scotland := &model.Place{ID: "pl3", UKNationName: &place.PlaceName{Name: "Scotland"}}
+	hitchin := &model.Place{ID: "pl4", Name: "Hitchin"}
+
+	p := &model.Person{ID: "p1", PreferredGivenName: "Mary Ann", PreferredFamilyName: "Smith"}
+	birth := func(dt *model.Date, pl *model.Place) model.TimelineEvent {
+		return &model.BirthEvent{
+			GeneralEvent:           model.GeneralEvent{Date: dt, Place: pl},
+			GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		ev   model.TimelineEvent
+		want []model.Link
+	}{
+		{
+			name: "variants",
+			ev:   birth(model.Year(1850), england),
+			want: []model.Link{
+				{Title: "FreeBMD birth search for Smith", URL: "https://example.com/search?type=Births&surname=Smith&given=Mary+Ann&start=1848&end=1852&district=Royston", Category: model.LinkCategorySearch},
+				{Title: "FreeBMD birth search for Smyth", URL: "https://example.com/search?type=Births&surname=Smyth&given=Mary+Ann&start=1848&end=1852&district=Royston", Category: model.LinkCategorySearch},
+			},
+		},
+		{
+			name: "registration district",
+			ev: &model.BirthEvent{
+				GeneralEvent:           model.GeneralEvent{Date: model.Year(1850), Place: england, Registration: &model.Registration{District: hitchin, Year: 1850}},
+				GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+			},
+			want: []model.Link{
+				{Title: "FreeBMD birth search for Smith", URL: "https://example.com/search?type=Births&surname=Smith&given=Mary+Ann&start=1848&end=1852&district=Hitchin", Category: model.LinkCategorySearch},
+				{Title: "FreeBMD birth search for Smyth", URL: "https://example.com/search?type=Births&surname=Smyth&given=Mary+Ann&start=1848&end=1852&district=Hitchin", Category: model.LinkCategorySearch},
+			},
+		},
+		{
+			name: "before coverage",
+			ev:   birth(model.Year(1830), england),
+		},
+		{
+			name: "other country",
+			ev:   birth(model.Year(1850), scotland),
+		},
+		{
+			name: "unknown date",
+			ev:   birth(model.UnknownDate(), england),
+		},
+		{
+			name: "other event",
+			ev: &model.DeathEvent{
+				GeneralEvent:           model.GeneralEvent{Date: model.Year(1850), Place: england},
+				GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hp.Hints(p, tt.ev)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("hints mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPersonResearchHints(t *testing.T) {
+	sg := &tree.SurnameGroups{}
+	sg.AddGroup("Smith", []string{"Smyth", "Smijth", "Smythe", "Smithe", "Smeeth"})
+
+	cfg := &tree.HintProviderConfig{
+		Name:     "Archive",
+		URL:      "https://example.com/search?surname={surname}&year={year}",
+		Events:   map[string]string{"birth": "", "marriage": "", "death": ""},
+		Variants: true,
+	}
+	s := &Site{HintProviders: []HintProvider{NewURLHintProvider(cfg, sg), NewURLHintProvider(cfg, sg)}}
+
+	p := &model.Person{ID: "p1", PreferredGivenName: "John", PreferredFamilyName: "Smith"}
+	p.BestBirthlikeEvent = &model.BirthEvent{
+		GeneralEvent:           model.GeneralEvent{Date: model.Year(1850)},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+	}
+	p.BestDeathlikeEvent = &model.DeathEvent{
+		GeneralEvent:           model.GeneralEvent{Date: model.Year(1900)},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+	}
+
+	got := s.personResearchHints(p)
+	if len(got) != maxPersonHints {
+		t.Fatalf("got %d hints, wanted %d", len(got), maxPersonHints)
+	}
+
+	seen := make(map[string]bool)
+	for _, l := range got {
+		if seen[l.URL] {
+			t.Errorf("duplicate hint %s", l.URL)
+		}
+		seen[l.URL] = true
+	}
+	for _, url := range []string{"https://example.com/search?surname=Smith&year=1850", "https://example.com/search?surname=Smith&year=1900"} {
+		if !seen[url] {
+			t.Errorf("missing hint %s", url)
+		}
+	}
+}
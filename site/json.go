@@ -0,0 +1,531 @@
+package site
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iand/genster/model"
+)
+
+// JSONIndexFile is the name of the JSON document that lists every object in
+// the tree. It is written to the root of the tree's content directory.
+const JSONIndexFile = "index.json"
+
+// A JSONRef is a reference from one JSON document to an object in the tree.
+type JSONRef struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`  // link to the object's page, omitted when the object has no page
+	Data string `json:"data,omitempty"` // link to the object's JSON document
+}
+
+// A JSONParticipant is a person taking part in an event.
+type JSONParticipant struct {
+	JSONRef
+	Role string `json:"role"`
+}
+
+// A JSONEvent is an event in the timeline of a person, place or family.
+type JSONEvent struct {
+	Type         string            `json:"type"`
+	Date         string            `json:"date,omitempty"`
+	Year         int               `json:"year,omitempty"`
+	Place        *JSONRef          `json:"place,omitempty"`
+	Detail       string            `json:"detail,omitempty"`
+	Inferred     bool              `json:"inferred,omitempty"`
+	Participants []JSONParticipant `json:"participants,omitempty"`
+	Citations    []JSONRef         `json:"citations,omitempty"`
+}
+
+// A JSONLink is a link to more information about an object.
+type JSONLink struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// A JSONOccupation is an occupation followed by a person.
+type JSONOccupation struct {
+	Name     string   `json:"name"`
+	Recorded string   `json:"recorded,omitempty"` // wording of the record, if different from the name
+	Code     string   `json:"hisco,omitempty"`
+	Class    string   `json:"class,omitempty"` // social class as a roman numeral
+	Date     string   `json:"date,omitempty"`
+	Place    *JSONRef `json:"place,omitempty"`
+}
+
+// JSONPerson is the JSON document written for a person.
+type JSONPerson struct {
+	ID             string           `json:"id"`
+	Name           string           `json:"name"`
+	SortName       string           `json:"sortName,omitempty"`
+	KnownNames     []string         `json:"knownNames,omitempty"`
+	Gender         string           `json:"gender,omitempty"`
+	VitalYears     string           `json:"vitalYears,omitempty"`
+	URL            string           `json:"url,omitempty"`
+	WikiTreeID     string           `json:"wikiTreeId,omitempty"`
+	FamilySearchID string           `json:"familySearchId,omitempty"`
+	Father         *JSONRef         `json:"father,omitempty"`
+	Mother         *JSONRef         `json:"mother,omitempty"`
+	Spouses        []JSONRef        `json:"spouses,omitempty"`
+	Children       []JSONRef        `json:"children,omitempty"`
+	Families       []JSONRef        `json:"families,omitempty"`
+	Occupations    []JSONOccupation `json:"occupations,omitempty"`
+	Events         []JSONEvent      `json:"events,omitempty"`
+	Completeness   int              `json:"completeness"`
+	Tags           []string         `json:"tags,omitempty"`
+	Links          []JSONLink       `json:"links,omitempty"`
+}
+
+// JSONPlace is the JSON document written for a place.
+type JSONPlace struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	FullName  string      `json:"fullName,omitempty"`
+	Type      string      `json:"type,omitempty"`
+	URL       string      `json:"url,omitempty"`
+	Parent    *JSONRef    `json:"parent,omitempty"`
+	Latitude  *float64    `json:"latitude,omitempty"`
+	Longitude *float64    `json:"longitude,omitempty"`
+	Events    []JSONEvent `json:"events,omitempty"`
+	Tags      []string    `json:"tags,omitempty"`
+	Links     []JSONLink  `json:"links,omitempty"`
+}
+
+// JSONSource is the JSON document written for a source.
+type JSONSource struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	Author     string     `json:"author,omitempty"`
+	Repository string     `json:"repository,omitempty"`
+	SearchLink string     `json:"searchLink,omitempty"`
+	Citations  []JSONRef  `json:"citations,omitempty"`
+	Tags       []string   `json:"tags,omitempty"`
+	Links      []JSONLink `json:"links,omitempty"`
+}
+
+// JSONCitation is the JSON document written for a citation.
+type JSONCitation struct {
+	ID     string      `json:"id"`
+	Detail string      `json:"detail,omitempty"`
+	Date   string      `json:"date,omitempty"`
+	URL    string      `json:"url,omitempty"`
+	Source *JSONRef    `json:"source,omitempty"`
+	Link   *JSONLink   `json:"link,omitempty"`
+	People []JSONRef   `json:"people,omitempty"`
+	Events []JSONEvent `json:"events,omitempty"`
+}
+
+// JSONFamily is the JSON document written for a family.
+type JSONFamily struct {
+	ID        string      `json:"id"`
+	Name      string      `json:"name"`
+	Bond      string      `json:"bond,omitempty"`
+	URL       string      `json:"url,omitempty"`
+	Father    *JSONRef    `json:"father,omitempty"`
+	Mother    *JSONRef    `json:"mother,omitempty"`
+	Children  []JSONRef   `json:"children,omitempty"`
+	StartDate string      `json:"startDate,omitempty"`
+	EndDate   string      `json:"endDate,omitempty"`
+	EndReason string      `json:"endReason,omitempty"`
+	Events    []JSONEvent `json:"events,omitempty"`
+}
+
+// JSONIndex is the top-level JSON document listing every object that has a
+// JSON document of its own.
+type JSONIndex struct {
+	Tree      string    `json:"tree,omitempty"`
+	KeyPerson *JSONRef  `json:"keyPerson,omitempty"`
+	People    []JSONRef `json:"people"`
+	Places    []JSONRef `json:"places"`
+	Sources   []JSONRef `json:"sources"`
+	Citations []JSONRef `json:"citations"`
+	Families  []JSONRef `json:"families"`
+}
+
+// WriteJSONData writes a JSON document alongside the page of every published
+// person, place, citation and family, one for every published source, and a
+// top-level index. The documents are built from the same model data as the
+// markdown pages so redacted people are left out in the same way.
+func (s *Site) WriteJSONData(contentDir string) error {
+	idx := JSONIndex{
+		Tree:      s.Tree.Name,
+		People:    []JSONRef{},
+		Places:    []JSONRef{},
+		Sources:   []JSONRef{},
+		Citations: []JSONRef{},
+		Families:  []JSONRef{},
+	}
+	if ref := s.jsonRefForPerson(s.PublishSet.KeyPerson); ref != nil && ref.URL != "" {
+		idx.KeyPerson = ref
+	}
+
+	for _, p := range s.PublishSet.People {
+		if s.LinkFor(p) == "" {
+			continue
+		}
+		if err := writeJSON(contentDir, jsonFileFor(s.PersonFilePattern, p.ID), s.jsonPerson(p)); err != nil {
+			return fmt.Errorf("write person json: %w", err)
+		}
+		idx.People = append(idx.People, *s.jsonRefForPerson(p))
+	}
+
+	for _, pl := range s.PublishSet.Places {
+		if s.LinkFor(pl) == "" {
+			continue
+		}
+		if err := writeJSON(contentDir, jsonFileFor(s.PlaceFilePattern, pl.ID), s.jsonPlace(pl)); err != nil {
+			return fmt.Errorf("write place json: %w", err)
+		}
+		idx.Places = append(idx.Places, *s.jsonRefForPlace(pl))
+	}
+
+	for _, so := range s.PublishSet.Sources {
+		if so.IsUnknown() || s.LinkFor(so) == "" {
+			continue
+		}
+		if err := writeJSON(contentDir, jsonFileFor(s.SourceFilePattern, so.ID), s.jsonSource(so)); err != nil {
+			return fmt.Errorf("write source json: %w", err)
+		}
+		idx.Sources = append(idx.Sources, *s.jsonRefForSource(so))
+	}
+
+	for _, c := range s.PublishSet.Citations {
+		if c.Redacted || s.LinkFor(c) == "" {
+			continue
+		}
+		if err := writeJSON(contentDir, jsonFileFor(s.CitationFilePattern, c.ID), s.jsonCitation(c)); err != nil {
+			return fmt.Errorf("write citation json: %w", err)
+		}
+		idx.Citations = append(idx.Citations, *s.jsonRefForCitation(c))
+	}
+
+	for _, f := range s.PublishSet.Families {
+		if f.Unknown || s.LinkFor(f) == "" {
+			continue
+		}
+		if err := writeJSON(contentDir, jsonFileFor(s.FamilyFilePattern, f.ID), s.jsonFamily(f)); err != nil {
+			return fmt.Errorf("write family json: %w", err)
+		}
+		idx.Families = append(idx.Families, *s.jsonRefForFamily(f))
+	}
+
+	for _, refs := range [][]JSONRef{idx.People, idx.Places, idx.Sources, idx.Citations, idx.Families} {
+		sortJSONRefs(refs)
+	}
+
+	if err := writeJSON(contentDir, JSONIndexFile, idx); err != nil {
+		return fmt.Errorf("write json index: %w", err)
+	}
+	return nil
+}
+
+// pageLinkFor returns the link to the page written for v, or an empty string
+// if no page is written for it.
+func (s *Site) pageLinkFor(v any) string {
+	switch v.(type) {
+	case *model.Source:
+		// Source pages are not published at this time
+		return ""
+	case *model.Family:
+		if !s.ExperimentFamilies {
+			return ""
+		}
+	}
+	return s.LinkFor(v)
+}
+
+// jsonRef returns a reference to v, which has the given id and name. The id
+// and links are only included when v is published so that the JSON does not
+// reveal anything about objects that have been left out of the site.
+func (s *Site) jsonRef(v any, id, name string) *JSONRef {
+	link := s.LinkFor(v)
+	if link == "" {
+		return &JSONRef{Name: name}
+	}
+	return &JSONRef{
+		ID:   id,
+		Name: name,
+		URL:  s.pageLinkFor(v),
+		Data: path.Join(link, "index.json"),
+	}
+}
+
+func (s *Site) jsonRefForPerson(p *model.Person) *JSONRef {
+	if p.IsUnknown() {
+		return nil
+	}
+	return s.jsonRef(p, p.ID, p.PreferredUniqueName)
+}
+
+func (s *Site) jsonRefForPlace(pl *model.Place) *JSONRef {
+	if pl.IsUnknown() {
+		return nil
+	}
+	return s.jsonRef(pl, pl.ID, pl.FullName)
+}
+
+func (s *Site) jsonRefForSource(so *model.Source) *JSONRef {
+	if so.IsUnknown() {
+		return nil
+	}
+	return s.jsonRef(so, so.ID, so.Title)
+}
+
+func (s *Site) jsonRefForCitation(c *model.GeneralCitation) *JSONRef {
+	if c == nil || c.Redacted {
+		return nil
+	}
+	name := c.Detail
+	if !c.Source.IsUnknown() {
+		name = strings.TrimSpace(c.Source.Title + ", " + c.Detail)
+		name = strings.TrimSuffix(name, ",")
+	}
+	return s.jsonRef(c, c.ID, name)
+}
+
+func (s *Site) jsonRefForFamily(f *model.Family) *JSONRef {
+	if f == nil || f.Unknown {
+		return nil
+	}
+	return s.jsonRef(f, f.ID, f.PreferredUniqueName)
+}
+
+func (s *Site) jsonRefsForPeople(people []*model.Person) []JSONRef {
+	var refs []JSONRef
+	for _, p := range people {
+		if ref := s.jsonRefForPerson(p); ref != nil {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+func (s *Site) jsonRefsForCitations(cits []*model.GeneralCitation) []JSONRef {
+	var refs []JSONRef
+	for _, c := range cits {
+		if ref := s.jsonRefForCitation(c); ref != nil {
+			refs = append(refs, *ref)
+		}
+	}
+	return refs
+}
+
+// jsonEvents converts the published events of a timeline.
+func (s *Site) jsonEvents(evs []model.TimelineEvent) []JSONEvent {
+	var out []JSONEvent
+	for _, ev := range evs {
+		if !s.PublishSet.Includes(ev) {
+			continue
+		}
+		je := JSONEvent{
+			Type:      ev.Type(),
+			Place:     s.jsonRefForPlace(ev.GetPlace()),
+			Detail:    ev.GetDetail(),
+			Inferred:  ev.IsInferred(),
+			Citations: s.jsonRefsForCitations(ev.GetCitations()),
+		}
+		if dt := ev.GetDate(); !dt.IsUnknown() {
+			je.Date = dt.String()
+			je.Year, _ = dt.Year()
+		}
+
+		seen := make(map[*model.Person]model.EventRole)
+		addParticipant := func(p *model.Person, role model.EventRole) {
+			ref := s.jsonRefForPerson(p)
+			if ref == nil || seen[p] == role {
+				return
+			}
+			seen[p] = role
+			je.Participants = append(je.Participants, JSONParticipant{JSONRef: *ref, Role: string(role)})
+		}
+		switch tev := ev.(type) {
+		case model.IndividualTimelineEvent:
+			addParticipant(tev.GetPrincipal(), model.EventRolePrincipal)
+		case model.UnionTimelineEvent:
+			addParticipant(tev.GetHusband(), model.EventRoleHusband)
+			addParticipant(tev.GetWife(), model.EventRoleWife)
+		case model.MultipartyTimelineEvent:
+			for _, p := range tev.GetPrincipals() {
+				addParticipant(p, model.EventRolePrincipal)
+			}
+		}
+		for _, ep := range ev.GetParticipants() {
+			addParticipant(ep.Person, ep.Role)
+		}
+		out = append(out, je)
+	}
+	return out
+}
+
+func jsonLinks(links []model.Link) []JSONLink {
+	var out []JSONLink
+	for _, l := range links {
+		out = append(out, JSONLink{Title: l.Title, URL: l.URL})
+	}
+	return out
+}
+
+func (s *Site) jsonPerson(p *model.Person) JSONPerson {
+	jp := JSONPerson{
+		ID:             p.ID,
+		Name:           p.PreferredFullName,
+		SortName:       p.PreferredSortName,
+		Gender:         "unknown",
+		VitalYears:     p.VitalYears,
+		URL:            s.pageLinkFor(p),
+		WikiTreeID:     p.WikiTreeID,
+		FamilySearchID: p.FamilySearchID,
+		Father:         s.jsonRefForPerson(p.Father),
+		Mother:         s.jsonRefForPerson(p.Mother),
+		Spouses:        s.jsonRefsForPeople(p.Spouses),
+		Children:       s.jsonRefsForPeople(p.Children),
+		Events:         s.jsonEvents(p.Timeline),
+		Completeness:   p.Completeness.Score(),
+		Tags:           p.Tags,
+		Links:          jsonLinks(p.Links),
+	}
+	// same values as the gender front-matter field of the person page
+	switch p.Gender {
+	case model.GenderMale:
+		jp.Gender = "male"
+	case model.GenderFemale:
+		jp.Gender = "female"
+	}
+	for _, n := range p.KnownNames {
+		if n.Name != p.PreferredFullName {
+			jp.KnownNames = append(jp.KnownNames, n.Name)
+		}
+	}
+	for _, f := range p.Families {
+		if ref := s.jsonRefForFamily(f); ref != nil {
+			jp.Families = append(jp.Families, *ref)
+		}
+	}
+	for _, o := range p.Occupations {
+		jo := JSONOccupation{Name: o.Name, Code: o.Code, Class: o.Class.Numeral(), Place: s.jsonRefForPlace(o.Place)}
+		if !strings.EqualFold(o.Detail, o.Name) {
+			jo.Recorded = o.Detail
+		}
+		if !o.Date.IsUnknown() {
+			jo.Date = o.Date.String()
+		}
+		jp.Occupations = append(jp.Occupations, jo)
+	}
+	return jp
+}
+
+func (s *Site) jsonPlace(pl *model.Place) JSONPlace {
+	jp := JSONPlace{
+		ID:       pl.ID,
+		Name:     pl.Name,
+		FullName: pl.FullName,
+		Type:     pl.PlaceType.String(),
+		URL:      s.pageLinkFor(pl),
+		Parent:   s.jsonRefForPlace(pl.Parent),
+		Events:   s.jsonEvents(pl.Timeline),
+		Tags:     pl.Tags,
+		Links:    jsonLinks(pl.Links),
+	}
+	if pl.GeoLocation != nil {
+		jp.Latitude = &pl.GeoLocation.Latitude
+		jp.Longitude = &pl.GeoLocation.Longitude
+	}
+	return jp
+}
+
+func (s *Site) jsonSource(so *model.Source) JSONSource {
+	js := JSONSource{
+		ID:         so.ID,
+		Title:      so.Title,
+		Author:     so.Author,
+		Repository: so.RepositoryName,
+		SearchLink: so.SearchLink,
+		Tags:       so.Tags,
+		Links:      jsonLinks(so.Links),
+	}
+	for _, c := range s.PublishSet.Citations {
+		if c.Source != so {
+			continue
+		}
+		if ref := s.jsonRefForCitation(c); ref != nil && ref.ID != "" {
+			js.Citations = append(js.Citations, *ref)
+		}
+	}
+	sortJSONRefs(js.Citations)
+	return js
+}
+
+func (s *Site) jsonCitation(c *model.GeneralCitation) JSONCitation {
+	jc := JSONCitation{
+		ID:     c.ID,
+		Detail: c.Detail,
+		URL:    s.pageLinkFor(c),
+		Source: s.jsonRefForSource(c.Source),
+		People: s.jsonRefsForPeople(c.PeopleCited),
+		Events: s.jsonEvents(c.EventsCited),
+	}
+	if !c.Date.IsUnknown() {
+		jc.Date = c.Date.String()
+	}
+	if c.URL != nil {
+		jc.Link = &JSONLink{Title: c.URL.Title, URL: c.URL.URL}
+	}
+	return jc
+}
+
+func (s *Site) jsonFamily(f *model.Family) JSONFamily {
+	jf := JSONFamily{
+		ID:        f.ID,
+		Name:      f.PreferredFullName,
+		Bond:      f.Bond,
+		URL:       s.pageLinkFor(f),
+		Father:    s.jsonRefForPerson(f.Father),
+		Mother:    s.jsonRefForPerson(f.Mother),
+		Children:  s.jsonRefsForPeople(f.Children),
+		EndReason: f.EndReason,
+		Events:    s.jsonEvents(f.Timeline),
+	}
+	if !f.BestStartDate.IsUnknown() {
+		jf.StartDate = f.BestStartDate.String()
+	}
+	if !f.BestEndDate.IsUnknown() {
+		jf.EndDate = f.BestEndDate.String()
+	}
+	return jf
+}
+
+// sortJSONRefs sorts references by name and then id so that documents are
+// written in a stable order.
+func sortJSONRefs(refs []JSONRef) {
+	sort.SliceStable(refs, func(i, j int) bool {
+		if refs[i].Name != refs[j].Name {
+			return refs[i].Name < refs[j].Name
+		}
+		return refs[i].ID < refs[j].ID
+	})
+}
+
+// jsonFileFor returns the name of the JSON document written alongside the
+// page whose file name is given by pattern and id.
+func jsonFileFor(pattern, id string) string {
+	return strings.TrimSuffix(fmt.Sprintf(pattern, id), ".md") + ".json"
+}
+
+func writeJSON(contentDir, fname string, v any) error {
+	f, err := CreateFile(filepath.Join(contentDir, fname))
+	if err != nil {
+		return fmt.Errorf("create json file: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+	return nil
+}
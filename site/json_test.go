@@ -0,0 +1,99 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/tree"
+)
+
+func TestWriteJSONData(t *testing.T) {
+	so := &model.Source{ID: "S1", Title: "Parish register"}
+	cit := &model.GeneralCitation{ID: "C1", Source: so, Detail: "folio 3"}
+	pl := &model.Place{ID: "P1", Name: "Harston", FullName: "Harston, Cambridgeshire", PlaceType: model.PlaceTypeVillage}
+
+	father := &model.Person{ID: "I2", PreferredFullName: "(living or recently deceased person)", PreferredUniqueName: "(living or recently deceased person)", Redacted: true}
+	john := &model.Person{ID: "I1", PreferredFullName: "John Smith", PreferredUniqueName: "John Smith (1850-1910)", Gender: model.GenderMale, Father: father}
+	birth := &model.BirthEvent{
+		GeneralEvent:           model.GeneralEvent{Date: model.PreciseDate(1850, 6, 1), Place: pl, Citations: []*model.GeneralCitation{cit}},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: john},
+	}
+	john.Timeline = []model.TimelineEvent{birth}
+	pl.Timeline = []model.TimelineEvent{birth}
+	cit.EventsCited = []model.TimelineEvent{birth}
+	cit.PeopleCited = []*model.Person{john}
+
+	tr := tree.NewTree("t", nil, nil)
+	tr.Name = "Smith Family"
+	s := NewSite("/trees/t", tr)
+	s.PublishSet = &PublishSet{
+		KeyPerson: john,
+		People:    map[string]*model.Person{"I1": john, "I2": father},
+		Places:    map[string]*model.Place{"P1": pl},
+		Citations: map[string]*model.GeneralCitation{"C1": cit},
+		Sources:   map[string]*model.Source{"S1": so},
+		Families:  map[string]*model.Family{},
+		Events:    map[model.TimelineEvent]bool{birth: true},
+	}
+
+	dir := t.TempDir()
+	if err := s.WriteJSONData(dir); err != nil {
+		t.Fatalf("WriteJSONData: %v", err)
+	}
+
+	readJSON := func(fname string, v any) {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, fname))
+		if err != nil {
+			t.Fatalf("read %s: %v", fname, err)
+		}
+		if err := json.Unmarshal(data, v); err != nil {
+			t.Fatalf("unmarshal %s: %v", fname, err)
+		}
+	}
+
+	var jp JSONPerson
+	readJSON("person/I1/index.json", &jp)
+	johnRef := JSONRef{ID: "I1", Name: "John Smith (1850-1910)", URL: "/trees/t/person/I1", Data: "/trees/t/person/I1/index.json"}
+	want := JSONPerson{
+		ID:     "I1",
+		Name:   "John Smith",
+		Gender: "male",
+		URL:    "/trees/t/person/I1",
+		Father: &JSONRef{Name: "(living or recently deceased person)"},
+		Events: []JSONEvent{{
+			Type:         "birth",
+			Date:         model.PreciseDate(1850, 6, 1).String(),
+			Year:         1850,
+			Place:        &JSONRef{ID: "P1", Name: "Harston, Cambridgeshire", URL: "/trees/t/place/P1", Data: "/trees/t/place/P1/index.json"},
+			Participants: []JSONParticipant{{JSONRef: johnRef, Role: "principal"}},
+			Citations:    []JSONRef{{ID: "C1", Name: "Parish register, folio 3", URL: "/trees/t/citation/C1", Data: "/trees/t/citation/C1/index.json"}},
+		}},
+	}
+	if diff := cmp.Diff(want, jp); diff != "" {
+		t.Errorf("person json mismatch (-want, +got):\n%s", diff)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "person", "I2", "index.json")); err == nil {
+		t.Errorf("json written for redacted person")
+	}
+
+	var js JSONSource
+	readJSON("source/S1/index.json", &js)
+	if len(js.Citations) != 1 || js.Citations[0].ID != "C1" {
+		t.Errorf("got source citations %+v, wanted C1", js.Citations)
+	}
+
+	var idx JSONIndex
+	readJSON(JSONIndexFile, &idx)
+	if idx.Tree != "Smith Family" || len(idx.People) != 1 || len(idx.Places) != 1 || len(idx.Sources) != 1 || len(idx.Citations) != 1 {
+		t.Errorf("got index %+v", idx)
+	}
+	if idx.KeyPerson == nil || *idx.KeyPerson != johnRef {
+		t.Errorf("got key person %+v, wanted %+v", idx.KeyPerson, johnRef)
+	}
+}
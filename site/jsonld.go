@@ -0,0 +1,179 @@
+package site
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/iand/genster/model"
+)
+
+// schemaContext is the JSON-LD context of schema.org descriptions.
+const schemaContext = "https://schema.org"
+
+// An ldPerson is a schema.org Person.
+type ldPerson struct {
+	Context    string     `json:"@context,omitempty"`
+	Type       string     `json:"@type"`
+	ID         string     `json:"@id,omitempty"`
+	Name       string     `json:"name"`
+	GivenName  string     `json:"givenName,omitempty"`
+	FamilyName string     `json:"familyName,omitempty"`
+	Gender     string     `json:"gender,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	BirthDate  string     `json:"birthDate,omitempty"`
+	BirthPlace *ldPlace   `json:"birthPlace,omitempty"`
+	DeathDate  string     `json:"deathDate,omitempty"`
+	DeathPlace *ldPlace   `json:"deathPlace,omitempty"`
+	Parent     []ldPerson `json:"parent,omitempty"`
+	Spouse     []ldPerson `json:"spouse,omitempty"`
+	Children   []ldPerson `json:"children,omitempty"`
+	SameAs     []string   `json:"sameAs,omitempty"`
+}
+
+// An ldPlace is a schema.org Place.
+type ldPlace struct {
+	Context          string   `json:"@context,omitempty"`
+	Type             string   `json:"@type"`
+	ID               string   `json:"@id,omitempty"`
+	Name             string   `json:"name"`
+	URL              string   `json:"url,omitempty"`
+	ContainedInPlace *ldPlace `json:"containedInPlace,omitempty"`
+	Geo              *ldGeo   `json:"geo,omitempty"`
+}
+
+// An ldGeo is a schema.org GeoCoordinates.
+type ldGeo struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// PersonJSONLD returns a schema.org Person description of p encoded as
+// JSON-LD, or an empty string if p is redacted. Redacted relatives are left
+// out entirely. Birth and death details are omitted for people who may still
+// be alive.
+func (s *Site) PersonJSONLD(p *model.Person) string {
+	if p.IsUnknown() || p.Redacted {
+		return ""
+	}
+
+	lp := s.ldPersonRef(p)
+	lp.Context = schemaContext
+	lp.GivenName = p.PreferredGivenName
+	lp.FamilyName = p.PreferredFamilyName
+	switch p.Gender {
+	case model.GenderMale:
+		lp.Gender = "Male"
+	case model.GenderFemale:
+		lp.Gender = "Female"
+	}
+
+	if !p.PossiblyAlive {
+		if ev, ok := p.BestBirthlikeEvent.(*model.BirthEvent); ok {
+			lp.BirthDate, _ = ev.GetDate().ISO8601()
+			lp.BirthPlace = s.ldPlaceRef(ev.GetPlace())
+		}
+		if ev, ok := p.BestDeathlikeEvent.(*model.DeathEvent); ok {
+			lp.DeathDate, _ = ev.GetDate().ISO8601()
+			lp.DeathPlace = s.ldPlaceRef(ev.GetPlace())
+		}
+	}
+
+	for _, parent := range []*model.Person{p.Father, p.Mother} {
+		if parent.IsUnknown() || parent.Redacted {
+			continue
+		}
+		lp.Parent = append(lp.Parent, s.ldPersonRef(parent))
+	}
+	for _, sp := range p.Spouses {
+		if sp.IsUnknown() || sp.Redacted {
+			continue
+		}
+		lp.Spouse = append(lp.Spouse, s.ldPersonRef(sp))
+	}
+	for _, ch := range p.Children {
+		if ch.IsUnknown() || ch.Redacted {
+			continue
+		}
+		lp.Children = append(lp.Children, s.ldPersonRef(ch))
+	}
+
+	if p.WikiTreeID != "" {
+		lp.SameAs = append(lp.SameAs, "https://www.wikitree.com/wiki/"+p.WikiTreeID)
+	}
+	if p.FamilySearchID != "" {
+		lp.SameAs = append(lp.SameAs, "https://www.familysearch.org/tree/person/details/"+p.FamilySearchID)
+	}
+
+	return encodeJSONLD(lp)
+}
+
+// PlaceJSONLD returns a schema.org Place description of pl encoded as JSON-LD,
+// including its coordinates when known.
+func (s *Site) PlaceJSONLD(pl *model.Place) string {
+	if pl.IsUnknown() {
+		return ""
+	}
+	lp := s.ldPlaceRef(pl)
+	lp.Context = schemaContext
+	lp.ContainedInPlace = s.ldPlaceRef(pl.Parent)
+	if pl.GeoLocation != nil {
+		lp.Geo = &ldGeo{
+			Type:      "GeoCoordinates",
+			Latitude:  pl.GeoLocation.Latitude,
+			Longitude: pl.GeoLocation.Longitude,
+		}
+	}
+	return encodeJSONLD(lp)
+}
+
+func (s *Site) ldPersonRef(p *model.Person) ldPerson {
+	u := s.absoluteLink(s.LinkFor(p))
+	return ldPerson{
+		Type: "Person",
+		ID:   u,
+		Name: p.PreferredFullName,
+		URL:  u,
+	}
+}
+
+func (s *Site) ldPlaceRef(pl *model.Place) *ldPlace {
+	if pl.IsUnknown() {
+		return nil
+	}
+	u := s.absoluteLink(s.LinkFor(pl))
+	return &ldPlace{
+		Type: "Place",
+		ID:   u,
+		Name: pl.FullName,
+		URL:  u,
+	}
+}
+
+// absoluteLink returns link, a path on the site, as an absolute URL of the
+// site. schema.org identifiers must be absolute so an empty string is returned
+// when there is no SiteURL.
+func (s *Site) absoluteLink(link string) string {
+	if link == "" || s.SiteURL == "" {
+		return ""
+	}
+	base, err := url.Parse(s.SiteURL)
+	if err != nil || !base.IsAbs() {
+		return ""
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// encodeJSONLD encodes v as compact JSON. It returns an empty string if v
+// cannot be encoded, which can only happen with invalid coordinates.
+func encodeJSONLD(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,173 @@
+package site
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/tree"
+)
+
+func TestPersonJSONLD(t *testing.T) {
+	pl := &model.Place{ID: "P1", Name: "Harston", FullName: "Harston, Cambridgeshire"}
+	newPerson := func(possiblyAlive bool) *model.Person {
+		father := &model.Person{ID: "I2", PreferredFullName: "William Smith"}
+		mother := &model.Person{ID: "I3", PreferredFullName: "(living or recently deceased person)", Redacted: true}
+		p := &model.Person{
+			ID:                  "I1",
+			PreferredFullName:   "John Smith",
+			PreferredGivenName:  "John",
+			PreferredFamilyName: "Smith",
+			Gender:              model.GenderMale,
+			Father:              father,
+			Mother:              mother,
+			WikiTreeID:          "Smith-1",
+			PossiblyAlive:       possiblyAlive,
+		}
+		p.BestBirthlikeEvent = &model.BirthEvent{
+			GeneralEvent:           model.GeneralEvent{Date: model.PreciseDate(1850, 6, 1), Place: pl},
+			GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+		}
+		return p
+	}
+
+	for _, tt := range []struct {
+		name string
+		p    *model.Person
+		want map[string]any
+	}{
+		{
+			name: "historic",
+			p:    newPerson(false),
+			want: map[string]any{
+				"@context":   "https://schema.org",
+				"@type":      "Person",
+				"@id":        "https://example.com/trees/t/person/I1",
+				"name":       "John Smith",
+				"givenName":  "John",
+				"familyName": "Smith",
+				"gender":     "Male",
+				"url":        "https://example.com/trees/t/person/I1",
+				"birthDate":  "1850-06-01",
+				"birthPlace": map[string]any{"@type": "Place", "@id": "https://example.com/trees/t/place/P1", "name": "Harston, Cambridgeshire", "url": "https://example.com/trees/t/place/P1"},
+				"parent":     []any{map[string]any{"@type": "Person", "@id": "https://example.com/trees/t/person/I2", "name": "William Smith", "url": "https://example.com/trees/t/person/I2"}},
+				"sameAs":     []any{"https://www.wikitree.com/wiki/Smith-1"},
+			},
+		},
+		{
+			name: "possibly alive",
+			p:    newPerson(true),
+			want: map[string]any{
+				"@context":   "https://schema.org",
+				"@type":      "Person",
+				"@id":        "https://example.com/trees/t/person/I1",
+				"name":       "John Smith",
+				"givenName":  "John",
+				"familyName": "Smith",
+				"gender":     "Male",
+				"url":        "https://example.com/trees/t/person/I1",
+				"parent":     []any{map[string]any{"@type": "Person", "@id": "https://example.com/trees/t/person/I2", "name": "William Smith", "url": "https://example.com/trees/t/person/I2"}},
+				"sameAs":     []any{"https://www.wikitree.com/wiki/Smith-1"},
+			},
+		},
+		{
+			name: "redacted",
+			p:    &model.Person{ID: "I3", PreferredFullName: "(living or recently deceased person)", Redacted: true},
+			want: nil,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSite("/trees/t", tree.NewTree("t", nil, nil))
+			s.SiteURL = "https://example.com"
+			s.PublishSet = &PublishSet{
+				People: map[string]*model.Person{"I1": tt.p, "I2": tt.p.Father},
+				Places: map[string]*model.Place{"P1": pl},
+			}
+			ld := s.PersonJSONLD(tt.p)
+			if tt.want == nil {
+				if ld != "" {
+					t.Errorf("got %s, wanted no JSON-LD", ld)
+				}
+				return
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal([]byte(ld), &got); err != nil {
+				t.Fatalf("unmarshal %q: %v", ld, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("JSON-LD mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPlaceJSONLD(t *testing.T) {
+	county := &model.Place{ID: "P2", Name: "Cambridgeshire", FullName: "Cambridgeshire"}
+	pl := &model.Place{
+		ID:          "P1",
+		Name:        "Harston",
+		FullName:    "Harston, Cambridgeshire",
+		Parent:      county,
+		GeoLocation: &model.GeoLocation{Latitude: 52.14, Longitude: 0.08},
+	}
+
+	s := NewSite("/trees/t", tree.NewTree("t", nil, nil))
+	s.SiteURL = "https://example.com"
+	s.PublishSet = &PublishSet{Places: map[string]*model.Place{"P1": pl, "P2": county}}
+	var got map[string]any
+	if err := json.Unmarshal([]byte(s.PlaceJSONLD(pl)), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := map[string]any{
+		"@context":         "https://schema.org",
+		"@type":            "Place",
+		"@id":              "https://example.com/trees/t/place/P1",
+		"name":             "Harston, Cambridgeshire",
+		"url":              "https://example.com/trees/t/place/P1",
+		"containedInPlace": map[string]any{"@type": "Place", "@id": "https://example.com/trees/t/place/P2", "name": "Cambridgeshire", "url": "https://example.com/trees/t/place/P2"},
+		"geo":              map[string]any{"@type": "GeoCoordinates", "latitude": 52.14, "longitude": 0.08},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("JSON-LD mismatch (-want, +got):\n%s", diff)
+	}
+}
+
+func TestJSONLDWithoutSiteURL(t *testing.T) {
+	pl := &model.Place{ID: "P1", Name: "Harston", FullName: "Harston, Cambridgeshire"}
+	p := &model.Person{ID: "I1", PreferredFullName: "John Smith"}
+
+	s := NewSite("/trees/t", tree.NewTree("t", nil, nil))
+	s.PublishSet = &PublishSet{
+		People: map[string]*model.Person{"I1": p},
+		Places: map[string]*model.Place{"P1": pl},
+	}
+
+	for _, tt := range []struct {
+		name string
+		ld   string
+		want map[string]any
+	}{
+		{
+			name: "person",
+			ld:   s.PersonJSONLD(p),
+			want: map[string]any{"@context": "https://schema.org", "@type": "Person", "name": "John Smith"},
+		},
+		{
+			name: "place",
+			ld:   s.PlaceJSONLD(pl),
+			want: map[string]any{"@context": "https://schema.org", "@type": "Place", "name": "Harston, Cambridgeshire"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]any
+			if err := json.Unmarshal([]byte(tt.ld), &got); err != nil {
+				t.Fatalf("unmarshal %q: %v", tt.ld, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("JSON-LD mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
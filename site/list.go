@@ -160,6 +160,9 @@ func (s *Site) WriteTodoListPages(root string) error {
 			// 	links += " or " + string(b.EncodeLink(text.LowerFirst(p.EditLink.Title), p.EditLink.URL))
 			// }
 			b.Para(b.EncodeText(text.FormatSentence(rel) + " " + links))
+			if p.Completeness != nil {
+				b.Para(b.EncodeText(fmt.Sprintf("Research completeness: %d%%.", p.Completeness.Score())))
+			}
 
 			for _, cat := range categories {
 				al := todosByCategory[cat]
@@ -172,6 +175,13 @@ func (s *Site) WriteTodoListPages(root string) error {
 					} else {
 						line = text.FinishSentence(line)
 					}
+					if len(a.Hints) > 0 {
+						hints := make([]string, 0, len(a.Hints))
+						for _, l := range a.Hints {
+							hints = append(hints, string(b.EncodeLink(b.EncodeText(l.Title), l.URL)))
+						}
+						line = text.FinishSentence(line) + " Try " + text.JoinList(hints) + "."
+					}
 					items = append(items, [2]md.Text{
 						md.Text(a.Context),
 						md.Text(line),
@@ -192,8 +202,13 @@ func (s *Site) WriteTodoListPages(root string) error {
 				// b.UnorderedList(items)
 			}
 
+			key := p.PreferredSortName + "~" + p.ID
+			if s.TodoOrder == TodoOrderCompleteness {
+				key = fmt.Sprintf("%03d~%s", p.Completeness.Score(), key)
+			}
+
 			group, groupPriority := groupRelation(p.RelationToKeyPerson)
-			pn.AddEntryWithGroup(p.PreferredSortName+"~"+p.ID, p.PreferredSortName, b.String(), group, groupPriority)
+			pn.AddEntryWithGroup(key, p.PreferredSortName, b.String(), group, groupPriority)
 		}
 
 	}
@@ -205,6 +220,37 @@ func (s *Site) WriteTodoListPages(root string) error {
 	return nil
 }
 
+// WriteNegativeEvidenceListPages writes a list of the searches in the research
+// log that found nothing, grouped by the person they were made for.
+func (s *Site) WriteNegativeEvidenceListPages(root string) error {
+	baseDir := filepath.Join(root, s.ListNegativeDir)
+	pn := NewPaginator()
+	for _, p := range s.Tree.People {
+		if s.LinkFor(p) == "" {
+			continue
+		}
+		if p.Redacted {
+			logging.Debug("not writing redacted person to negative evidence index", "id", p.ID)
+			continue
+		}
+		searches := nilSearches(p.Searches)
+		if len(searches) == 0 {
+			continue
+		}
+
+		b := s.NewMarkdownBuilder()
+		b.Heading2(md.Text(p.PreferredUniqueName), p.ID)
+		b.Para(b.EncodeModelLink("View page", p))
+		b.UnorderedList(searchItems(b, searches))
+		pn.AddEntry(p.PreferredSortName+"~"+p.ID, p.PreferredSortName, b.String())
+	}
+	if err := pn.WritePages(s, baseDir, PageLayoutListNegative, "Negative Evidence", "Searches of record sets that found nothing for the people sought."); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *Site) WritePersonListPages(root string) error {
 	baseDir := filepath.Join(root, s.ListPeopleDir)
 	pn := NewPaginator()
@@ -240,12 +286,12 @@ func (s *Site) WritePersonListPages(root string) error {
 		items := make([][2]md.Text, 0)
 		b := &narrative.CitationSkippingEncoder[md.Text]{ContentBuilder: s.NewMarkdownBuilder()}
 
-		summary := narrative.PersonSummary(p, b, narrative.DefaultNameChooser{}, b.EncodeText(p.PreferredFamiliarName), true, true, false, true, false)
+		summary := narrative.PersonSummary(p, b, narrative.DefaultNameChooser{}, b.EncodeText(p.PreferredFamiliarName), true, true, false, true, false, s.Locale)
 
 		var rel string
 		if s.LinkFor(p) != "" {
 			if p.RelationToKeyPerson != nil && !p.RelationToKeyPerson.IsSelf() {
-				rel = b.EncodeBold(b.EncodeText(text.FormatSentence(p.RelationToKeyPerson.Name()))).String()
+				rel = b.EncodeBold(b.EncodeText(text.FormatSentence(s.Locale.RelationName(p.RelationToKeyPerson)))).String()
 			}
 		}
 
@@ -346,12 +392,12 @@ func (s *Site) WriteSurnameListPages(root string) error {
 			b := &narrative.CitationSkippingEncoder[md.Text]{ContentBuilder: s.NewMarkdownBuilder()}
 
 			title := b.EncodeModelLink(b.EncodeText(p.PreferredSortName), p)
-			summary := narrative.PersonSummary(p, b, narrative.DefaultNameChooser{}, b.EncodeText(p.PreferredFamiliarName), true, true, false, true, false)
+			summary := narrative.PersonSummary(p, b, narrative.DefaultNameChooser{}, b.EncodeText(p.PreferredFamiliarName), true, true, false, true, false, s.Locale)
 
 			var rel string
 			if s.LinkFor(p) != "" {
 				if p.RelationToKeyPerson != nil && !p.RelationToKeyPerson.IsSelf() {
-					rel = b.EncodeBold(b.EncodeText(p.RelationToKeyPerson.Name())).String()
+					rel = b.EncodeBold(b.EncodeText(s.Locale.RelationName(p.RelationToKeyPerson))).String()
 					title = b.EncodeText(text.AppendClause(title.String(), rel))
 				}
 			}
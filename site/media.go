@@ -0,0 +1,254 @@
+package site
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif" // register GIF decoder for media derivatives
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/iand/genster/logging"
+	"github.com/iand/genster/model"
+	"golang.org/x/image/draw"
+)
+
+// mediaDerivativeVersion is included in the fingerprint of every media
+// derivative so that cached derivatives can be invalidated when the way they
+// are made changes.
+const mediaDerivativeVersion = 1
+
+// mediaSizes are the sizes of derivative written for each image, with the
+// maximum width of each. Images are never enlarged, so a small image may have
+// fewer derivatives.
+var mediaSizes = []struct {
+	Name  string
+	Width int
+}{
+	{Name: "thumbnail", Width: 200},
+	{Name: "medium", Width: 800},
+	{Name: "full", Width: 1600},
+}
+
+// WriteMediaDerivatives writes resized copies of each published media object,
+// and of each region of one highlighted by a citation, to the media
+// directory. Derivatives are named by a fingerprint of the content of the
+// source image so they can be cached by browsers, and are kept in a cache so
+// that they are only made again when the source image changes. Images that
+// cannot be read are logged and published without derivatives.
+func (s *Site) WriteMediaDerivatives(contentDir string) {
+	hashes := make(map[string]string)
+	for _, mo := range s.PublishSet.MediaObjects {
+		ds, err := s.writeMediaDerivatives(contentDir, mo, nil, hashes)
+		if err != nil {
+			logging.Warn("media derivatives failed", "id", mo.ID, "err", err)
+			continue
+		}
+		mo.Derivatives = ds
+	}
+
+	for _, cmo := range s.publishedCitedMediaObjects() {
+		if cmo.Highlight == nil {
+			cmo.Derivatives = cmo.Object.Derivatives
+			continue
+		}
+		ds, err := s.writeMediaDerivatives(contentDir, cmo.Object, cmo.Highlight, hashes)
+		if err != nil {
+			logging.Warn("media derivatives failed", "id", cmo.Object.ID, "err", err)
+			continue
+		}
+		cmo.Derivatives = ds
+	}
+}
+
+// publishedCitedMediaObjects returns the media objects cited by the people,
+// events, places and citations in the publish set.
+func (s *Site) publishedCitedMediaObjects() []*model.CitedMediaObject {
+	var cmos []*model.CitedMediaObject
+	add := func(list []*model.CitedMediaObject) {
+		for _, cmo := range list {
+			if _, ok := s.PublishSet.MediaObjects[cmo.Object.ID]; ok {
+				cmos = append(cmos, cmo)
+			}
+		}
+	}
+	for _, p := range s.PublishSet.People {
+		add(p.Gallery)
+		for _, ev := range p.Timeline {
+			add(ev.GetMediaObjects())
+		}
+	}
+	for _, pl := range s.PublishSet.Places {
+		add(pl.Gallery)
+	}
+	for _, c := range s.PublishSet.Citations {
+		add(c.MediaObjects)
+	}
+	return cmos
+}
+
+// writeMediaDerivatives writes the derivatives of mo, cropped to region if it
+// is not nil, and returns them smallest first. hashes caches the content
+// hashes of source files by path.
+func (s *Site) writeMediaDerivatives(contentDir string, mo *model.MediaObject, region *model.Region, hashes map[string]string) ([]model.MediaDerivative, error) {
+	var ext string
+	switch mo.MediaType {
+	case "image/jpeg":
+		ext = "jpg"
+	case "image/png", "image/gif":
+		ext = "png"
+	default:
+		return nil, nil
+	}
+
+	srcHash, ok := hashes[mo.SrcFilePath]
+	if !ok {
+		var err error
+		srcHash, err = fileHash(mo.SrcFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("hash source image: %w", err)
+		}
+		hashes[mo.SrcFilePath] = srcHash
+	}
+
+	cfg, err := decodeImageConfig(mo.SrcFilePath)
+	if err != nil {
+		return nil, err
+	}
+	crop := cropRect(image.Rect(0, 0, cfg.Width, cfg.Height), region)
+	if crop.Empty() {
+		return nil, fmt.Errorf("highlighted region is empty")
+	}
+
+	var src image.Image // decoded lazily, only if a derivative is not in the cache
+	var ds []model.MediaDerivative
+	for _, size := range mediaSizes {
+		width := min(size.Width, crop.Dx())
+		if len(ds) > 0 && ds[len(ds)-1].Width == width {
+			break
+		}
+		height := max(1, crop.Dy()*width/crop.Dx())
+
+		fname := fmt.Sprintf("%s-%s.%s", mediaDerivativeFingerprint(srcHash, region, width), size.Name, ext)
+		cachePath := filepath.Join(cacheDir("media"), fname)
+		if _, err := os.Stat(cachePath); err != nil {
+			if src == nil {
+				src, err = decodeImage(mo.SrcFilePath)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if err := writeScaledImage(cachePath, src, crop, width, height, ext); err != nil {
+				return nil, err
+			}
+		} else {
+			touchCacheFile(cachePath)
+		}
+
+		if err := CopyFile(filepath.Join(contentDir, s.MediaDir, fname), cachePath); err != nil {
+			return nil, fmt.Errorf("copy media derivative: %w", err)
+		}
+		ds = append(ds, model.MediaDerivative{
+			Size:   size.Name,
+			Link:   fmt.Sprintf(s.MediaLinkPattern, fname),
+			Width:  width,
+			Height: height,
+		})
+	}
+	return ds, nil
+}
+
+// mediaDerivativeFingerprint returns a short fingerprint identifying the
+// derivative of width pixels made from the source image with the given
+// content hash, cropped to region.
+func mediaDerivativeFingerprint(srcHash string, region *model.Region, width int) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "v%d|%s|%d", mediaDerivativeVersion, srcHash, width)
+	if region != nil {
+		fmt.Fprintf(h, "|%d,%d,%d,%d", region.Left, region.Bottom, region.Width, region.Height)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// cropRect returns the part of bounds covered by region, whose dimensions are
+// percentages measured from the bottom left. A nil region covers all of bounds.
+func cropRect(bounds image.Rectangle, region *model.Region) image.Rectangle {
+	if region == nil {
+		return bounds
+	}
+	width := bounds.Dx()
+	height := bounds.Dy()
+	return image.Rectangle{
+		Min: image.Point{
+			X: bounds.Min.X + width*region.Left/100,
+			Y: bounds.Min.Y + height*(100-region.Bottom-region.Height)/100,
+		},
+		Max: image.Point{
+			X: bounds.Min.X + width*(region.Left+region.Width)/100,
+			Y: bounds.Min.Y + height*(100-region.Bottom)/100,
+		},
+	}.Intersect(bounds)
+}
+
+// writeScaledImage scales the crop rectangle of src to width by height and
+// writes it to fname in the format named by ext.
+func writeScaledImage(fname string, src image.Image, crop image.Rectangle, width, height int, ext string) error {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop.Add(src.Bounds().Min), draw.Src, nil)
+
+	if err := writeCacheFile(fname, func(w io.Writer) error {
+		switch ext {
+		case "jpg":
+			return jpeg.Encode(w, dst, &jpeg.Options{Quality: 85})
+		default:
+			return png.Encode(w, dst)
+		}
+	}); err != nil {
+		return fmt.Errorf("write media derivative: %w", err)
+	}
+	return nil
+}
+
+func decodeImageConfig(fname string) (image.Config, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return image.Config{}, fmt.Errorf("open image: %w", err)
+	}
+	defer f.Close()
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return image.Config{}, fmt.Errorf("decode image config: %w", err)
+	}
+	return cfg, nil
+}
+
+func decodeImage(fname string) (image.Image, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("open image: %w", err)
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	return img, nil
+}
+
+// fileHash returns the hex encoded SHA-256 hash of the content of fname.
+func fileHash(fname string) (string, error) {
+	f, err := os.Open(fname)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
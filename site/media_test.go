@@ -0,0 +1,91 @@
+package site
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/genster/model"
+)
+
+func TestWriteMediaDerivatives(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	contentDir := t.TempDir()
+
+	src := filepath.Join(t.TempDir(), "photo.png")
+	f, err := os.Create(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 1000, 500))); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	s := &Site{MediaDir: "media", MediaLinkPattern: "/media/%s"}
+	mo := &model.MediaObject{ID: "m1", SrcFilePath: src, MediaType: "image/png"}
+
+	sizes := func(ds []model.MediaDerivative) [][3]any {
+		var out [][3]any
+		for _, d := range ds {
+			out = append(out, [3]any{d.Size, d.Width, d.Height})
+			if _, err := os.Stat(filepath.Join(contentDir, "media", filepath.Base(d.Link))); err != nil {
+				t.Errorf("derivative %s not written: %v", d.Link, err)
+			}
+		}
+		return out
+	}
+
+	for _, tt := range []struct {
+		name   string
+		region *model.Region
+		want   [][3]any
+	}{
+		{
+			name: "whole image",
+			want: [][3]any{{"thumbnail", 200, 100}, {"medium", 800, 400}, {"full", 1000, 500}},
+		},
+		{
+			name:   "top left quarter",
+			region: &model.Region{Left: 0, Bottom: 50, Width: 50, Height: 50},
+			want:   [][3]any{{"thumbnail", 200, 100}, {"medium", 500, 250}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ds, err := s.writeMediaDerivatives(contentDir, mo, tt.region, map[string]string{})
+			if err != nil {
+				t.Fatalf("writeMediaDerivatives: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, sizes(ds)); diff != "" {
+				t.Errorf("derivatives mismatch (-want +got):\n%s", diff)
+			}
+
+			// a second run reuses the cached derivatives under the same names
+			again, err := s.writeMediaDerivatives(contentDir, mo, tt.region, map[string]string{})
+			if err != nil {
+				t.Fatalf("writeMediaDerivatives: %v", err)
+			}
+			if diff := cmp.Diff(ds, again); diff != "" {
+				t.Errorf("derivatives changed between runs (-first +second):\n%s", diff)
+			}
+		})
+	}
+
+	// changing the source image changes the derivative names
+	before, _ := s.writeMediaDerivatives(contentDir, mo, nil, map[string]string{})
+	f, err = os.Create(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, image.NewGray(image.Rect(0, 0, 1000, 500))); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	after, _ := s.writeMediaDerivatives(contentDir, mo, nil, map[string]string{})
+	if len(after) == 0 || before[0].Link == after[0].Link {
+		t.Errorf("derivative name did not change with source image")
+	}
+}
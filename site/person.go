@@ -3,7 +3,9 @@ package site
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/iand/genster/debug"
@@ -15,7 +17,7 @@ import (
 	"github.com/iand/genster/text"
 )
 
-func RenderPersonPage(s *Site, p *model.Person) (render.Document[md.Text], error) {
+func RenderPersonPage(s *Site, p *model.Person, charts *PersonCharts) (render.Document[md.Text], error) {
 	pov := &model.POV{Person: p}
 
 	if p.BestBirthlikeEvent != nil {
@@ -56,6 +58,12 @@ func RenderPersonPage(s *Site, p *model.Person) (render.Document[md.Text], error
 		doc.SetFrontMatterField("gender", "unknown")
 	}
 	doc.AddTags(CleanTags(p.Tags))
+	if p.Completeness != nil {
+		doc.SetFrontMatterField("completeness", strconv.Itoa(p.Completeness.Score()))
+	}
+	if ld := s.PersonJSONLD(p); ld != "" {
+		doc.SetFrontMatterField("jsonld", ld)
+	}
 
 	// determine the feature image
 	if !p.Gender.IsUnknown() {
@@ -145,22 +153,14 @@ func RenderPersonPage(s *Site, p *model.Person) (render.Document[md.Text], error
 		}
 	}
 
-	var storySubjects, storyMentions, questionSubjects, questionMentions []model.Link
-	for _, l := range p.Links {
-		switch l.Category {
-		case model.LinkCategoryStorySubject:
-			storySubjects = append(storySubjects, l)
-		case model.LinkCategoryStoryMention:
-			storyMentions = append(storyMentions, l)
-		case model.LinkCategoryQuestionSubject:
-			questionSubjects = append(questionSubjects, l)
-		case model.LinkCategoryQuestionMention:
-			questionMentions = append(questionMentions, l)
-		default:
-			doc.AddLink(l.Title, l.URL, l.Category)
-		}
+	for _, l := range writeContentLinks(doc, p.PreferredGivenName, p.Links) {
+		doc.AddLink(l.Title, l.URL, l.Category)
+	}
+
+	// searches of external record collections for the person's vital events
+	for _, l := range s.personResearchHints(p) {
+		doc.AddLink(l.Title, l.URL, l.Category)
 	}
-	writeContentLinksPara(doc, p.PreferredGivenName, storySubjects, storyMentions, questionSubjects, questionMentions)
 
 	if p.Intro != nil {
 		narrative.RenderText(*p.Intro, doc)
@@ -169,6 +169,7 @@ func RenderPersonPage(s *Site, p *model.Person) (render.Document[md.Text], error
 	// Render narrative
 	n := &narrative.PersonNarrative[md.Text]{
 		Statements: make([]narrative.Statement[md.Text], 0),
+		Locale:     s.Locale,
 	}
 
 	// Everyone has an intro
@@ -261,6 +262,18 @@ func RenderPersonPage(s *Site, p *model.Person) (render.Document[md.Text], error
 		}
 	}
 
+	if p.Completeness != nil && len(p.Completeness.Items) > 0 {
+		doc.Heading2("Research Completeness", "")
+		para := fmt.Sprintf("%d%% of the evidence expected for %s has been found.", p.Completeness.Score(), p.PreferredGivenName)
+		if missing := p.Completeness.Missing(); len(missing) > 0 {
+			for i := range missing {
+				missing[i] = text.LowerFirst(missing[i])
+			}
+			para += " Still to be found: " + text.FinishSentence(text.JoinList(missing))
+		}
+		doc.Para(doc.EncodeText(para))
+	}
+
 	t := &model.Timeline{
 		Events: make([]model.TimelineEvent, 0, len(p.Timeline)),
 	}
@@ -276,11 +289,15 @@ func RenderPersonPage(s *Site, p *model.Person) (render.Document[md.Text], error
 
 		doc.ResetSeenLinks()
 
-		fmtr := narrative.NewNarrativeTimelineEntryFormatter[md.Text](pov, doc, logging.With("id", p.ID), &narrative.TimelineNameChooser{}, false)
+		fmtr := narrative.NewNarrativeTimelineEntryFormatter[md.Text](pov, doc, logging.With("id", p.ID), &narrative.TimelineNameChooser{}, false, s.Locale)
 
 		if err := RenderTimeline(t, pov, doc, fmtr); err != nil {
 			return nil, fmt.Errorf("render timeline narrative: %w", err)
 		}
+
+		if slices.ContainsFunc(t.Events, func(ev model.TimelineEvent) bool { return ev.GetDate().IsDualDated() }) {
+			doc.Para(doc.EncodeItalic(doc.EncodeText(model.OldStyleNote)))
+		}
 	}
 
 	if len(p.MiscFacts) > 0 || len(p.KnownNames) > 1 {
@@ -290,6 +307,26 @@ func RenderPersonPage(s *Site, p *model.Person) (render.Document[md.Text], error
 		}
 	}
 
+	if charts != nil {
+		doc.Heading2("Family Tree", "")
+		if charts.AncestorsLink != "" {
+			doc.Figure(charts.AncestorsLink, "Ancestors of "+p.PreferredFullName, doc.EncodeText("Ancestors of "+p.PreferredFamiliarFullName), nil, "")
+		}
+		if charts.DescendantsLink != "" {
+			doc.Figure(charts.DescendantsLink, "Descendants of "+p.PreferredFullName, doc.EncodeText("Descendants of "+p.PreferredFamiliarFullName), nil, "")
+		}
+	}
+
+	if link := s.GalleryLinkFor(p); link != "" {
+		doc.Heading2("Images", "")
+		doc.Para(doc.EncodeLink(doc.EncodeText("See all images of "+p.PreferredFamiliarFullName), link))
+	}
+
+	if len(p.Searches) > 0 {
+		doc.Heading2("Searches Made", "")
+		doc.UnorderedList(searchItems(doc, p.Searches))
+	}
+
 	if len(p.ResearchNotes) > 0 {
 		doc.Heading2("Research Notes", "")
 		for _, t := range p.ResearchNotes {
@@ -316,6 +353,29 @@ func linkTitles(doc *md.Document, links []model.Link, prefix string) []string {
 	return titles
 }
 
+// writeContentLinks describes the stories and questions in links that are
+// about the subject called name in a paragraph added to doc. It returns the
+// remaining links, such as diary entries and websites.
+func writeContentLinks(doc *md.Document, name string, links []model.Link) []model.Link {
+	var storySubjects, storyMentions, questionSubjects, questionMentions, others []model.Link
+	for _, l := range links {
+		switch l.Category {
+		case model.LinkCategoryStorySubject:
+			storySubjects = append(storySubjects, l)
+		case model.LinkCategoryStoryMention:
+			storyMentions = append(storyMentions, l)
+		case model.LinkCategoryQuestionSubject:
+			questionSubjects = append(questionSubjects, l)
+		case model.LinkCategoryQuestionMention:
+			questionMentions = append(questionMentions, l)
+		default:
+			others = append(others, l)
+		}
+	}
+	writeContentLinksPara(doc, name, storySubjects, storyMentions, questionSubjects, questionMentions)
+	return others
+}
+
 // writeContentLinksPara adds a paragraph to doc describing which stories and
 // questions the person is the subject of, and which they are merely mentioned
 // in. Links are rendered as markdown hyperlinks inline in the sentence.
@@ -0,0 +1,189 @@
+package site
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/iand/genster/chart"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/tree"
+	"github.com/iand/gtree"
+)
+
+// personChartVersion is included in the fingerprint of every person chart so that
+// cached charts can be invalidated when the way they are drawn changes.
+const personChartVersion = 1
+
+// PersonCharts holds links to the charts generated for a person's page.
+type PersonCharts struct {
+	AncestorsLink   string // link to the ancestor chart, empty if none was generated
+	DescendantsLink string // link to the descendant chart, empty if none was generated
+}
+
+// WritePersonCharts writes compact ancestor and descendant charts for p into the
+// person's page directory according to the site's PersonCharts configuration.
+// Charts are cached by a fingerprint of the people they contain and are only
+// regenerated when one of those people changes. Returns nil if no charts were
+// written.
+func (s *Site) WritePersonCharts(p *model.Person, contentDir string) (*PersonCharts, error) {
+	if s.PersonCharts == nil || p.Redacted {
+		return nil, nil
+	}
+
+	pc := &PersonCharts{}
+	dir := filepath.Join(contentDir, s.PersonDir, p.ID)
+
+	if spec := s.PersonCharts.Ancestors; spec != nil && (!p.Father.IsUnknown() || !p.Mother.IsUnknown()) {
+		fp := personChartFingerprint("ancestors", spec, ancestorChartPeople(p, spec.Generations))
+		if err := writeCachedChart(filepath.Join(dir, "ancestors.svg"), fp, func() (string, error) {
+			return s.renderAncestorChart(p, spec)
+		}); err != nil {
+			return nil, fmt.Errorf("ancestor chart: %w", err)
+		}
+		pc.AncestorsLink = path.Join(s.LinkFor(p), "ancestors.svg")
+	}
+
+	if spec := s.PersonCharts.Descendants; spec != nil && len(p.Children) > 0 {
+		fp := personChartFingerprint("descendants", spec, descendantChartPeople(p, spec.Generations))
+		if err := writeCachedChart(filepath.Join(dir, "descendants.svg"), fp, func() (string, error) {
+			return s.renderDescendantChart(p, spec)
+		}); err != nil {
+			return nil, fmt.Errorf("descendant chart: %w", err)
+		}
+		pc.DescendantsLink = path.Join(s.LinkFor(p), "descendants.svg")
+	}
+
+	if pc.AncestorsLink == "" && pc.DescendantsLink == "" {
+		return nil, nil
+	}
+	return pc, nil
+}
+
+func (s *Site) renderAncestorChart(p *model.Person, spec *tree.ChartSpec) (string, error) {
+	ch, _, err := chart.BuildAncestorChart(s.Tree, p, spec.Detail, spec.Generations, true)
+	if err != nil {
+		return "", fmt.Errorf("build chart: %w", err)
+	}
+
+	lay, err := ch.Layout(gtree.DefaultAncestorLayoutOptions())
+	if err != nil {
+		return "", fmt.Errorf("layout chart: %w", err)
+	}
+	return gtree.SVG(lay, nil)
+}
+
+func (s *Site) renderDescendantChart(p *model.Person, spec *tree.ChartSpec) (string, error) {
+	ch, _, err := chart.BuildDescendantChart(s.Tree, p, spec.Detail, spec.Generations, true, "everyone", false, true, true)
+	if err != nil {
+		return "", fmt.Errorf("build chart: %w", err)
+	}
+
+	opts := gtree.DefaultLayoutOptions()
+	opts.BackgroundColor = ""
+	lay, err := ch.Layout(opts)
+	if err != nil {
+		return "", fmt.Errorf("layout chart: %w", err)
+	}
+	return gtree.SVG(lay, nil)
+}
+
+// writeCachedChart writes the chart identified by fingerprint to fname, only calling
+// render if the chart is not already in the cache.
+func writeCachedChart(fname string, fingerprint string, render func() (string, error)) error {
+	cachePath := filepath.Join(cacheDir("charts"), fingerprint+".svg")
+
+	if _, err := os.Stat(cachePath); err != nil {
+		svg, err := render()
+		if err != nil {
+			return err
+		}
+		if err := writeCacheFile(cachePath, func(w io.Writer) error {
+			_, err := io.WriteString(w, svg)
+			return err
+		}); err != nil {
+			return fmt.Errorf("write cached chart: %w", err)
+		}
+	} else {
+		touchCacheFile(cachePath)
+	}
+
+	if err := CopyFile(fname, cachePath); err != nil {
+		return fmt.Errorf("copy chart: %w", err)
+	}
+	return nil
+}
+
+// ancestorChartPeople returns p and their ancestors up to the given number of generations.
+func ancestorChartPeople(p *model.Person, generations int) []*model.Person {
+	people := []*model.Person{p}
+	if generations > 0 {
+		if !p.Father.IsUnknown() {
+			people = append(people, ancestorChartPeople(p.Father, generations-1)...)
+		}
+		if !p.Mother.IsUnknown() {
+			people = append(people, ancestorChartPeople(p.Mother, generations-1)...)
+		}
+	}
+	return people
+}
+
+// descendantChartPeople returns p, their spouses and their descendants with their
+// spouses, up to the given number of generations.
+func descendantChartPeople(p *model.Person, generations int) []*model.Person {
+	people := []*model.Person{p}
+	for _, f := range p.Families {
+		if o := f.OtherParent(p); !o.IsUnknown() {
+			people = append(people, o)
+		}
+		if generations > 0 {
+			for _, c := range f.Children {
+				people = append(people, descendantChartPeople(c, generations-1)...)
+			}
+		}
+	}
+	return people
+}
+
+// personChartFingerprint returns a hash that changes whenever any detail of the people
+// that could be shown in a chart changes.
+func personChartFingerprint(kind string, spec *tree.ChartSpec, people []*model.Person) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "v%d\n%s\n%d\n%d\n", personChartVersion, kind, spec.Generations, spec.Detail)
+	for _, p := range people {
+		fmt.Fprintf(h, "%s|%s|%s|%s|%s|%s|%t|%t\n", p.ID, p.PreferredFullName, p.PreferredFamiliarFullName, p.NickName, p.Epithet, p.VitalYears, p.Redacted, p.IsDirectAncestor())
+		fingerprintEvent(h, p.BestBirthlikeEvent)
+		fingerprintEvent(h, p.BestDeathlikeEvent)
+		fingerprintTime(h, p.UpdateTime)
+		for _, f := range p.Families {
+			fmt.Fprintf(h, "f|%s|%s\n", f.ID, f.Bond)
+			fingerprintEvent(h, f.BestStartEvent)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func fingerprintEvent(h hash.Hash, ev model.TimelineEvent) {
+	if ev == nil {
+		fmt.Fprintln(h, "-")
+		return
+	}
+	fmt.Fprintln(h, model.AbbrevWhatWhen(ev))
+	if !ev.GetPlace().IsUnknown() {
+		fmt.Fprintln(h, model.AbbrevWhere(ev))
+	}
+}
+
+func fingerprintTime(h hash.Hash, t *time.Time) {
+	if t == nil {
+		fmt.Fprintln(h, "-")
+		return
+	}
+	fmt.Fprintln(h, t.UTC().Format(time.RFC3339Nano))
+}
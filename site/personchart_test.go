@@ -0,0 +1,33 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/tree"
+)
+
+func TestPersonChartFingerprint(t *testing.T) {
+	father := &model.Person{ID: "f", PreferredFullName: "John Smith", VitalYears: "1800-1870"}
+	mother := &model.Person{ID: "m", PreferredFullName: "Mary Jones", VitalYears: "1805-1880"}
+	child := &model.Person{ID: "c", PreferredFullName: "William Smith", Father: father, Mother: mother}
+	spec := &tree.ChartSpec{Generations: 2, Detail: 1}
+
+	fingerprint := func() string {
+		return personChartFingerprint("ancestors", spec, ancestorChartPeople(child, spec.Generations))
+	}
+
+	base := fingerprint()
+	if got := fingerprint(); got != base {
+		t.Errorf("fingerprint not stable: got %s, wanted %s", got, base)
+	}
+
+	mother.VitalYears = "1805-1881"
+	if got := fingerprint(); got == base {
+		t.Errorf("fingerprint did not change when ancestor changed")
+	}
+
+	if got := personChartFingerprint("ancestors", &tree.ChartSpec{Generations: 2, Detail: 2}, ancestorChartPeople(child, 2)); got == fingerprint() {
+		t.Errorf("fingerprint did not change when detail changed")
+	}
+}
@@ -2,6 +2,7 @@ package site
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
@@ -28,6 +29,13 @@ func RenderPlacePage(s *Site, p *model.Place, placeMap *PlaceMap) (render.Docume
 	doc.SetFrontMatterField("buildingkind", p.BuildingKind.String())
 	doc.ID(p.ID)
 	doc.AddTags(CleanTags(p.Tags))
+	if ld := s.PlaceJSONLD(p); ld != "" {
+		doc.SetFrontMatterField("jsonld", ld)
+	}
+	if p.GrampsID != "" {
+		doc.SetFrontMatterField("grampsid", p.GrampsID)
+		doc.AddAlias(s.RedirectPath(p.GrampsID))
+	}
 
 	name := p.Name + " is a" + text.MaybeAn(p.PlaceType.String())
 
@@ -41,6 +49,21 @@ func RenderPlacePage(s *Site, p *model.Place, placeMap *PlaceMap) (render.Docume
 		narrative.RenderText(t, doc)
 	}
 
+	if history := jurisdictionHistory(doc, p); len(history) > 0 {
+		doc.EmptyPara()
+		doc.Heading2("Jurisdiction", "")
+		doc.UnorderedList(history)
+	}
+
+	var links []model.Link
+	for _, l := range writeContentLinks(doc, p.Name, p.Links) {
+		if l.Category == model.LinkCategoryDiary {
+			doc.AddLink(l.Title, l.URL, l.Category)
+			continue
+		}
+		links = append(links, l)
+	}
+
 	if placeMap != nil {
 		exploreLink := doc.EncodeLink(doc.EncodeText(placeMap.ExploreText), placeMap.ExploreURL)
 		caption := doc.EncodeText(p.Name+". "+placeMap.MapName+" (") + exploreLink + doc.EncodeText(")")
@@ -55,16 +78,16 @@ func RenderPlacePage(s *Site, p *model.Place, placeMap *PlaceMap) (render.Docume
 		doc.EmptyPara()
 		doc.Heading2("Timeline", "")
 
-		fmtr := narrative.NewNarrativeTimelineEntryFormatter[md.Text](pov, doc, logging.Default(), &narrative.TimelineNameChooser{}, false)
+		fmtr := narrative.NewNarrativeTimelineEntryFormatter[md.Text](pov, doc, logging.Default(), &narrative.TimelineNameChooser{}, false, s.Locale)
 
 		if err := RenderTimeline(t, pov, doc, fmtr); err != nil {
 			return nil, fmt.Errorf("render timeline narrative: %w", err)
 		}
 	}
 
-	if len(p.Links) > 0 {
+	if len(links) > 0 {
 		doc.Heading2("Links", "")
-		for _, l := range p.Links {
+		for _, l := range links {
 			doc.Para(doc.EncodeLink(doc.EncodeText(l.Title), l.URL))
 		}
 	}
@@ -78,3 +101,48 @@ func RenderPlacePage(s *Site, p *model.Place, placeMap *PlaceMap) (render.Docume
 
 	return doc, nil
 }
+
+// jurisdictionHistory lists the places that have contained p and the other
+// names it has been known by, earliest first.
+func jurisdictionHistory(doc *md.Document, p *model.Place) []md.Text {
+	type entry struct {
+		period model.YearPeriod
+		text   md.Text
+	}
+	var entries []entry
+	for _, j := range p.Jurisdictions {
+		t := doc.EncodeText("Part of ") + doc.EncodeModelLink(doc.EncodeText(j.Parent.FullName), j.Parent)
+		if when := j.When(); when != "" {
+			t += doc.EncodeText(" " + when)
+		}
+		entries = append(entries, entry{period: j.YearPeriod, text: t})
+	}
+	for _, an := range p.AltNames {
+		t := doc.EncodeText("Also known as " + an.Name)
+		if when := an.When(); when != "" {
+			t = doc.EncodeText("Known as " + an.Name + " " + when)
+		}
+		entries = append(entries, entry{period: an.YearPeriod, text: t})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// periods with no start sort first, then by start and end
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].period, entries[j].period
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To == 0 || b.To == 0 {
+			return b.To == 0 && a.To != 0
+		}
+		return a.To < b.To
+	})
+
+	history := make([]md.Text, len(entries))
+	for i, e := range entries {
+		history[i] = e.text
+	}
+	return history
+}
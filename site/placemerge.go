@@ -0,0 +1,307 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/iand/genster/logging"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/place"
+	kdl "github.com/sblinch/kdl-go"
+	"github.com/sblinch/kdl-go/document"
+	"github.com/urfave/cli/v3"
+)
+
+var PlacesCommand = &cli.Command{
+	Name:   "places",
+	Usage:  "Find places that are probably the same and propose merging them",
+	Action: places,
+	Flags: append([]cli.Flag{
+		&cli.StringFlag{
+			Name:        "gedcom",
+			Aliases:     []string{"g"},
+			Usage:       "GEDCOM file to read from",
+			Destination: &placesopts.gedcomFile,
+		},
+		&cli.StringFlag{
+			Name:        "gramps",
+			Usage:       "Gramps xml file to read from",
+			Destination: &placesopts.grampsFile,
+		},
+		&cli.StringFlag{
+			Name:        "gramps-dbname",
+			Usage:       "Name of the gramps database, used to keep IDs consistent between versions of the same database",
+			Destination: &placesopts.grampsDatabaseName,
+		},
+		&cli.StringFlag{
+			Name:        "config",
+			Aliases:     []string{"c"},
+			Usage:       "Path to a KDL tree configuration file",
+			Required:    true,
+			Destination: &placesopts.treeConfig,
+		},
+		&cli.StringFlag{
+			Name:        "output",
+			Aliases:     []string{"o"},
+			Usage:       "File to write the proposed place annotations to, standard output if omitted",
+			Destination: &placesopts.output,
+		},
+		&cli.FloatFlag{
+			Name:        "distance",
+			Usage:       "Greatest distance in kilometres between the coordinates of places that may be merged",
+			Value:       10,
+			Destination: &placesopts.distance,
+		},
+	}, logging.Flags...),
+}
+
+var placesopts struct {
+	gedcomFile         string
+	grampsFile         string
+	grampsDatabaseName string
+	treeConfig         string
+	output             string
+	distance           float64
+}
+
+func places(ctx context.Context, cc *cli.Command) error {
+	logging.Setup()
+
+	t, _, err := loadTree(placesopts.gedcomFile, placesopts.grampsFile, placesopts.grampsDatabaseName, placesopts.treeConfig)
+	if err != nil {
+		return err
+	}
+
+	// generating applies existing annotations so places that have already
+	// been merged are not proposed again
+	if err := t.Generate(false); err != nil {
+		return fmt.Errorf("build tree: %w", err)
+	}
+
+	pls := make([]*model.Place, 0, len(t.Places))
+	for _, pl := range t.Places {
+		pls = append(pls, pl)
+	}
+	merges := clusterPlaces(pls, t.Gazetteer, placesopts.distance)
+	logging.Info("found places to merge", "groups", len(merges))
+
+	w := io.Writer(os.Stdout)
+	if placesopts.output != "" {
+		f, err := CreateFile(placesopts.output)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := writePlaceMerges(w, merges); err != nil {
+		return fmt.Errorf("write place annotations: %w", err)
+	}
+	return nil
+}
+
+// A placeMerge is a group of places that are probably the same, with the
+// place they should be merged into.
+type placeMerge struct {
+	Target *model.Place
+	Places []*model.Place // places to merge into Target
+}
+
+// placeKey is the form of a place's name used to compare it with others.
+type placeKey struct {
+	pl       *model.Place
+	locality string   // the normalized first part of the name
+	context  []string // the normalized remaining parts, with known aliases replaced by the name they stand for
+}
+
+func newPlaceKey(pl *model.Place, g *place.Gazetteer) *placeKey {
+	name := pl.OriginalText
+	if name == "" {
+		name = pl.FullName
+	}
+	if name == "" {
+		name = pl.Name
+	}
+	parts := strings.Split(normalizePlaceName(name), ", ")
+	k := &placeKey{pl: pl, locality: parts[0]}
+	for _, part := range parts[1:] {
+		if pns := g.Lookup(part); len(pns) == 1 {
+			part = strings.ToLower(pns[0].Name)
+		} else if pn, ok := place.LookupPlaceName(part); ok {
+			part = strings.ToLower(pn.Name)
+		}
+		k.context = append(k.context, part)
+	}
+	return k
+}
+
+// compatible reports whether k and o may name the same place: their names
+// must not disagree, nor their countries, and if both have coordinates they
+// must be within maxKm of each other. Places whose coordinates are that close
+// are compatible even if their names disagree.
+func (k *placeKey) compatible(o *placeKey, maxKm float64) bool {
+	if !k.pl.CountryName.IsUnknown() && !o.pl.CountryName.IsUnknown() && !k.pl.CountryName.SameAs(o.pl.CountryName) {
+		return false
+	}
+	if !k.pl.UKNationName.IsUnknown() && !o.pl.UKNationName.IsUnknown() && !k.pl.UKNationName.SameAs(o.pl.UKNationName) {
+		return false
+	}
+	if k.pl.GeoLocation != nil && o.pl.GeoLocation != nil {
+		return distanceKm(k.pl.GeoLocation, o.pl.GeoLocation) <= maxKm
+	}
+	return isSubset(k.context, o.context) || isSubset(o.context, k.context)
+}
+
+// clusterPlaces groups places that are probably the same. Places are grouped
+// when the first parts of their names are the same and the rest of one name
+// is contained in the rest of the other, or their coordinates are within
+// maxKm of each other. The place with the most specific name in each group
+// is chosen as the one to merge the others into. A place that could belong
+// to more than one group, such as a bare "Newton" when there is a Newton in
+// two counties, is left alone.
+func clusterPlaces(pls []*model.Place, g *place.Gazetteer, maxKm float64) []*placeMerge {
+	byLocality := make(map[string][]*placeKey)
+	for _, pl := range pls {
+		if pl.IsUnknown() {
+			continue
+		}
+		k := newPlaceKey(pl, g)
+		if k.locality == "" {
+			continue
+		}
+		byLocality[k.locality] = append(byLocality[k.locality], k)
+	}
+
+	var merges []*placeMerge
+	for _, keys := range byLocality {
+		if len(keys) < 2 {
+			continue
+		}
+		// most specific names first so they seed the clusters
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i].context) != len(keys[j].context) {
+				return len(keys[i].context) > len(keys[j].context)
+			}
+			if len(keys[i].pl.Timeline) != len(keys[j].pl.Timeline) {
+				return len(keys[i].pl.Timeline) > len(keys[j].pl.Timeline)
+			}
+			return keys[i].pl.ID < keys[j].pl.ID
+		})
+
+		var clusters [][]*placeKey
+		for _, k := range keys {
+			var matched []int
+			for ci, c := range clusters {
+				ok := true
+				for _, m := range c {
+					if !k.compatible(m, maxKm) {
+						ok = false
+						break
+					}
+				}
+				if ok {
+					matched = append(matched, ci)
+				}
+			}
+			switch len(matched) {
+			case 0:
+				clusters = append(clusters, []*placeKey{k})
+			case 1:
+				clusters[matched[0]] = append(clusters[matched[0]], k)
+			default:
+				logging.Debug("place could be one of several others", "id", k.pl.ID, "name", k.pl.OriginalText)
+			}
+		}
+
+		for _, c := range clusters {
+			if len(c) < 2 {
+				continue
+			}
+			pm := &placeMerge{Target: c[0].pl}
+			for _, k := range c[1:] {
+				pm.Places = append(pm.Places, k.pl)
+			}
+			merges = append(merges, pm)
+		}
+	}
+
+	sort.Slice(merges, func(i, j int) bool {
+		return merges[i].Target.PreferredSortName < merges[j].Target.PreferredSortName
+	})
+	return merges
+}
+
+// writePlaceMerges writes merges as KDL place annotations that can be added
+// to the annotations section of a tree config.
+func writePlaceMerges(w io.Writer, merges []*placeMerge) error {
+	places := document.NewNode()
+	places.SetName("places")
+	for _, pm := range merges {
+		for _, pl := range pm.Places {
+			sameas := document.NewNode()
+			sameas.SetName("sameas")
+			sameas.AddArgument(pm.Target.ID, "")
+			sameas.Comment = &document.Comment{
+				Before: []byte("// " + placeMergeName(pl) + " is the same as " + placeMergeName(pm.Target)),
+			}
+
+			n := document.NewNode()
+			n.SetName("place")
+			n.AddProperty("id", pl.ID, "")
+			n.AddNode(sameas)
+			places.AddNode(n)
+		}
+	}
+
+	annotations := document.NewNode()
+	annotations.SetName("annotations")
+	annotations.AddNode(places)
+
+	doc := document.New()
+	doc.AddNode(annotations)
+
+	opts := kdl.DefaultGenerateOptions
+	opts.Indent = "    "
+	return kdl.GenerateWithOptions(doc, w, opts)
+}
+
+func placeMergeName(pl *model.Place) string {
+	name := pl.OriginalText
+	if name == "" {
+		name = pl.FullName
+	}
+	return strings.ReplaceAll(name, "\n", " ")
+}
+
+// isSubset reports whether every string in a is also in b.
+func isSubset(a, b []string) bool {
+	for _, s := range a {
+		found := false
+		for _, t := range b {
+			if s == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// distanceKm returns the great circle distance between a and b in kilometres.
+func distanceKm(a, b *model.GeoLocation) float64 {
+	const earthRadiusKm = 6371
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dlat := lat2 - lat1
+	dlon := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Sin(dlat/2)*math.Sin(dlat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dlon/2)*math.Sin(dlon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
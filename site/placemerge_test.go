@@ -0,0 +1,118 @@
+package site
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/place"
+	kdl "github.com/sblinch/kdl-go"
+)
+
+func TestClusterPlaces(t *testing.T) {
+	england, _ := place.LookupPlaceName("England")
+	wales, _ := place.LookupPlaceName("Wales")
+
+	newPlace := func(id, name string, nation *place.PlaceName, gl *model.GeoLocation) *model.Place {
+		pl := &model.Place{
+			ID:           id,
+			OriginalText: name,
+			FullName:     name,
+			CountryName:  place.UnknownPlaceName(),
+			UKNationName: place.UnknownPlaceName(),
+			GeoLocation:  gl,
+		}
+		if nation != nil {
+			pl.UKNationName = nation
+		}
+		return pl
+	}
+
+	g, err := place.ReadGazetteer(strings.NewReader("id,kind,name,aliases,parents\nshr,county,Shropshire,Salop,England\n"), ',')
+	if err != nil {
+		t.Fatalf("ReadGazetteer: %v", err)
+	}
+
+	pls := []*model.Place{
+		newPlace("shifnal1", "Shifnal, Shropshire, England", england, nil),
+		newPlace("shifnal2", "Shifnal, Salop", nil, nil),
+		newPlace("shifnal3", "Shifnal", nil, nil),
+		newPlace("newton1", "Newton, Suffolk, England", england, nil),
+		newPlace("newton2", "Newton, Cambridgeshire, England", england, nil),
+		newPlace("newton3", "Newton", nil, nil),
+		newPlace("newton4", "Newton, Montgomeryshire, Wales", wales, nil),
+		newPlace("newton5", "newton,  suffolk", nil, nil),
+		newPlace("brewood1", "Brewood, Staffs", nil, &model.GeoLocation{Latitude: 52.677, Longitude: -2.173}),
+		newPlace("brewood2", "Brewood, Staffordshire, England", england, &model.GeoLocation{Latitude: 52.680, Longitude: -2.170}),
+		newPlace("acton1", "Acton, Middlesex", nil, &model.GeoLocation{Latitude: 51.51, Longitude: -0.27}),
+		newPlace("acton2", "Acton, Cheshire", nil, &model.GeoLocation{Latitude: 53.07, Longitude: -2.55}),
+		newPlace("acton3", "Acton", nil, nil),
+	}
+
+	got := make(map[string][]string)
+	for _, pm := range clusterPlaces(pls, g, 10) {
+		var ids []string
+		for _, pl := range pm.Places {
+			ids = append(ids, pl.ID)
+		}
+		sort.Strings(ids)
+		got[pm.Target.ID] = ids
+	}
+
+	want := map[string][]string{
+		"shifnal1": {"shifnal2", "shifnal3"},
+		"newton1":  {"newton5"},
+		"brewood2": {"brewood1"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("merges mismatch (-want +got):\n%s", diff)
+	}
+
+	var b strings.Builder
+	if err := writePlaceMerges(&b, clusterPlaces(pls[:2], g, 10)); err != nil {
+		t.Fatalf("writePlaceMerges: %v", err)
+	}
+	wantKDL := `annotations {
+    places {
+        place id="shifnal2" {
+            // Shifnal, Salop is the same as Shifnal, Shropshire, England
+            sameas "shifnal1"
+        }
+    }
+}
+`
+	if diff := cmp.Diff(wantKDL, b.String()); diff != "" {
+		t.Errorf("kdl mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWritePlaceMergesQuoting(t *testing.T) {
+	target := &model.Place{ID: `zürich "old"`, OriginalText: "Zürich"}
+	merges := []*placeMerge{
+		{
+			Target: target,
+			Places: []*model.Place{{ID: `back\slash`, OriginalText: "Zurich"}},
+		},
+	}
+
+	var b strings.Builder
+	if err := writePlaceMerges(&b, merges); err != nil {
+		t.Fatalf("writePlaceMerges: %v", err)
+	}
+
+	doc, err := kdl.Parse(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("output is not valid kdl: %v\n%s", err, b.String())
+	}
+
+	pl := doc.Nodes[0].Children[0].Children[0]
+	id, _ := pl.Properties.Get("id")
+	if got, want := id.Value, `back\slash`; got != want {
+		t.Errorf("got id %q, wanted %q", got, want)
+	}
+	if got, want := pl.Children[0].Arguments[0].Value, `zürich "old"`; got != want {
+		t.Errorf("got sameas %q, wanted %q", got, want)
+	}
+}
@@ -41,11 +41,24 @@ func NewPublishSet(t *tree.Tree, include model.PersonMatcher) (*PublishSet, erro
 		Events:       make(map[model.TimelineEvent]bool),
 	}
 
+	// events may refer to a copy of a place as it was named and contained at
+	// the date of the event, so the published places are always the tree's
+	// own, with their current hierarchy
+	canonicalPlace := func(pl *model.Place) *model.Place {
+		if cpl, ok := t.Places[pl.ID]; ok {
+			return cpl
+		}
+		return pl
+	}
+
 	includePlace := func(pl *model.Place) {
-		ps.Places[pl.ID] = pl
-		for pl.Parent != nil {
-			ps.Places[pl.Parent.ID] = pl.Parent
-			pl = pl.Parent
+		// include the places that contained it at the time as well as those
+		// that contain it now
+		for cur := pl; cur != nil; cur = cur.Parent {
+			ps.Places[cur.ID] = canonicalPlace(cur)
+		}
+		for cur := canonicalPlace(pl).Parent; cur != nil; cur = cur.Parent {
+			ps.Places[cur.ID] = canonicalPlace(cur)
 		}
 	}
 
@@ -166,7 +179,8 @@ func NewPublishSet(t *tree.Tree, include model.PersonMatcher) (*PublishSet, erro
 		pl := ev.GetPlace()
 		if pl != nil {
 			if _, ok := ps.Places[pl.ID]; !ok {
-				pl.Timeline = model.FilterEventList(pl.Timeline, includedEvents)
+				cpl := canonicalPlace(pl)
+				cpl.Timeline = model.FilterEventList(cpl.Timeline, includedEvents)
 				includePlace(pl)
 			}
 		}
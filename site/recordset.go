@@ -0,0 +1,259 @@
+package site
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/tree"
+)
+
+// recordSetTodos returns a todo for each record that the site's record sets
+// suggest should exist for p but which has not been cited or already searched
+// for without success. Only record sets marked for everyone apply to people who
+// are not direct ancestors.
+func (s *Site) recordSetTodos(p *model.Person) []*model.ToDo {
+	var todos []*model.ToDo
+	for _, rs := range s.RecordSets {
+		// most record sets are only worth searching for direct ancestors
+		if !rs.Everyone && !p.IsDirectAncestor() {
+			continue
+		}
+		if len(rs.Years) > 0 {
+			todos = append(todos, s.snapshotRecordSetTodos(p, rs)...)
+			continue
+		}
+		for _, kind := range rs.Events {
+			for _, ev := range recordSetEvents(p, kind) {
+				yr, ok := ev.GetDate().Year()
+				if !ok || !rs.CoversYear(yr) || !recordSetCoversPlace(rs, ev.GetPlace()) {
+					continue
+				}
+				if recordSetCited(p, rs, kind, ev) || searchedWithNilResult(p, rs.Name, yr) {
+					continue
+				}
+				todos = append(todos, &model.ToDo{
+					Category:  model.ToDoCategoryRecords,
+					Context:   ev.Type() + " event",
+					Goal:      fmt.Sprintf("Search the %s for the %s in %d.", rs.Name, ev.Type(), yr),
+					Reason:    fmt.Sprintf("the %s took place in %s in %d, which is covered by the %s, but no citation from that record set has been found", ev.Type(), ev.GetPlace().NameWithCountry, yr, rs.Name),
+					RecordSet: rs.Name,
+					Hints:     s.researchHints(p, ev),
+				})
+			}
+		}
+	}
+	return todos
+}
+
+// snapshotRecordSetTodos returns a todo for each year in which the record set
+// was taken and p is known to have been alive but no record from that year
+// has been cited.
+func (s *Site) snapshotRecordSetTodos(p *model.Person, rs *tree.RecordSet) []*model.ToDo {
+	if !livedInRecordSetCountry(p, rs) {
+		return nil
+	}
+	var todos []*model.ToDo
+	for _, yr := range rs.Years {
+		if !rs.CoversYear(yr) || !knownAliveIn(p, yr) {
+			continue
+		}
+		if citedInYear(p, rs, yr) || searchedWithNilResult(p, rs.Name, yr) {
+			continue
+		}
+		// the census event being sought, placed where the person was last known to be
+		sought := &model.CensusEvent{
+			GeneralEvent: model.GeneralEvent{Date: model.Year(yr), Place: lastKnownPlace(p, yr)},
+		}
+		todos = append(todos, &model.ToDo{
+			Category:  model.ToDoCategoryRecords,
+			Context:   fmt.Sprintf("%s, %d", rs.Name, yr),
+			Goal:      fmt.Sprintf("Search the %s for the person in %d.", rs.Name, yr),
+			Reason:    fmt.Sprintf("the person was alive in %d and lived where the %s was taken, but no citation from that year has been found", yr, rs.Name),
+			RecordSet: rs.Name,
+			Hints:     s.researchHints(p, sought),
+		})
+	}
+	return todos
+}
+
+// recordSetEvents returns the events of p that a record set recording events
+// of the given kind would be expected to hold a record of.
+func recordSetEvents(p *model.Person, kind string) []model.TimelineEvent {
+	switch kind {
+	case "birth":
+		if p.BestBirthlikeEvent != nil {
+			return []model.TimelineEvent{p.BestBirthlikeEvent}
+		}
+	case "death":
+		if p.BestDeathlikeEvent != nil {
+			return []model.TimelineEvent{p.BestDeathlikeEvent}
+		}
+	case "marriage":
+		var evs []model.TimelineEvent
+		for _, ev := range p.Timeline {
+			if _, ok := ev.(*model.MarriageEvent); ok && ev.DirectlyInvolves(p) {
+				evs = append(evs, ev)
+			}
+		}
+		return evs
+	}
+	return nil
+}
+
+// recordSetEventKind returns the kind of life event, birth, marriage or
+// death, that ev is evidence of, or an empty string if it is none of them.
+func recordSetEventKind(ev model.TimelineEvent) string {
+	switch ev.(type) {
+	case *model.BirthEvent, *model.BaptismEvent:
+		return "birth"
+	case *model.MarriageEvent, *model.MarriageBannsEvent, *model.MarriageLicenseEvent:
+		return "marriage"
+	case *model.DeathEvent, *model.BurialEvent, *model.CremationEvent, *model.ProbateEvent, *model.WillEvent:
+		return "death"
+	}
+	return ""
+}
+
+// recordSetCited reports whether ev, or another event of p that is evidence
+// of the same birth or death, has a citation from the record set. Marriages
+// are only matched against the citations of the marriage itself.
+func recordSetCited(p *model.Person, rs *tree.RecordSet, kind string, ev model.TimelineEvent) bool {
+	if citesRecordSet(ev.GetCitations(), rs) {
+		return true
+	}
+	if kind == "marriage" {
+		return false
+	}
+	for _, other := range p.Timeline {
+		if recordSetEventKind(other) != kind || !other.DirectlyInvolves(p) {
+			continue
+		}
+		if citesRecordSet(other.GetCitations(), rs) {
+			return true
+		}
+	}
+	return false
+}
+
+// citedInYear reports whether any event of p in yr has a citation from the
+// record set.
+func citedInYear(p *model.Person, rs *tree.RecordSet, yr int) bool {
+	for _, ev := range p.Timeline {
+		if evyr, ok := ev.GetDate().Year(); !ok || evyr != yr {
+			continue
+		}
+		if citesRecordSet(ev.GetCitations(), rs) {
+			return true
+		}
+	}
+	return false
+}
+
+// citesRecordSet reports whether any of the citations is of a source that
+// belongs to the record set.
+func citesRecordSet(citations []*model.GeneralCitation, rs *tree.RecordSet) bool {
+	for _, c := range citations {
+		if c.Source.IsUnknown() {
+			continue
+		}
+		if rs.Census && c.Source.IsCensus {
+			return true
+		}
+		if rs.CivilRegistration && c.Source.IsCivilRegistration {
+			return true
+		}
+		for _, phrase := range rs.Sources {
+			if containsPhrase(c.Source.Title, phrase) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsPhrase reports whether the words of phrase appear in s as whole
+// words, compared without regard to case, so "GRO" matches "GRO Birth Index"
+// but not "Grove Street Chapel".
+func containsPhrase(s, phrase string) bool {
+	s, phrase = strings.ToLower(s), strings.ToLower(phrase)
+	if phrase == "" {
+		return false
+	}
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	for start := 0; ; {
+		i := strings.Index(s[start:], phrase)
+		if i == -1 {
+			return false
+		}
+		i += start
+		end := i + len(phrase)
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if (i == 0 || !isWordRune(before)) && (end == len(s) || !isWordRune(after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		start = i + size
+	}
+}
+
+// recordSetCoversPlace reports whether pl lies in one of the countries
+// covered by the record set.
+func recordSetCoversPlace(rs *tree.RecordSet, pl *model.Place) bool {
+	if len(rs.Countries) == 0 {
+		return true
+	}
+	return placeInCountry(pl, rs.CoversCountry)
+}
+
+// livedInRecordSetCountry reports whether any event of p took place in one of
+// the countries covered by the record set.
+func livedInRecordSetCountry(p *model.Person, rs *tree.RecordSet) bool {
+	if len(rs.Countries) == 0 {
+		return true
+	}
+	for _, ev := range p.Timeline {
+		if ev.DirectlyInvolves(p) && recordSetCoversPlace(rs, ev.GetPlace()) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownAliveIn reports whether p is known to have been alive throughout yr:
+// born before it and either died after it or possibly still alive.
+func knownAliveIn(p *model.Person, yr int) bool {
+	if p.BestBirthlikeEvent == nil {
+		return false
+	}
+	byr, ok := p.BestBirthlikeEvent.GetDate().Year()
+	if !ok || byr >= yr {
+		return false
+	}
+	if p.BestDeathlikeEvent == nil || p.BestDeathlikeEvent.GetDate().IsUnknown() {
+		return p.PossiblyAlive
+	}
+	dyr, ok := p.BestDeathlikeEvent.GetDate().Year()
+	return ok && dyr > yr
+}
+
+// lastKnownPlace returns the place of the last event of p before or in yr
+// that has a known place, or nil if there is none.
+func lastKnownPlace(p *model.Person, yr int) *model.Place {
+	var pl *model.Place
+	latest := 0
+	for _, ev := range p.Timeline {
+		evyr, ok := ev.GetDate().Year()
+		if !ok || evyr > yr || evyr < latest || ev.GetPlace().IsUnknown() || !ev.DirectlyInvolves(p) {
+			continue
+		}
+		pl = ev.GetPlace()
+		latest = evyr
+	}
+	return pl
+}
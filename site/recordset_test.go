@@ -0,0 +1,89 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/place"
+	"github.com/iand/genster/tree"
+)
+
+func TestRecordSetTodos(t *testing.T) {
+	england := &model.Place{ID: "pl1", NameWithCountry: "Harston, England", UKNationName: &place.PlaceName{Name: "England"}}
+	gro := &model.Source{ID: "s1", Title: "GRO Birth Index", IsCivilRegistration: true}
+	census := &model.Source{ID: "s2", Title: "1861 Census", IsCensus: true}
+
+	p := &model.Person{ID: "p1"}
+	p.RelationToKeyPerson = model.Self(p)
+	birth := &model.BirthEvent{
+		GeneralEvent:           model.GeneralEvent{Date: model.PreciseDate(1850, 6, 1), Place: england, Citations: []*model.GeneralCitation{{ID: "c1", Source: gro}}},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+	}
+	censusEv := &model.CensusEvent{
+		GeneralEvent: model.GeneralEvent{Date: model.PreciseDate(1861, 4, 7), Place: england, Citations: []*model.GeneralCitation{{ID: "c2", Source: census}}},
+		Entries:      []*model.CensusEntry{{Principal: p}},
+	}
+	death := &model.DeathEvent{
+		GeneralEvent:           model.GeneralEvent{Date: model.PreciseDate(1885, 2, 3), Place: england},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+	}
+	p.BestBirthlikeEvent = birth
+	p.BestDeathlikeEvent = death
+	p.Timeline = []model.TimelineEvent{birth, censusEv, death}
+	p.Searches = []*model.Search{{RecordSet: "Census", From: 1871, To: 1871, Result: model.SearchResultNil, People: []*model.Person{p}}}
+
+	s := &Site{
+		RecordSets: []*tree.RecordSet{
+			{Name: "GRO births", Events: []string{"birth"}, From: 1837, Countries: []string{"England", "Wales"}, CivilRegistration: true},
+			{Name: "GRO deaths", Events: []string{"death"}, From: 1837, Countries: []string{"England", "Wales"}, CivilRegistration: true},
+			{Name: "Scottish deaths", Events: []string{"death"}, From: 1855, Countries: []string{"Scotland"}},
+			{Name: "Parish burials", Events: []string{"death"}, To: 1812, Countries: []string{"England"}},
+			{Name: "Census", Years: []int{1841, 1851, 1861, 1871, 1881, 1891}, Countries: []string{"England"}, Census: true},
+		},
+	}
+
+	var got []string
+	for _, td := range s.recordSetTodos(p) {
+		got = append(got, td.RecordSet+": "+td.Context)
+	}
+	want := []string{
+		"GRO deaths: death event",
+		"Census: Census, 1851",
+		"Census: Census, 1881",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("todos mismatch (-want +got):\n%s", diff)
+	}
+
+	// only record sets marked for everyone apply to other relatives
+	p.RelationToKeyPerson = nil
+	s.RecordSets[1].Everyone = true
+	got = got[:0]
+	for _, td := range s.recordSetTodos(p) {
+		got = append(got, td.RecordSet+": "+td.Context)
+	}
+	if diff := cmp.Diff([]string{"GRO deaths: death event"}, got); diff != "" {
+		t.Errorf("todos for non-ancestor mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestContainsPhrase(t *testing.T) {
+	for _, tt := range []struct {
+		s      string
+		phrase string
+		want   bool
+	}{
+		{s: "GRO Birth Index", phrase: "GRO", want: true},
+		{s: "England & Wales, FreeBMD Birth Index", phrase: "freebmd", want: true},
+		{s: "Grove Street Chapel registers", phrase: "GRO", want: false},
+		{s: "Bishop's Transcripts, Norfolk", phrase: "Bishop's Transcripts", want: true},
+		{s: "Parishes of Suffolk", phrase: "Parish", want: false},
+		{s: "Monograph, GRO", phrase: "GRO", want: true},
+		{s: "anything", phrase: "", want: false},
+	} {
+		if got := containsPhrase(tt.s, tt.phrase); got != tt.want {
+			t.Errorf("containsPhrase(%q, %q): got %v, wanted %v", tt.s, tt.phrase, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package site
+
+import (
+	"fmt"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/render"
+	"github.com/iand/genster/render/md"
+	"github.com/iand/genster/text"
+)
+
+// searchItems returns a list item for each of searches describing what was
+// searched and what was found, linked to the diary entry that logged it.
+func searchItems(enc render.TextEncoder[md.Text], searches []*model.Search) []md.Text {
+	items := make([]md.Text, 0, len(searches))
+	for _, sr := range searches {
+		what := "searched " + sr.RecordSet
+		if sr.Repository != "" {
+			what += " at " + sr.Repository
+		}
+		if yrs := searchYears(sr); yrs != "" {
+			what += ", " + yrs
+		}
+
+		item := enc.EncodeLink(enc.EncodeText(sr.Link.Title), sr.Link.URL) + enc.EncodeText(": "+text.FinishSentence(what)+" ")
+
+		var found []string
+		for _, c := range sr.Citations {
+			found = append(found, string(enc.EncodeModelLink(enc.EncodeText(c.String()), c)))
+		}
+		switch sr.Result {
+		case model.SearchResultNil:
+			item += enc.EncodeText("Nothing was found.")
+		case model.SearchResultFound:
+			if len(found) > 0 {
+				item += enc.EncodeText("Found ") + md.Text(text.JoinList(found)) + enc.EncodeText(".")
+			} else {
+				item += enc.EncodeText("The records sought were found.")
+			}
+		case model.SearchResultPartial:
+			if len(found) > 0 {
+				item += enc.EncodeText("Some of the records sought were found: ") + md.Text(text.JoinList(found)) + enc.EncodeText(".")
+			} else {
+				item += enc.EncodeText("Some of the records sought were found.")
+			}
+		}
+		if sr.Notes != "" {
+			item += enc.EncodeText(" " + text.FinishSentence(sr.Notes))
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// searchYears describes the years covered by a search, or returns an empty
+// string if the search was not limited to a range of years.
+func searchYears(sr *model.Search) string {
+	switch {
+	case sr.From != 0 && sr.To != 0:
+		return fmt.Sprintf("%d to %d", sr.From, sr.To)
+	case sr.From != 0:
+		return fmt.Sprintf("from %d", sr.From)
+	case sr.To != 0:
+		return fmt.Sprintf("up to %d", sr.To)
+	}
+	return ""
+}
+
+// nilSearches returns the searches in searches that found nothing.
+func nilSearches(searches []*model.Search) []*model.Search {
+	var out []*model.Search
+	for _, sr := range searches {
+		if sr.Result == model.SearchResultNil {
+			out = append(out, sr)
+		}
+	}
+	return out
+}
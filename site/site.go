@@ -15,6 +15,7 @@ import (
 	"github.com/iand/gedcom"
 	"github.com/iand/genster/chart"
 	"github.com/iand/genster/layout"
+	"github.com/iand/genster/locale"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
 	"github.com/iand/genster/narrative"
@@ -38,6 +39,8 @@ const (
 	PageLayoutFamily          = layout.PageLayoutFamily
 	PageLayoutCitation        = layout.PageLayoutCitation
 	PageLayoutListInferences  = layout.PageLayoutListInferences
+	PageLayoutListNegative    = layout.PageLayoutListNegative
+	PageLayoutGallery         = layout.PageLayoutGallery
 	PageLayoutListAnomalies   = layout.PageLayoutListAnomalies
 	PageLayoutListTodo        = layout.PageLayoutListTodo
 	PageLayoutListPeople      = layout.PageLayoutListPeople
@@ -52,6 +55,7 @@ const (
 	PageLayoutTreeOverview    = layout.PageLayoutTreeOverview
 	PageLayoutChartAncestors  = layout.PageLayoutChartAncestors
 	PageLayoutChartTrees      = layout.PageLayoutChartTrees
+	PageLayoutStatistics      = layout.PageLayoutStatistics
 )
 
 const (
@@ -64,6 +68,7 @@ const (
 	PageSectionMedia      = "media"
 	PageSectionFamily     = "family"
 	PageSectionFamilyLine = "familyline"
+	PageSectionStatistics = "statistics"
 )
 
 const (
@@ -74,6 +79,12 @@ const (
 	PageCategoryFamily   = "family"
 )
 
+// Orders in which the entries of the todo list can be sorted.
+const (
+	TodoOrderName         = "name"         // alphabetically by person name
+	TodoOrderCompleteness = "completeness" // least well researched people first
+)
+
 type Site struct {
 	BaseURL   string
 	Tree      *tree.Tree
@@ -108,6 +119,7 @@ type Site struct {
 	ListInferencesDir  string
 	ListAnomaliesDir   string
 	ListTodoDir        string
+	ListNegativeDir    string
 	ListPeopleDir      string
 	ListPlacesDir      string
 	ListSourcesDir     string
@@ -119,15 +131,34 @@ type Site struct {
 	ChartAncestorsDir string
 	ChartTreesDir     string
 	GedcomDir         string
+	StatisticsDir     string
 
 	IncludePrivate     bool
 	IncludeDebugInfo   bool
 	ExperimentFamilies bool
 	MapTilerAPIKey     string // API key for MapTiler Cloud (NLS historic maps)
+	TodoOrder          string // order of the people in the todo list, one of TodoOrderName or TodoOrderCompleteness
+	JSONData           bool   // write a JSON document alongside each person, place, citation and family page
+	SiteURL            string // absolute URL of the published site, such as https://example.com, used for schema.org identifiers
+
+	// PersonCharts configures the charts embedded in person pages, nil if none should be generated
+	PersonCharts *tree.PersonChartConfig
+
+	// RecordSets describes the record sets used to suggest records to search for
+	RecordSets []*tree.RecordSet
+
+	// Locale supplies the wording of narrative text in the site's language
+	Locale locale.Locale
+
+	// HintProviders build links to searches of external record collections for person pages and todos
+	HintProviders []HintProvider
 
 	// PublishSet is the set of objects that will have pages written
 	PublishSet *PublishSet
-	Changelog  []*Change
+
+	// galleryLinks holds the links to the gallery pages written for people, families and sources
+	galleryLinks map[any]string
+	Changelog    []*Change
 }
 
 type Change struct {
@@ -142,6 +173,7 @@ func NewSite(baseURL string, t *tree.Tree) *Site {
 		BaseURL:   baseURL,
 		Tree:      t,
 		Calendars: make(map[int]*Calendar),
+		Locale:    locale.Default,
 
 		PersonDir:         PageSectionPerson,
 		PersonLinkPattern: path.Join(baseURL, PageSectionPerson, "/%s/"),
@@ -183,6 +215,7 @@ func NewSite(baseURL string, t *tree.Tree) *Site {
 		ListInferencesDir:  path.Join(PageSectionList, "inferences"),
 		ListAnomaliesDir:   path.Join(PageSectionList, "anomalies"),
 		ListTodoDir:        path.Join(PageSectionList, "todo"),
+		ListNegativeDir:    path.Join(PageSectionList, "negative"),
 		ListPeopleDir:      path.Join(PageSectionList, "people"),
 		ListPlacesDir:      path.Join(PageSectionList, "places"),
 		ListSourcesDir:     path.Join(PageSectionList, "sources"),
@@ -194,6 +227,7 @@ func NewSite(baseURL string, t *tree.Tree) *Site {
 		ChartAncestorsDir: path.Join(PageSectionChart, "ancestors"),
 		ChartTreesDir:     path.Join(PageSectionChart, "trees"),
 		GedcomDir:         path.Join(PageSectionChart, "gedcom"),
+		StatisticsDir:     PageSectionStatistics,
 
 		PublishSet: nil,
 	}
@@ -209,6 +243,7 @@ func (s *Site) Generate() error {
 		// GenerateOlb(p)
 		s.ScanPersonTodos(p)
 		s.ScanPersonForAnomalies(p)
+		p.Completeness = model.EvaluateCompleteness(p)
 		s.AssignTags(p)
 		s.DetectPuzzles(p)
 	}
@@ -572,11 +607,21 @@ func FlattenMapByValueDesc[M ~map[K]V, K simplevalue, V simplevalue](m M) []Tupl
 }
 
 func (s *Site) WritePages(contentDir string) error {
+	s.WriteMediaDerivatives(contentDir)
+	if err := s.WriteGalleryPages(contentDir); err != nil {
+		return fmt.Errorf("write gallery pages: %w", err)
+	}
+
 	for _, p := range s.PublishSet.People {
 		if s.LinkFor(p) == "" {
 			continue
 		}
-		d, err := RenderPersonPage(s, p)
+		charts, err := s.WritePersonCharts(p, contentDir)
+		if err != nil {
+			return fmt.Errorf("write person charts for %s: %w", p.ID, err)
+		}
+
+		d, err := RenderPersonPage(s, p, charts)
 		if err != nil {
 			return fmt.Errorf("render person page: %w", err)
 		}
@@ -763,6 +808,10 @@ func (s *Site) WritePages(contentDir string) error {
 		return fmt.Errorf("write todo pages: %w", err)
 	}
 
+	if err := s.WriteNegativeEvidenceListPages(contentDir); err != nil {
+		return fmt.Errorf("write negative evidence pages: %w", err)
+	}
+
 	if err := s.WriteTreeOverview(contentDir); err != nil {
 		return fmt.Errorf("write tree overview: %w", err)
 	}
@@ -771,6 +820,10 @@ func (s *Site) WritePages(contentDir string) error {
 		return fmt.Errorf("write ancestor chart: %w", err)
 	}
 
+	if err := s.WriteStatisticsPages(contentDir); err != nil {
+		return fmt.Errorf("write statistics pages: %w", err)
+	}
+
 	if err := s.WriteGedcom(contentDir); err != nil {
 		return fmt.Errorf("write gedcom: %w", err)
 	}
@@ -779,6 +832,12 @@ func (s *Site) WritePages(contentDir string) error {
 		return fmt.Errorf("write change log: %w", err)
 	}
 
+	if s.JSONData {
+		if err := s.WriteJSONData(contentDir); err != nil {
+			return fmt.Errorf("write json data: %w", err)
+		}
+	}
+
 	// if err := s.WriteChartTrees(root); err != nil {
 	// 	return fmt.Errorf("write chart trees: %w", err)
 	// }
@@ -1001,6 +1060,9 @@ func (s *Site) WriteTreeOverview(root string) error {
 
 	}
 
+	doc.EmptyPara()
+	doc.Para(md.Text(text.FormatSentence(text.JoinSentenceParts("See", doc.EncodeLink("more statistics", s.StatisticsDir).String(), "on lifespans, marriages, families, names, occupations and sources"))))
+
 	var notes string
 	if !s.Tree.KeyPerson.IsUnknown() {
 		doc.EmptyPara()
@@ -1073,10 +1135,10 @@ func (s *Site) WriteChartAncestors(root string) error {
 				adds = append(adds, ancestors[i].Epithet)
 			}
 			if ancestors[i].BestBirthlikeEvent != nil && !ancestors[i].BestBirthlikeEvent.GetDate().IsUnknown() {
-				adds = append(adds, narrative.EventWhatWhenWhere(ancestors[i].BestBirthlikeEvent, doc, narrative.DefaultNameChooser{}))
+				adds = append(adds, narrative.EventWhatWhenWhere(ancestors[i].BestBirthlikeEvent, doc, narrative.DefaultNameChooser{}, s.Locale))
 			}
 			if ancestors[i].BestDeathlikeEvent != nil && !ancestors[i].BestDeathlikeEvent.GetDate().IsUnknown() {
-				adds = append(adds, narrative.EventWhatWhenWhere(ancestors[i].BestDeathlikeEvent, doc, narrative.DefaultNameChooser{}))
+				adds = append(adds, narrative.EventWhatWhenWhere(ancestors[i].BestDeathlikeEvent, doc, narrative.DefaultNameChooser{}, s.Locale))
 			}
 
 			detail = text.AppendClause(detail, text.JoinList(adds))
@@ -1194,7 +1256,7 @@ func (s *Site) WriteChartTrees(root string) error {
 }
 
 func (s *Site) WriteDescendantTree(fname string, p *model.Person, depth int) error {
-	ch, err := chart.BuildDescendantChart(s.Tree, p, 3, depth, true, "direct", true, true, true)
+	ch, _, err := chart.BuildDescendantChart(s.Tree, p, 3, depth, true, "direct", true, true, true)
 	if err != nil {
 		return fmt.Errorf("build lineage: %w", err)
 	}
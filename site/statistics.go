@@ -0,0 +1,905 @@
+package site
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/render/md"
+	"github.com/iand/genster/text"
+)
+
+// An AgeSummary accumulates ages so that their average can be reported.
+type AgeSummary struct {
+	Count int
+	Total int
+}
+
+func (a *AgeSummary) Add(age int) {
+	a.Count++
+	a.Total += age
+}
+
+// Mean returns the average of the ages added to the summary, or zero if there are none.
+func (a AgeSummary) Mean() float64 {
+	if a.Count == 0 {
+		return 0
+	}
+	return float64(a.Total) / float64(a.Count)
+}
+
+// DecadeLifespan summarises the ages at death of the people born in a decade.
+type DecadeLifespan struct {
+	Decade int // first year of the decade
+	Male   AgeSummary
+	Female AgeSummary
+}
+
+// LifespansByBirthDecade returns the ages at death of people grouped by the decade of
+// their birth, ordered by decade. Only people with a known age at death are included.
+// It excludes redacted people.
+func (ps *PublishSet) LifespansByBirthDecade() []DecadeLifespan {
+	byDecade := make(map[int]*DecadeLifespan)
+	for _, p := range ps.People {
+		if p.Redacted {
+			continue
+		}
+		decade, ok := p.BestBirthDate().DecadeStart()
+		if !ok {
+			continue
+		}
+		age, ok := p.AgeInYearsAtDeath()
+		if !ok {
+			continue
+		}
+		dl, ok := byDecade[decade]
+		if !ok {
+			dl = &DecadeLifespan{Decade: decade}
+			byDecade[decade] = dl
+		}
+		switch {
+		case p.Gender.IsMale():
+			dl.Male.Add(age)
+		case p.Gender.IsFemale():
+			dl.Female.Add(age)
+		}
+	}
+
+	list := make([]DecadeLifespan, 0, len(byDecade))
+	for _, dl := range byDecade {
+		list = append(list, *dl)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Decade < list[j].Decade })
+	return list
+}
+
+// DecadeInfantMortality records the number of people born in a decade and how many of
+// them died before their first birthday.
+type DecadeInfantMortality struct {
+	Decade       int // first year of the decade
+	Births       int
+	InfantDeaths int
+}
+
+// Rate returns the proportion of births that resulted in an infant death.
+func (d DecadeInfantMortality) Rate() float64 {
+	if d.Births == 0 {
+		return 0
+	}
+	return float64(d.InfantDeaths) / float64(d.Births)
+}
+
+// InfantMortalityByDecade returns the number of births and infant deaths for each decade,
+// ordered by decade. It excludes redacted people.
+func (ps *PublishSet) InfantMortalityByDecade() []DecadeInfantMortality {
+	byDecade := make(map[int]*DecadeInfantMortality)
+	for _, p := range ps.People {
+		if p.Redacted {
+			continue
+		}
+		decade, ok := p.BestBirthDate().DecadeStart()
+		if !ok {
+			continue
+		}
+		dm, ok := byDecade[decade]
+		if !ok {
+			dm = &DecadeInfantMortality{Decade: decade}
+			byDecade[decade] = dm
+		}
+		dm.Births++
+		if age, ok := p.AgeInYearsAtDeath(); ok && age == 0 {
+			dm.InfantDeaths++
+		}
+	}
+
+	list := make([]DecadeInfantMortality, 0, len(byDecade))
+	for _, dm := range byDecade {
+		list = append(list, *dm)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Decade < list[j].Decade })
+	return list
+}
+
+// An AgeBand counts the men and women whose age fell within a range.
+type AgeBand struct {
+	Label  string
+	Min    int // lowest age in the band
+	Max    int // highest age in the band, or -1 if the band is unbounded
+	Male   int
+	Female int
+}
+
+// MarriageAges summarises the ages at which people first married.
+type MarriageAges struct {
+	Male   AgeSummary
+	Female AgeSummary
+	Bands  []AgeBand
+}
+
+// AgeAtFirstMarriage returns a summary of the ages at which people married for the first
+// time, using the earliest dated marriage for each person. It excludes redacted people.
+func (ps *PublishSet) AgeAtFirstMarriage() MarriageAges {
+	ma := MarriageAges{
+		Bands: []AgeBand{
+			{Label: "Under 20", Min: 0, Max: 19},
+			{Label: "20–24", Min: 20, Max: 24},
+			{Label: "25–29", Min: 25, Max: 29},
+			{Label: "30–34", Min: 30, Max: 34},
+			{Label: "35–39", Min: 35, Max: 39},
+			{Label: "40 and over", Min: 40, Max: -1},
+		},
+	}
+
+	for _, p := range ps.People {
+		if p.Redacted || (!p.Gender.IsMale() && !p.Gender.IsFemale()) {
+			continue
+		}
+		var first *model.Date
+		for _, f := range p.Families {
+			if f.Bond != model.FamilyBondMarried || f.BestStartEvent == nil {
+				continue
+			}
+			dt := f.BestStartEvent.GetDate()
+			if dt.IsUnknown() {
+				continue
+			}
+			if first == nil || dt.SortsBefore(first) {
+				first = dt
+			}
+		}
+		if first == nil {
+			continue
+		}
+		age, ok := p.AgeInYearsAt(first)
+		if !ok || age < 0 {
+			continue
+		}
+
+		if p.Gender.IsMale() {
+			ma.Male.Add(age)
+		} else {
+			ma.Female.Add(age)
+		}
+		countInAgeBand(ma.Bands, age, p.Gender)
+	}
+
+	return ma
+}
+
+// AgeAtDeathDistribution returns the number of men and women whose age at death fell
+// within each of a series of bands, ordered by age. It excludes redacted people.
+func (ps *PublishSet) AgeAtDeathDistribution() []AgeBand {
+	bands := []AgeBand{
+		{Label: "Under 1", Min: 0, Max: 0},
+		{Label: "1–4", Min: 1, Max: 4},
+		{Label: "5–14", Min: 5, Max: 14},
+		{Label: "15–29", Min: 15, Max: 29},
+		{Label: "30–44", Min: 30, Max: 44},
+		{Label: "45–59", Min: 45, Max: 59},
+		{Label: "60–74", Min: 60, Max: 74},
+		{Label: "75–89", Min: 75, Max: 89},
+		{Label: "90 and over", Min: 90, Max: -1},
+	}
+
+	for _, p := range ps.People {
+		if p.Redacted {
+			continue
+		}
+		age, ok := p.AgeInYearsAtDeath()
+		if !ok || age < 0 {
+			continue
+		}
+		countInAgeBand(bands, age, p.Gender)
+	}
+
+	return bands
+}
+
+// countInAgeBand adds a person of gender g to the band that age falls within. People
+// of unknown gender are not counted.
+func countInAgeBand(bands []AgeBand, age int, g model.Gender) {
+	for i := range bands {
+		b := &bands[i]
+		if age >= b.Min && (b.Max == -1 || age <= b.Max) {
+			switch {
+			case g.IsMale():
+				b.Male++
+			case g.IsFemale():
+				b.Female++
+			}
+			return
+		}
+	}
+}
+
+// maxChildrenPerFamily is the number of children above which families are counted together.
+const maxChildrenPerFamily = 12
+
+// ChildrenPerFamily returns the number of families with each number of children. The
+// final entry counts families with maxChildrenPerFamily or more children. Families whose
+// parents are both redacted or unknown are excluded.
+func (ps *PublishSet) ChildrenPerFamily() []int {
+	counts := make([]int, maxChildrenPerFamily+1)
+	for _, f := range ps.Families {
+		if (f.Father.IsUnknown() || f.Father.Redacted) && (f.Mother.IsUnknown() || f.Mother.Redacted) {
+			continue
+		}
+		counts[min(len(f.Children), maxChildrenPerFamily)]++
+	}
+	return counts
+}
+
+// A NameCount is the number of people sharing a name.
+type NameCount struct {
+	Name  string
+	Count int
+}
+
+// ForenameEra lists the most common forenames given to people born within an era.
+type ForenameEra struct {
+	Start  int // first year of the era
+	End    int // last year of the era
+	Male   []NameCount
+	Female []NameCount
+}
+
+// forenameEraLength is the number of years in each era when counting forenames.
+const forenameEraLength = 50
+
+// CommonForenamesByEra returns the most common first forenames of men and women for each
+// fifty year period, ordered by period. It excludes redacted and unidentified people.
+func (ps *PublishSet) CommonForenamesByEra(limit int) []ForenameEra {
+	type counts struct {
+		male, female map[string]int
+	}
+	byEra := make(map[int]*counts)
+	for _, p := range ps.People {
+		if p.Redacted || p.Unidentified {
+			continue
+		}
+		yr, ok := p.BestBirthDate().Year()
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(p.PreferredGivenName)
+		if len(fields) == 0 || fields[0] == model.UnknownNamePlaceholder {
+			continue
+		}
+		name := fields[0]
+
+		era := yr - yr%forenameEraLength
+		c, ok := byEra[era]
+		if !ok {
+			c = &counts{male: make(map[string]int), female: make(map[string]int)}
+			byEra[era] = c
+		}
+		switch {
+		case p.Gender.IsMale():
+			c.male[name]++
+		case p.Gender.IsFemale():
+			c.female[name]++
+		}
+	}
+
+	list := make([]ForenameEra, 0, len(byEra))
+	for era, c := range byEra {
+		list = append(list, ForenameEra{
+			Start:  era,
+			End:    era + forenameEraLength - 1,
+			Male:   topNames(c.male, limit),
+			Female: topNames(c.female, limit),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Start < list[j].Start })
+	return list
+}
+
+func topNames(m map[string]int, limit int) []NameCount {
+	list := make([]NameCount, 0, len(m))
+	for name, n := range m {
+		list = append(list, NameCount{Name: name, Count: n})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Name < list[j].Name
+	})
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// OccupationGroupDistribution returns a map of occupation groups and the number of people
+// whose occupations fall mainly within that group.
+// It excludes redacted people.
+func (ps *PublishSet) OccupationGroupDistribution() map[string]int {
+	dist := make(map[string]int)
+	for _, p := range ps.People {
+		if p.Redacted || p.OccupationGroup == model.OccupationGroupUnknown {
+			continue
+		}
+		dist[text.UpperFirst(string(p.OccupationGroup))]++
+	}
+	return dist
+}
+
+// SocialClassDistribution returns a map of social classes and the number of
+// people whose main occupation falls within that class.
+// It excludes redacted people.
+func (ps *PublishSet) SocialClassDistribution() map[model.SocialClass]int {
+	dist := make(map[model.SocialClass]int)
+	for _, p := range ps.People {
+		if p.Redacted || p.SocialClass == model.SocialClassUnknown {
+			continue
+		}
+		dist[p.SocialClass]++
+	}
+	return dist
+}
+
+// CommonOccupations returns up to limit of the most common occupations,
+// by their normalised titles, with the number of people who followed each.
+// A person is counted once for each different occupation.
+// It excludes redacted people.
+func (ps *PublishSet) CommonOccupations(limit int) []NameCount {
+	counts := make(map[string]int)
+	for _, p := range ps.People {
+		if p.Redacted {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, occ := range p.Occupations {
+			if occ.IsUnknown() || occ.Name == "" || seen[occ.Name] {
+				continue
+			}
+			seen[occ.Name] = true
+			counts[occ.Name]++
+		}
+	}
+	return topNames(counts, limit)
+}
+
+// Source types used to summarise citation coverage.
+const (
+	SourceTypeCivilRegistration = "Civil registration"
+	SourceTypeCensus            = "Census"
+	SourceTypeUnreliable        = "Unreliable sources"
+	SourceTypeOther             = "Other sources"
+)
+
+// SourceTypes lists the source types in the order they should be reported.
+var SourceTypes = []string{SourceTypeCivilRegistration, SourceTypeCensus, SourceTypeOther, SourceTypeUnreliable}
+
+// SourceType returns the type of record held by a source.
+func SourceType(so *model.Source) string {
+	switch {
+	case so.IsUnknown():
+		return SourceTypeOther
+	case so.IsUnreliable:
+		return SourceTypeUnreliable
+	case so.IsCivilRegistration:
+		return SourceTypeCivilRegistration
+	case so.IsCensus:
+		return SourceTypeCensus
+	default:
+		return SourceTypeOther
+	}
+}
+
+// SourceTypeCoverage records how widely a type of source has been cited.
+type SourceTypeCoverage struct {
+	Type      string
+	Citations int // number of citations of sources of this type
+	People    int // number of people with at least one citation of this type
+}
+
+// SourceCoverage summarises the use of sources across the people in the tree.
+type SourceCoverage struct {
+	People          int // number of people considered
+	Uncited         int // number of people without any citations
+	CitedBirth      int // number of people whose birth is supported by a citation
+	CitedDeath      int // number of people whose death is supported by a citation
+	KnownToHaveDied int // number of people who are known to have died
+	Types           []SourceTypeCoverage
+}
+
+// SourceCoverage returns a summary of the types of sources cited for people in the tree.
+// It excludes redacted people.
+func (ps *PublishSet) SourceCoverage() SourceCoverage {
+	var sc SourceCoverage
+	types := make(map[string]*SourceTypeCoverage)
+	for _, t := range SourceTypes {
+		types[t] = &SourceTypeCoverage{Type: t}
+	}
+
+	for _, p := range ps.People {
+		if p.Redacted {
+			continue
+		}
+		sc.People++
+
+		cits := p.AllCitations()
+		if len(cits) == 0 {
+			sc.Uncited++
+		}
+		seen := make(map[string]bool)
+		for _, c := range cits {
+			if c.Redacted {
+				continue
+			}
+			st := SourceType(c.Source)
+			types[st].Citations++
+			if !seen[st] {
+				types[st].People++
+				seen[st] = true
+			}
+		}
+
+		if p.BestBirthlikeEvent != nil && len(p.BestBirthlikeEvent.GetCitations()) > 0 {
+			sc.CitedBirth++
+		}
+		if p.BestDeathlikeEvent != nil {
+			sc.KnownToHaveDied++
+			if len(p.BestDeathlikeEvent.GetCitations()) > 0 {
+				sc.CitedDeath++
+			}
+		}
+	}
+
+	for _, t := range SourceTypes {
+		sc.Types = append(sc.Types, *types[t])
+	}
+	return sc
+}
+
+// maxCompletenessGenerations is the number of generations of ancestors of the key person
+// that are considered when summarising research completeness.
+const maxCompletenessGenerations = 10
+
+// GenerationCompleteness summarises the research completeness of one generation of the
+// key person's ancestors.
+type GenerationCompleteness struct {
+	Generation int // generations back from the key person: 1 for parents, 2 for grandparents and so on
+	Possible   int // number of ancestors the generation could contain
+	Identified int // number of ancestors in the generation who have been identified
+	TotalScore int // sum of the completeness scores of the identified ancestors
+}
+
+// Score returns the average completeness score of the generation, treating ancestors who
+// have not been identified as having a score of zero.
+func (g GenerationCompleteness) Score() float64 {
+	if g.Possible == 0 {
+		return 0
+	}
+	return float64(g.TotalScore) / float64(g.Possible)
+}
+
+// IdentifiedScore returns the average completeness score of the identified ancestors in the generation.
+func (g GenerationCompleteness) IdentifiedScore() float64 {
+	if g.Identified == 0 {
+		return 0
+	}
+	return float64(g.TotalScore) / float64(g.Identified)
+}
+
+// AncestorCompletenessByGeneration returns the research completeness of each generation of
+// the key person's ancestors, ending with the most distant generation that contains an
+// identified ancestor.
+func (ps *PublishSet) AncestorCompletenessByGeneration() []GenerationCompleteness {
+	if ps.KeyPerson.IsUnknown() {
+		return nil
+	}
+
+	ancestors := ps.Ancestors(ps.KeyPerson, maxCompletenessGenerations)
+	gens := make([]GenerationCompleteness, maxCompletenessGenerations)
+	last := -1
+	for i := range gens {
+		gens[i].Generation = i + 1
+		gens[i].Possible = 1 << (i + 1)
+	}
+	for i, p := range ancestors {
+		if p.IsUnknown() {
+			continue
+		}
+		// ancestors are numbered from 2, with each generation starting at a power of two
+		g := -1
+		for idx := i + 2; idx > 1; idx >>= 1 {
+			g++
+		}
+		gens[g].Identified++
+		if p.Completeness != nil {
+			gens[g].TotalScore += p.Completeness.Score()
+		}
+		last = max(last, g)
+	}
+
+	return gens[:last+1]
+}
+
+// ancestorGenerationLabel returns a name for a generation of ancestors, where 1 is the parents.
+func ancestorGenerationLabel(g int) string {
+	switch g {
+	case 1:
+		return "Parents"
+	case 2:
+		return "Grandparents"
+	case 3:
+		return "Great-Grandparents"
+	case 4:
+		return "Great-Great-Grandparents"
+	default:
+		return fmt.Sprintf("%dx Great-Grandparents", g-2)
+	}
+}
+
+// A statisticsPage is one of the pages in the statistics section of the site.
+type statisticsPage struct {
+	Slug    string
+	Title   string
+	Summary string
+	Render  func(s *Site, doc *md.Document) (chart string)
+}
+
+var statisticsPages = []statisticsPage{
+	{Slug: "lifespans", Title: "Lifespans", Summary: "The average age at death of the people in the tree by the decade of their birth.", Render: renderLifespanStatistics},
+	{Slug: "infant-mortality", Title: "Infant Mortality", Summary: "The proportion of children in the tree who died before their first birthday by the decade of their birth.", Render: renderInfantMortalityStatistics},
+	{Slug: "marriage", Title: "Age at First Marriage", Summary: "The ages at which the people in the tree first married.", Render: renderMarriageStatistics},
+	{Slug: "children", Title: "Children per Family", Summary: "The number of children recorded for each family in the tree.", Render: renderChildrenStatistics},
+	{Slug: "forenames", Title: "Common Forenames", Summary: "The most popular forenames given to people in the tree in each half century.", Render: renderForenameStatistics},
+	{Slug: "occupations", Title: "Occupations", Summary: "The kinds of work done by the people in the tree.", Render: renderOccupationStatistics},
+	{Slug: "sources", Title: "Source Coverage", Summary: "The types of sources cited as evidence for the people in the tree.", Render: renderSourceStatistics},
+	{Slug: "completeness", Title: "Ancestor Research Completeness", Summary: "How much of the expected evidence has been found for each generation of ancestors.", Render: renderCompletenessStatistics},
+}
+
+// WriteStatisticsPages writes the statistics section of the site: an index page and one
+// page per topic, each with a table of figures and, where useful, an SVG bar chart.
+func (s *Site) WriteStatisticsPages(root string) error {
+	baseDir := filepath.Join(root, s.StatisticsDir)
+
+	index := s.NewDocument()
+	index.Title("Statistics")
+	index.Summary("Statistics describing the people and families in this tree.")
+	index.Layout(PageLayoutStatistics.String())
+	index.SetSitemapDisable()
+	index.Para("These figures are drawn from the people and families recorded in this tree. They reflect the research that has been done so far and are not representative of the wider population.")
+
+	items := make([]md.Text, 0, len(statisticsPages))
+	for _, sp := range statisticsPages {
+		doc := s.NewDocument()
+		doc.Title(sp.Title)
+		doc.Summary(sp.Summary)
+		doc.Layout(PageLayoutStatistics.String())
+		doc.SetSitemapDisable()
+
+		svg := sp.Render(s, doc)
+		if svg != "" {
+			fname := filepath.Join(baseDir, sp.Slug, "chart.svg")
+			f, err := CreateFile(fname)
+			if err != nil {
+				return fmt.Errorf("create chart file: %w", err)
+			}
+			if _, err := f.WriteString(svg); err != nil {
+				f.Close()
+				return fmt.Errorf("write chart: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("close chart: %w", err)
+			}
+		}
+
+		if err := writePage(doc, filepath.Join(baseDir, sp.Slug), "index.md"); err != nil {
+			return fmt.Errorf("write %s statistics page: %w", sp.Slug, err)
+		}
+		items = append(items, md.Text(text.JoinSentenceParts(index.EncodeLink(index.EncodeText(sp.Title), path.Join(s.BaseURL, s.StatisticsDir, sp.Slug)+"/").String(), "–", sp.Summary)))
+	}
+	index.UnorderedList(items)
+
+	if err := writePage(index, baseDir, "index.md"); err != nil {
+		return fmt.Errorf("write statistics index: %w", err)
+	}
+	return nil
+}
+
+// statisticsFigure adds the chart written alongside a statistics page to the page.
+func statisticsFigure(doc *md.Document, alt string) {
+	doc.Figure("chart.svg", alt, doc.EncodeText(alt), nil, "")
+}
+
+func decadeLabel(decade int) string {
+	return fmt.Sprintf("%ds", decade)
+}
+
+func formatMean(a AgeSummary) string {
+	if a.Count == 0 {
+		return "–"
+	}
+	return fmt.Sprintf("%.1f", a.Mean())
+}
+
+func renderLifespanStatistics(s *Site, doc *md.Document) string {
+	stats := s.PublishSet.LifespansByBirthDecade()
+	if len(stats) == 0 {
+		doc.Para("There are not yet enough people with known dates of birth and death to calculate lifespans.")
+		return ""
+	}
+
+	categories := make([]string, len(stats))
+	male := barSeries{Name: "Men", Values: make([]float64, len(stats))}
+	female := barSeries{Name: "Women", Values: make([]float64, len(stats))}
+	rows := make([][]md.Text, len(stats))
+	for i, st := range stats {
+		categories[i] = decadeLabel(st.Decade)
+		male.Values[i] = st.Male.Mean()
+		female.Values[i] = st.Female.Mean()
+		rows[i] = []md.Text{
+			md.Text(categories[i]),
+			md.Text(formatMean(st.Male)),
+			md.Text(strconv.Itoa(st.Male.Count)),
+			md.Text(formatMean(st.Female)),
+			md.Text(strconv.Itoa(st.Female.Count)),
+		}
+	}
+
+	statisticsFigure(doc, "Average age at death by decade of birth")
+	doc.Table([]md.Text{"Born", "Men: average age", "Men: people", "Women: average age", "Women: people"}, rows)
+
+	doc.Heading3("Age at death", "age-at-death")
+	bands := s.PublishSet.AgeAtDeathDistribution()
+	bandRows := make([][]md.Text, len(bands))
+	for i, b := range bands {
+		bandRows[i] = []md.Text{md.Text(b.Label), md.Text(strconv.Itoa(b.Male)), md.Text(strconv.Itoa(b.Female))}
+	}
+	doc.Table([]md.Text{"Age", "Men", "Women"}, bandRows)
+
+	return barChartSVG(categories, []barSeries{male, female})
+}
+
+func renderInfantMortalityStatistics(s *Site, doc *md.Document) string {
+	stats := s.PublishSet.InfantMortalityByDecade()
+	if len(stats) == 0 {
+		doc.Para("There are not yet enough people with known dates of birth to calculate infant mortality.")
+		return ""
+	}
+
+	categories := make([]string, len(stats))
+	rate := barSeries{Name: "Infant deaths per 100 births", Values: make([]float64, len(stats))}
+	rows := make([][]md.Text, len(stats))
+	for i, st := range stats {
+		categories[i] = decadeLabel(st.Decade)
+		rate.Values[i] = st.Rate() * 100
+		rows[i] = []md.Text{
+			md.Text(categories[i]),
+			md.Text(strconv.Itoa(st.Births)),
+			md.Text(strconv.Itoa(st.InfantDeaths)),
+			md.Text(fmt.Sprintf("%.1f%%", st.Rate()*100)),
+		}
+	}
+
+	statisticsFigure(doc, "Infant deaths per 100 births by decade of birth")
+	doc.Table([]md.Text{"Born", "Births", "Died in infancy", "Rate"}, rows)
+	return barChartSVG(categories, []barSeries{rate})
+}
+
+func renderMarriageStatistics(s *Site, doc *md.Document) string {
+	stats := s.PublishSet.AgeAtFirstMarriage()
+	if stats.Male.Count == 0 && stats.Female.Count == 0 {
+		doc.Para("There are not yet enough dated marriages to calculate ages at marriage.")
+		return ""
+	}
+
+	var summary []string
+	if stats.Male.Count > 0 {
+		summary = append(summary, fmt.Sprintf("men first married at an average age of %s", formatMean(stats.Male)))
+	}
+	if stats.Female.Count > 0 {
+		summary = append(summary, fmt.Sprintf("women first married at an average age of %s", formatMean(stats.Female)))
+	}
+	doc.Para(md.Text(text.FormatSentence(text.JoinSentenceParts("Across the tree", text.JoinList(summary)))))
+
+	categories := make([]string, len(stats.Bands))
+	male := barSeries{Name: "Men", Values: make([]float64, len(stats.Bands))}
+	female := barSeries{Name: "Women", Values: make([]float64, len(stats.Bands))}
+	rows := make([][]md.Text, len(stats.Bands))
+	for i, b := range stats.Bands {
+		categories[i] = b.Label
+		male.Values[i] = float64(b.Male)
+		female.Values[i] = float64(b.Female)
+		rows[i] = []md.Text{md.Text(b.Label), md.Text(strconv.Itoa(b.Male)), md.Text(strconv.Itoa(b.Female))}
+	}
+
+	statisticsFigure(doc, "Number of people by age at first marriage")
+	doc.Table([]md.Text{"Age", "Men", "Women"}, rows)
+	return barChartSVG(categories, []barSeries{male, female})
+}
+
+func renderChildrenStatistics(s *Site, doc *md.Document) string {
+	counts := s.PublishSet.ChildrenPerFamily()
+
+	// omit the empty tail of the distribution
+	last := len(counts) - 1
+	for last > 0 && counts[last] == 0 {
+		last--
+	}
+
+	families, children := 0, 0
+	for n, c := range counts {
+		families += c
+		children += n * c
+	}
+	if families == 0 {
+		doc.Para("There are no families in the tree yet.")
+		return ""
+	}
+	doc.Para(md.Text(text.FormatSentence(fmt.Sprintf("The %d families in the tree have an average of %.1f recorded children", families, float64(children)/float64(families)))))
+
+	categories := make([]string, last+1)
+	series := barSeries{Name: "Families", Values: make([]float64, last+1)}
+	rows := make([][]md.Text, last+1)
+	for n := 0; n <= last; n++ {
+		categories[n] = strconv.Itoa(n)
+		if n == maxChildrenPerFamily {
+			categories[n] += "+"
+		}
+		series.Values[n] = float64(counts[n])
+		rows[n] = []md.Text{md.Text(categories[n]), md.Text(strconv.Itoa(counts[n]))}
+	}
+
+	statisticsFigure(doc, "Number of families by number of children")
+	doc.Table([]md.Text{"Children", "Families"}, rows)
+	return barChartSVG(categories, []barSeries{series})
+}
+
+func renderForenameStatistics(s *Site, doc *md.Document) string {
+	eras := s.PublishSet.CommonForenamesByEra(5)
+	if len(eras) == 0 {
+		doc.Para("There are not yet enough people with known dates of birth to list common forenames.")
+		return ""
+	}
+
+	names := func(list []NameCount) md.Text {
+		parts := make([]string, len(list))
+		for i, nc := range list {
+			parts[i] = fmt.Sprintf("%s (%d)", nc.Name, nc.Count)
+		}
+		return doc.EncodeText(strings.Join(parts, ", "))
+	}
+
+	rows := make([][]md.Text, len(eras))
+	for i, era := range eras {
+		rows[i] = []md.Text{md.Text(fmt.Sprintf("%d–%d", era.Start, era.End)), names(era.Male), names(era.Female)}
+	}
+	doc.Table([]md.Text{"Born", "Men", "Women"}, rows)
+	return ""
+}
+
+func renderOccupationStatistics(s *Site, doc *md.Document) string {
+	dist := FlattenMapByValueDesc(s.PublishSet.OccupationGroupDistribution())
+	if len(dist) == 0 {
+		doc.Para("No occupations have been recorded for people in the tree yet.")
+		return ""
+	}
+
+	categories := make([]string, len(dist))
+	series := barSeries{Name: "People", Values: make([]float64, len(dist))}
+	rows := make([][]md.Text, len(dist))
+	for i, t := range dist {
+		categories[i] = t.K
+		series.Values[i] = float64(t.V)
+		rows[i] = []md.Text{doc.EncodeText(t.K), md.Text(strconv.Itoa(t.V))}
+	}
+
+	statisticsFigure(doc, "Number of people by main occupation group")
+	doc.Table([]md.Text{"Occupation group", "People"}, rows)
+
+	classes := s.PublishSet.SocialClassDistribution()
+	if len(classes) > 0 {
+		doc.Heading3("Social class", "class")
+		doc.Para("People are counted in the social class of the occupation they were most often recorded with, using the five classes of the Registrar General.")
+		var classRows [][]md.Text
+		for c := model.SocialClassProfessional; c <= model.SocialClassUnskilled; c++ {
+			classRows = append(classRows, []md.Text{doc.EncodeText(c.Numeral() + " " + text.UpperFirst(c.String())), md.Text(strconv.Itoa(classes[c]))})
+		}
+		doc.Table([]md.Text{"Social class", "People"}, classRows)
+	}
+
+	if common := s.PublishSet.CommonOccupations(20); len(common) > 0 {
+		doc.Heading3("Common occupations", "common")
+		var commonRows [][]md.Text
+		for _, nc := range common {
+			commonRows = append(commonRows, []md.Text{doc.EncodeText(text.UpperFirst(nc.Name)), md.Text(strconv.Itoa(nc.Count))})
+		}
+		doc.Table([]md.Text{"Occupation", "People"}, commonRows)
+	}
+
+	return barChartSVG(categories, []barSeries{series})
+}
+
+func renderSourceStatistics(s *Site, doc *md.Document) string {
+	sc := s.PublishSet.SourceCoverage()
+	if sc.People == 0 {
+		doc.Para("There are no people in the tree yet.")
+		return ""
+	}
+
+	pct := func(n, of int) string {
+		if of == 0 {
+			return "–"
+		}
+		return fmt.Sprintf("%.0f%%", float64(n)/float64(of)*100)
+	}
+
+	doc.Para(md.Text(text.FormatSentence(fmt.Sprintf("Of the %d people in the tree, %d (%s) have no citations at all", sc.People, sc.Uncited, pct(sc.Uncited, sc.People)))))
+	doc.Table([]md.Text{"Fact", "People", "Supported by a citation", "Coverage"}, [][]md.Text{
+		{"Birth or baptism", md.Text(strconv.Itoa(sc.People)), md.Text(strconv.Itoa(sc.CitedBirth)), md.Text(pct(sc.CitedBirth, sc.People))},
+		{"Death or burial", md.Text(strconv.Itoa(sc.KnownToHaveDied)), md.Text(strconv.Itoa(sc.CitedDeath)), md.Text(pct(sc.CitedDeath, sc.KnownToHaveDied))},
+	})
+
+	categories := make([]string, len(sc.Types))
+	series := barSeries{Name: "People cited", Values: make([]float64, len(sc.Types))}
+	rows := make([][]md.Text, len(sc.Types))
+	for i, st := range sc.Types {
+		categories[i] = st.Type
+		series.Values[i] = float64(st.People)
+		rows[i] = []md.Text{md.Text(st.Type), md.Text(strconv.Itoa(st.Citations)), md.Text(strconv.Itoa(st.People)), md.Text(pct(st.People, sc.People))}
+	}
+
+	statisticsFigure(doc, "Number of people cited by each type of source")
+	doc.Table([]md.Text{"Source type", "Citations", "People cited", "Coverage"}, rows)
+	return barChartSVG(categories, []barSeries{series})
+}
+
+func renderCompletenessStatistics(s *Site, doc *md.Document) string {
+	stats := s.PublishSet.AncestorCompletenessByGeneration()
+	if len(stats) == 0 {
+		doc.Para("No ancestors of the key person have been identified yet.")
+		return ""
+	}
+
+	doc.Para("Each person's score is the percentage of the evidence expected for them that has been found: cited records of their birth, baptism, marriages, death and burial, each census taken during their lifetime, their parents and their occupation. The score for a generation counts ancestors who have not yet been identified as zero.")
+
+	categories := make([]string, len(stats))
+	score := barSeries{Name: "Generation", Values: make([]float64, len(stats))}
+	identified := barSeries{Name: "Identified ancestors", Values: make([]float64, len(stats))}
+	rows := make([][]md.Text, len(stats))
+	for i, st := range stats {
+		categories[i] = ancestorGenerationLabel(st.Generation)
+		score.Values[i] = st.Score()
+		identified.Values[i] = st.IdentifiedScore()
+		rows[i] = []md.Text{
+			md.Text(categories[i]),
+			md.Text(fmt.Sprintf("%d of %d", st.Identified, st.Possible)),
+			md.Text(fmt.Sprintf("%.0f%%", st.IdentifiedScore())),
+			md.Text(fmt.Sprintf("%.0f%%", st.Score())),
+		}
+	}
+
+	statisticsFigure(doc, "Research completeness by generation of ancestors")
+	doc.Table([]md.Text{"Generation", "Identified", "Identified ancestors: completeness", "Generation: completeness"}, rows)
+	return barChartSVG(categories, []barSeries{score, identified})
+}
@@ -0,0 +1,159 @@
+package site
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iand/genster/model"
+)
+
+func testStatisticsPerson(id string, gender model.Gender, born, died *model.Date) *model.Person {
+	p := &model.Person{ID: id, Gender: gender, PreferredGivenName: id}
+	if born != nil {
+		p.BestBirthlikeEvent = &model.BirthEvent{GeneralEvent: model.GeneralEvent{Date: born}}
+	}
+	if died != nil {
+		p.BestDeathlikeEvent = &model.DeathEvent{GeneralEvent: model.GeneralEvent{Date: died}}
+	}
+	return p
+}
+
+func TestPublishSetStatistics(t *testing.T) {
+	ps := &PublishSet{
+		People: map[string]*model.Person{
+			"a": testStatisticsPerson("a", model.GenderMale, model.PreciseDate(1851, 3, 1), model.PreciseDate(1911, 6, 1)),
+			"b": testStatisticsPerson("b", model.GenderFemale, model.PreciseDate(1855, 1, 1), model.PreciseDate(1925, 1, 1)),
+			"c": testStatisticsPerson("c", model.GenderFemale, model.PreciseDate(1858, 5, 1), model.PreciseDate(1858, 9, 1)),
+			"d": testStatisticsPerson("d", model.GenderMale, model.PreciseDate(1862, 1, 1), nil),
+			"e": {ID: "e", Redacted: true},
+		},
+	}
+
+	lifespans := ps.LifespansByBirthDecade()
+	if len(lifespans) != 1 {
+		t.Fatalf("got %d lifespan decades, wanted 1", len(lifespans))
+	}
+	if got := lifespans[0]; got.Decade != 1850 || got.Male.Mean() != 60 || got.Female.Count != 2 || got.Female.Mean() != 35 {
+		t.Errorf("got lifespans %+v", got)
+	}
+
+	deaths := ps.AgeAtDeathDistribution()
+	if got := deaths[0]; got.Female != 1 || got.Male != 0 {
+		t.Errorf("got %+v in band %s, wanted 1 woman", got, got.Label)
+	}
+	if got := deaths[6]; got.Male != 1 || got.Female != 1 {
+		t.Errorf("got %+v in band %s, wanted 1 man and 1 woman", got, got.Label)
+	}
+
+	mortality := ps.InfantMortalityByDecade()
+	if len(mortality) != 2 {
+		t.Fatalf("got %d infant mortality decades, wanted 2", len(mortality))
+	}
+	if got := mortality[0]; got.Births != 3 || got.InfantDeaths != 1 {
+		t.Errorf("got infant mortality %+v, wanted 3 births and 1 infant death", got)
+	}
+	if got := mortality[1]; got.Decade != 1860 || got.Births != 1 || got.InfantDeaths != 0 {
+		t.Errorf("got infant mortality %+v, wanted 1 birth in 1860s", got)
+	}
+
+	ps.People["a"].Families = []*model.Family{{
+		Bond:           model.FamilyBondMarried,
+		BestStartEvent: &model.MarriageEvent{GeneralEvent: model.GeneralEvent{Date: model.PreciseDate(1877, 7, 1)}},
+	}}
+	marriage := ps.AgeAtFirstMarriage()
+	if marriage.Male.Count != 1 || marriage.Male.Mean() != 26 {
+		t.Errorf("got male marriage ages %+v, wanted a single marriage at 26", marriage.Male)
+	}
+	if marriage.Bands[2].Male != 1 {
+		t.Errorf("got %d men in band %s, wanted 1", marriage.Bands[2].Male, marriage.Bands[2].Label)
+	}
+
+	ps.People["a"].SocialClass = model.SocialClassPartlySkilled
+	ps.People["a"].Occupations = []*model.Occupation{{Name: "agricultural labourer"}, {Name: "carter"}, {Name: "agricultural labourer"}}
+	ps.People["d"].SocialClass = model.SocialClassPartlySkilled
+	ps.People["d"].Occupations = []*model.Occupation{{Name: "agricultural labourer"}}
+	ps.People["e"].SocialClass = model.SocialClassSkilled
+	ps.People["e"].Occupations = []*model.Occupation{{Name: "blacksmith"}}
+	classes := ps.SocialClassDistribution()
+	if len(classes) != 1 || classes[model.SocialClassPartlySkilled] != 2 {
+		t.Errorf("got social classes %v, wanted 2 people in class IV", classes)
+	}
+	common := ps.CommonOccupations(5)
+	if len(common) != 2 || common[0] != (NameCount{Name: "agricultural labourer", Count: 2}) || common[1] != (NameCount{Name: "carter", Count: 1}) {
+		t.Errorf("got common occupations %+v, wanted agricultural labourer (2) and carter (1)", common)
+	}
+}
+
+func TestChildrenPerFamily(t *testing.T) {
+	father := &model.Person{ID: "f"}
+	children := func(n int) []*model.Person {
+		return make([]*model.Person, n)
+	}
+	ps := &PublishSet{
+		Families: map[string]*model.Family{
+			"f1": {Father: father, Children: children(2)},
+			"f2": {Father: father, Children: children(2)},
+			"f3": {Father: father, Children: children(maxChildrenPerFamily + 3)},
+			"f4": {Children: children(1)}, // no known parents
+		},
+	}
+
+	counts := ps.ChildrenPerFamily()
+	if counts[2] != 2 {
+		t.Errorf("got %d families with 2 children, wanted 2", counts[2])
+	}
+	if counts[maxChildrenPerFamily] != 1 {
+		t.Errorf("got %d families with %d or more children, wanted 1", counts[maxChildrenPerFamily], maxChildrenPerFamily)
+	}
+	if counts[1] != 0 {
+		t.Errorf("got %d families with 1 child, wanted family without parents to be excluded", counts[1])
+	}
+}
+
+func TestBarChartSVG(t *testing.T) {
+	svg := barChartSVG([]string{"1850s", "1860s"}, []barSeries{
+		{Name: "Men", Values: []float64{40, 0}},
+		{Name: "Women", Values: []float64{45, 52}},
+	})
+
+	if !strings.HasPrefix(svg, "<svg ") {
+		t.Errorf("chart does not start with an svg element")
+	}
+	if strings.Contains(svg, "<script") {
+		t.Errorf("chart contains a script")
+	}
+	// three non-zero values plus two key swatches
+	if got := strings.Count(svg, "<rect "); got != 5 {
+		t.Errorf("got %d rects, wanted 5", got)
+	}
+	if !strings.Contains(svg, "<title>1860s Women: 52</title>") {
+		t.Errorf("bar title not found")
+	}
+}
+
+func TestAncestorCompletenessByGeneration(t *testing.T) {
+	scored := func(id string, met int) *model.Person {
+		p := &model.Person{ID: id, Completeness: &model.Completeness{}}
+		for i := 0; i < 4; i++ {
+			p.Completeness.Items = append(p.Completeness.Items, model.CompletenessItem{Met: i < met})
+		}
+		return p
+	}
+
+	father := scored("f", 4)
+	mother := scored("m", 2)
+	father.Mother = scored("fm", 1)
+	key := &model.Person{ID: "k", Father: father, Mother: mother}
+
+	ps := &PublishSet{KeyPerson: key}
+	gens := ps.AncestorCompletenessByGeneration()
+	if len(gens) != 2 {
+		t.Fatalf("got %d generations, wanted 2", len(gens))
+	}
+	if got := gens[0]; got.Identified != 2 || got.Possible != 2 || got.Score() != 75 {
+		t.Errorf("got parents %+v with score %v, wanted both identified with score 75", got, got.Score())
+	}
+	if got := gens[1]; got.Identified != 1 || got.Possible != 4 || got.IdentifiedScore() != 25 || got.Score() != 6.25 {
+		t.Errorf("got grandparents %+v, wanted one of four identified with score 25", got)
+	}
+}
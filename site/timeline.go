@@ -54,8 +54,8 @@ func RenderTimeline[T render.EncodedText](t *model.Timeline, pov *model.POV, enc
 		}
 
 		var sy, sd string
-		if y, m, d, ok := dt.YMD(); ok {
-			sy = strconv.Itoa(y)
+		if _, m, d, ok := dt.YMD(); ok {
+			sy, _ = dt.DisplayYear()
 			sd = fmt.Sprintf("%d %s", d, monthNames[m])
 		} else if dt.Span {
 			switch d := dt.Date.(type) {
@@ -83,7 +83,7 @@ func RenderTimeline[T render.EncodedText](t *model.Timeline, pov *model.POV, enc
 				sy = strconv.Itoa(d.Year())
 				sd = d.MonthRange()
 			case *gdate.MonthYear:
-				sy = strconv.Itoa(d.Year())
+				sy, _ = dt.DisplayYear()
 				sd = monthNames[d.M]
 			case *gdate.BeforePrecise:
 				sy = d.Occurrence()
@@ -104,7 +104,6 @@ func (s *Site) ScanPersonTodos(p *model.Person) []*model.ToDo {
 		hasCitations := len(ev.GetCitations()) > 0
 		unreliableCitations := 0
 		censusCitations := 0
-		civilRegistrationCitations := 0
 		transcribedCitations := 0
 		for _, c := range ev.GetCitations() {
 			if c.Source.IsUnknown() {
@@ -117,7 +116,6 @@ func (s *Site) ScanPersonTodos(p *model.Person) []*model.ToDo {
 				censusCitations++
 			}
 			if c.Source.IsCivilRegistration {
-				civilRegistrationCitations++
 				if len(c.TranscriptionText) == 0 {
 					p.ToDos = append(p.ToDos, &model.ToDo{
 						Category: model.ToDoCategoryCitations,
@@ -134,7 +132,6 @@ func (s *Site) ScanPersonTodos(p *model.Person) []*model.ToDo {
 
 		hasOnlyCensusCitations := hasCitations && censusCitations == len(ev.GetCitations())
 		hasOnlyUnreliableCitations := hasCitations && unreliableCitations == len(ev.GetCitations())
-		hasCivilRegistrationCitation := civilRegistrationCitations > 0
 		hasTranscribedCitation := transcribedCitations > 0
 
 		if hasOnlyUnreliableCitations {
@@ -167,38 +164,6 @@ func (s *Site) ScanPersonTodos(p *model.Person) []*model.ToDo {
 					Reason:   fmt.Sprintf("event appears to have a firm date %q but no source citation", ev.GetDate().String()),
 				})
 			}
-
-			// for direct ancestors only
-			if p.IsDirectAncestor() {
-				// if in UK and date >= 1837 look for a GRO citation
-				switch ev.(type) {
-				case *model.BirthEvent, *model.DeathEvent, *model.MarriageEvent:
-					if ev.GetPlace().IsUnknown() || ev.GetPlace().UKNationName.IsUnknown() {
-						break
-					}
-
-					yr, ok := ev.GetDate().Year()
-					if !ok || yr < 1837 {
-						break
-					}
-
-					if !hasCivilRegistrationCitation {
-						var goal string
-						if _, ok := ev.(*model.MarriageEvent); ok {
-							goal = fmt.Sprintf("obtain a copy of the certificate for the marriage in %d", yr)
-						} else {
-							goal = fmt.Sprintf("obtain a copy of the %s certificate", ev.Type())
-						}
-
-						p.ToDos = append(p.ToDos, &model.ToDo{
-							Category: model.ToDoCategoryRecords,
-							Context:  fmt.Sprintf("%s event", ev.Type()),
-							Goal:     goal,
-							Reason:   "the date and place of the event is known and it is within the period of Civil Registration in the United Kingdom, so a copy of the relevant certificate can be requested",
-						})
-					}
-				}
-			}
 		}
 
 		switch ev.(type) {
@@ -248,5 +213,18 @@ func (s *Site) ScanPersonTodos(p *model.Person) []*model.ToDo {
 		}
 	}
 
+	p.ToDos = append(p.ToDos, s.recordSetTodos(p)...)
+
 	return todos
 }
+
+// searchedWithNilResult reports whether the research log records a search of
+// recordSet covering year that was made for p and found nothing.
+func searchedWithNilResult(p *model.Person, recordSet string, year int) bool {
+	for _, sr := range p.Searches {
+		if sr.Result == model.SearchResultNil && sr.Covers(recordSet, year) {
+			return true
+		}
+	}
+	return false
+}
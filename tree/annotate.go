@@ -403,6 +403,67 @@ func appendStringOrList(f *[]string, v any) error {
 	return nil
 }
 
+// appendJurisdiction adds a place that contained p, given as its id and an
+// optional period of years such as "1889-1965". The id is resolved once all
+// places have been annotated.
+func appendJurisdiction(p *model.Place, v any) error {
+	id, yp, err := stringWithPeriod(v)
+	if err != nil {
+		return err
+	}
+	p.Jurisdictions = append(p.Jurisdictions, &model.PlaceJurisdiction{
+		YearPeriod: yp,
+		ParentID:   id,
+	})
+	return nil
+}
+
+// appendAltName adds another name for p, given with an optional period of
+// years such as "-1974".
+func appendAltName(p *model.Place, v any) error {
+	name, yp, err := stringWithPeriod(v)
+	if err != nil {
+		return err
+	}
+	p.AltNames = append(p.AltNames, &model.PlaceAltName{
+		YearPeriod: yp,
+		Name:       name,
+	})
+	return nil
+}
+
+// stringWithPeriod parses a value that is either a string or a string
+// followed by a period of years.
+func stringWithPeriod(v any) (string, model.YearPeriod, error) {
+	var yp model.YearPeriod
+	switch tv := v.(type) {
+	case string:
+		return tv, yp, nil
+	case []any:
+		if len(tv) != 2 {
+			return "", yp, fmt.Errorf("expected a string followed by a period of years")
+		}
+		s, ok := tv[0].(string)
+		if !ok {
+			return "", yp, fmt.Errorf("expected a string followed by a period of years")
+		}
+		if yr, ok := intValue(tv[1]); ok {
+			return s, model.YearPeriod{From: yr, To: yr}, nil
+		}
+		period, ok := tv[1].(string)
+		if !ok {
+			return "", yp, fmt.Errorf("expected a period of years such as \"1889-1965\"")
+		}
+		yp, err := model.ParseYearPeriod(period)
+		if err != nil {
+			return "", yp, err
+		}
+		return s, yp, nil
+	default:
+		return "", yp, fmt.Errorf("expected a string value")
+	}
+}
+
 type (
 	personAnnotaterFunc func(*model.Person, any) error
 	placeAnnotaterFunc  func(*model.Place, any) error
@@ -437,6 +498,7 @@ var personReplacers = map[string]personAnnotaterFunc{
 var placeReplacers = map[string]placeAnnotaterFunc{
 	"name":    func(p *model.Place, v any) error { return setString(&p.Name, v) },
 	"latlong": func(p *model.Place, v any) error { return setGeoLocation(&p.GeoLocation, v) },
+	"sameas":  func(p *model.Place, v any) error { return setString(&p.SameAsID, v) },
 }
 
 // all possible source replacers
@@ -458,7 +520,9 @@ var personAdders = map[string]personAnnotaterFunc{
 
 // all possible place adders
 var placeAdders = map[string]placeAnnotaterFunc{
-	"tags": func(p *model.Place, v any) error { return appendStringOrList(&p.Tags, v) },
+	"tags":    func(p *model.Place, v any) error { return appendStringOrList(&p.Tags, v) },
+	"parent":  appendJurisdiction,
+	"altname": appendAltName,
 }
 
 // all possible source adders
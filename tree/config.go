@@ -3,8 +3,11 @@ package tree
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/iand/genster/occupation"
+	"github.com/iand/genster/place"
 	kdl "github.com/sblinch/kdl-go"
 )
 
@@ -13,8 +16,26 @@ type Config struct {
 	ID            string
 	Name          string
 	Description   string
+	Language      string // language tag of narrative text, such as "de"; empty for English
 	SurnameGroups *SurnameGroups
 	Annotations   *Annotations
+	PersonCharts  *PersonChartConfig     // nil if no charts should be generated for person pages
+	RecordSets    []*RecordSet           // nil if the config has no record-sets section
+	HintProviders []*HintProviderConfig  // nil if the config has no hint-providers section
+	Gazetteer     *place.Gazetteer       // nil if the config does not name a gazetteer file
+	Occupations   *occupation.Dictionary // nil if the config does not name an occupation dictionary file
+}
+
+// PersonChartConfig controls the charts generated for each person's page.
+type PersonChartConfig struct {
+	Ancestors   *ChartSpec // nil if no ancestor charts should be generated
+	Descendants *ChartSpec // nil if no descendant charts should be generated
+}
+
+// ChartSpec holds the settings for a single kind of chart.
+type ChartSpec struct {
+	Generations int // number of generations to include, excluding the subject of the chart
+	Detail      int // level of detail to include with each person (0:none,1:years,2:dates,3:full)
 }
 
 // ReadConfig reads a KDL config file and returns a *Config.
@@ -53,6 +74,10 @@ func ReadConfig(filename string) (*Config, error) {
 						s, _ := child.Arguments[0].Value.(string)
 						cfg.Description = strings.TrimSpace(s)
 					}
+				case "language":
+					if len(child.Arguments) > 0 {
+						cfg.Language, _ = child.Arguments[0].Value.(string)
+					}
 				}
 			}
 		case "annotations":
@@ -111,8 +136,98 @@ func ReadConfig(filename string) (*Config, error) {
 				sg.AddGroup(canonical, variants)
 			}
 			cfg.SurnameGroups = sg
+		case "person-charts":
+			pc := &PersonChartConfig{}
+			for _, child := range node.Children {
+				kind := child.Name.ValueString()
+				spec := &ChartSpec{Generations: 3, Detail: 1}
+				if kind == "descendants" {
+					spec.Generations = 2
+				}
+				if v, ok := child.Properties.Get("generations"); ok {
+					n, ok := intValue(v.Value)
+					if !ok || n < 1 {
+						return nil, fmt.Errorf("person-charts %s: generations must be a positive integer", kind)
+					}
+					spec.Generations = n
+				}
+				if v, ok := child.Properties.Get("detail"); ok {
+					n, ok := intValue(v.Value)
+					if !ok || n < 0 || n > 3 {
+						return nil, fmt.Errorf("person-charts %s: detail must be between 0 and 3", kind)
+					}
+					spec.Detail = n
+				}
+				switch kind {
+				case "ancestors":
+					pc.Ancestors = spec
+				case "descendants":
+					pc.Descendants = spec
+				default:
+					return nil, fmt.Errorf("unknown person-charts chart %q", kind)
+				}
+			}
+			cfg.PersonCharts = pc
+		case "record-sets":
+			rss, err := parseRecordSets(node)
+			if err != nil {
+				return nil, err
+			}
+			cfg.RecordSets = rss
+		case "hint-providers":
+			hps, err := parseHintProviders(node)
+			if err != nil {
+				return nil, err
+			}
+			cfg.HintProviders = hps
+		case "gazetteer":
+			names := stringArgs(node)
+			if len(names) != 1 {
+				return nil, fmt.Errorf("gazetteer must have a single file name")
+			}
+			// a relative file name is relative to the directory of the config file
+			fname := names[0]
+			if !filepath.IsAbs(fname) {
+				fname = filepath.Join(filepath.Dir(filename), fname)
+			}
+			g, err := place.LoadGazetteer(fname)
+			if err != nil {
+				return nil, fmt.Errorf("load gazetteer: %w", err)
+			}
+			cfg.Gazetteer = g
+		case "occupations":
+			names := stringArgs(node)
+			if len(names) != 1 {
+				return nil, fmt.Errorf("occupations must have a single file name")
+			}
+			fname := names[0]
+			if !filepath.IsAbs(fname) {
+				fname = filepath.Join(filepath.Dir(filename), fname)
+			}
+			d, err := occupation.LoadDictionary(fname)
+			if err != nil {
+				return nil, fmt.Errorf("load occupations: %w", err)
+			}
+			cfg.Occupations = d
 		}
 	}
 
 	return cfg, nil
 }
+
+// intValue converts a numeric KDL value to an int.
+func intValue(v any) (int, bool) {
+	switch tv := v.(type) {
+	case int:
+		return tv, true
+	case int64:
+		return int(tv), true
+	case float64:
+		if tv != float64(int(tv)) {
+			return 0, false
+		}
+		return int(tv), true
+	default:
+		return 0, false
+	}
+}
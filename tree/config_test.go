@@ -2,10 +2,12 @@ package tree
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/iand/genster/model"
 )
 
 func TestReadConfig(t *testing.T) {
@@ -16,6 +18,7 @@ tree id="cg" {
         The Chambers family originated from Suffolk, England.
         The Guivers are on Ian's paternal side.
         "#
+    language "de"
 }
 
 surname-groups {
@@ -42,6 +45,7 @@ surname-groups {
 		ID:          "cg",
 		Name:        "Chambers and Guiver Family Tree",
 		Description: "The Chambers family originated from Suffolk, England.\n        The Guivers are on Ian's paternal side.",
+		Language:    "de",
 	}
 
 	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Config{}, "SurnameGroups", "Annotations")); diff != "" {
@@ -79,3 +83,348 @@ surname-groups {
 		t.Error("expected Martyn to resolve to Martin group")
 	}
 }
+
+func TestReadConfigPersonCharts(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		kdl     string
+		want    *PersonChartConfig
+		wantErr bool
+	}{
+		{
+			name: "absent",
+			kdl:  `tree id="cg"`,
+			want: nil,
+		},
+		{
+			name: "defaults",
+			kdl: `person-charts {
+    ancestors
+    descendants
+}`,
+			want: &PersonChartConfig{
+				Ancestors:   &ChartSpec{Generations: 3, Detail: 1},
+				Descendants: &ChartSpec{Generations: 2, Detail: 1},
+			},
+		},
+		{
+			name: "ancestors only",
+			kdl: `person-charts {
+    ancestors generations=4 detail=2
+}`,
+			want: &PersonChartConfig{
+				Ancestors: &ChartSpec{Generations: 4, Detail: 2},
+			},
+		},
+		{
+			name: "bad detail",
+			kdl: `person-charts {
+    descendants detail=5
+}`,
+			wantErr: true,
+		},
+		{
+			name: "unknown chart",
+			kdl: `person-charts {
+    fan
+}`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "tree.kdl")
+			if err := os.WriteFile(fname, []byte(tt.kdl), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadConfig(fname)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfig: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got.PersonCharts); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadConfigRecordSets(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		kdl     string
+		want    []*RecordSet
+		wantErr bool
+	}{
+		{
+			name: "absent",
+			kdl:  `name "test"`,
+			want: nil,
+		},
+		{
+			name: "events and years",
+			kdl: `record-sets {
+    record-set "GRO deaths" {
+        events "death"
+        from 1837
+        countries "England" "Wales"
+        sources "GRO"
+        civil-registration
+    }
+    record-set "Census" {
+        years 1841 1851
+        census
+        everyone
+    }
+}`,
+			want: []*RecordSet{
+				{Name: "GRO deaths", Events: []string{"death"}, From: 1837, Countries: []string{"England", "Wales"}, Sources: []string{"GRO"}, CivilRegistration: true},
+				{Name: "Census", Years: []int{1841, 1851}, Census: true, Everyone: true},
+			},
+		},
+		{
+			name: "unknown event",
+			kdl: `record-sets {
+    record-set "Burials" {
+        events "burial"
+    }
+}`,
+			wantErr: true,
+		},
+		{
+			name: "no events or years",
+			kdl: `record-sets {
+    record-set "Wills" {
+        from 1858
+    }
+}`,
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			kdl: `record-sets {
+    record-set "Census" {
+        years 1841
+        county "Suffolk"
+    }
+}`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "tree.kdl")
+			if err := os.WriteFile(fname, []byte(tt.kdl), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadConfig(fname)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfig: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got.RecordSets); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultRecordSets(t *testing.T) {
+	rss := DefaultRecordSets()
+	names := make([]string, 0, len(rss))
+	for _, rs := range rss {
+		names = append(names, rs.Name)
+	}
+	want := []string{"GRO births", "GRO marriages", "GRO deaths", "Census", "1939 Register", "Probate calendar", "Parish registers"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadConfigHintProviders(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		kdl     string
+		want    []*HintProviderConfig
+		wantErr bool
+	}{
+		{
+			name: "absent",
+			kdl:  `name "test"`,
+			want: nil,
+		},
+		{
+			name: "local archive",
+			kdl: `hint-providers {
+    provider "Suffolk Archives" {
+        url "https://www.suffolkarchives.co.uk/search?q={surname}+{given}&from={from}&to={to}"
+        events "baptism" burial="burials"
+        to 1900
+        countries "England"
+        span 5
+        variants
+    }
+}`,
+			want: []*HintProviderConfig{
+				{
+					Name:      "Suffolk Archives",
+					URL:       "https://www.suffolkarchives.co.uk/search?q={surname}+{given}&from={from}&to={to}",
+					Events:    map[string]string{"baptism": "baptism", "burial": "burials"},
+					To:        1900,
+					Countries: []string{"England"},
+					Span:      5,
+					Variants:  true,
+				},
+			},
+		},
+		{
+			name: "missing url",
+			kdl: `hint-providers {
+    provider "Archive" {
+        events "birth"
+    }
+}`,
+			wantErr: true,
+		},
+		{
+			name: "missing events",
+			kdl: `hint-providers {
+    provider "Archive" {
+        url "https://example.com/?q={surname}"
+    }
+}`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			fname := filepath.Join(t.TempDir(), "tree.kdl")
+			if err := os.WriteFile(fname, []byte(tt.kdl), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := ReadConfig(fname)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadConfig: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got.HintProviders); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDefaultHintProviders(t *testing.T) {
+	hps := DefaultHintProviders()
+	names := make([]string, 0, len(hps))
+	for _, hp := range hps {
+		names = append(names, hp.Name)
+	}
+	want := []string{"FreeBMD", "FreeCEN", "FamilySearch", "FindMyPast", "Ancestry"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadConfigGazetteer(t *testing.T) {
+	dir := t.TempDir()
+	const gazetteer = "id\tkind\tname\tparents\tlatitude\tlongitude\n" +
+		"cam\tcounty\tCambridgeshire\tEngland\t\t\n" +
+		"harston\tparish\tHarston\tcam\t52.14\t0.08\n"
+	if err := os.WriteFile(filepath.Join(dir, "places.tsv"), []byte(gazetteer), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fname := filepath.Join(dir, "tree.kdl")
+	if err := os.WriteFile(fname, []byte(`gazetteer "places.tsv"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadConfig(fname)
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+	if cfg.Gazetteer.Len() != 2 {
+		t.Fatalf("got %d gazetteer places, want 2", cfg.Gazetteer.Len())
+	}
+
+	tr := NewTree("test", &Annotations{}, &SurnameGroups{})
+	tr.Gazetteer = cfg.Gazetteer
+
+	pl := tr.FindPlaceUnstructured("Harston, Cambridgeshire, England")
+	if pl.PlaceType != model.PlaceTypeParish {
+		t.Errorf("got type %q, want %q", pl.PlaceType, model.PlaceTypeParish)
+	}
+	if pl.GeoLocation == nil || pl.GeoLocation.Latitude != 52.14 {
+		t.Errorf("got location %v, want 52.14,0.08", pl.GeoLocation)
+	}
+	if pl.Parent == nil || pl.Parent.Name != "Cambridgeshire" || pl.Parent.PlaceType != model.PlaceTypeCounty {
+		t.Fatalf("got parent %v, want county of Cambridgeshire", pl.Parent)
+	}
+	if pl.Parent.Parent == nil || pl.Parent.Parent.Name != "England" || pl.Parent.Parent.Parent != nil {
+		t.Errorf("got grandparent %v, want England", pl.Parent.Parent)
+	}
+	if pl.UKNationName.Name != "England" {
+		t.Errorf("got nation %q, want England", pl.UKNationName.Name)
+	}
+	if again := tr.FindPlaceUnstructured("Harston, Cambridgeshire"); again != pl {
+		t.Errorf("got a different place for the same parish")
+	}
+
+	farm := tr.FindPlaceUnstructured("Church Farm, Harston, Cambridgeshire")
+	if farm.Name != "Church Farm" || farm.Parent != pl {
+		t.Errorf("got %q in %v, want Church Farm in Harston", farm.Name, farm.Parent)
+	}
+	if want := "Church Farm, Harston, Cambridgeshire, England"; farm.FullName != want {
+		t.Errorf("got full name %q, want %q", farm.FullName, want)
+	}
+	if want := "England, Cambridgeshire, Harston, Church Farm"; farm.PreferredSortName != want {
+		t.Errorf("got sort name %q, want %q", farm.PreferredSortName, want)
+	}
+
+	if err := tr.RefinePlaceNames(farm); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Church Farm, Harston, Cambridgeshire, England"; farm.FullName != want {
+		t.Errorf("got full name %q, want %q", farm.FullName, want)
+	}
+}
+
+func TestReadConfigOccupations(t *testing.T) {
+	dir := t.TempDir()
+	const occupations = "code,title,class,group,aliases\n" +
+		",straw plaiter,IV,crafts,plaiter\n"
+	if err := os.WriteFile(filepath.Join(dir, "occupations.csv"), []byte(occupations), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fname := filepath.Join(dir, "tree.kdl")
+	if err := os.WriteFile(fname, []byte(`occupations "occupations.csv"`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ReadConfig(fname)
+	if err != nil {
+		t.Fatalf("ReadConfig: %v", err)
+	}
+	if got := len(cfg.Occupations.Entries()); got != 1 {
+		t.Fatalf("got %d occupations, want 1", got)
+	}
+	e, ok := cfg.Occupations.Lookup("Plaiter", 0)
+	if !ok || e.Title != "straw plaiter" || e.Class != model.SocialClassPartlySkilled || e.Group != model.OccupationGroupCrafts {
+		t.Errorf("got %+v, want straw plaiter in class IV crafts", e)
+	}
+}
@@ -0,0 +1,134 @@
+package tree
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	kdl "github.com/sblinch/kdl-go"
+	"github.com/sblinch/kdl-go/document"
+)
+
+// defaultHintProviders describes the hint providers used when a tree config
+// has no hint-providers section.
+//
+//go:embed hintproviders.kdl
+var defaultHintProviders string
+
+// A HintProviderConfig describes an external record collection, such as an
+// online index or a local archive catalogue, and how to build a link to a
+// search of it for a person and event.
+//
+// URL is a template in which the placeholders {surname}, {given}, {year},
+// {from}, {to}, {span}, {type} and {district} are replaced by the query
+// escaped values for the search.
+type HintProviderConfig struct {
+	Name      string            // name of the collection, used in the titles of links
+	URL       string            // template for the search URL
+	Events    map[string]string // event types searched, mapped to the value substituted for {type}
+	From      int               // first year covered, zero if there is no limit
+	To        int               // last year covered, zero if there is no limit
+	Countries []string          // countries covered, matched against the nation or country of a place; empty for everywhere
+	Span      int               // number of years either side of the event date to search
+	Variants  bool              // true if a link should be made for each variant of the surname
+}
+
+// DefaultHintProviders returns the hint providers used when a tree config
+// does not describe any: FreeBMD, FreeCEN, FamilySearch, FindMyPast and
+// Ancestry.
+func DefaultHintProviders() []*HintProviderConfig {
+	doc, err := kdl.Parse(strings.NewReader(defaultHintProviders))
+	if err != nil {
+		panic(fmt.Sprintf("parse default hint providers: %v", err))
+	}
+	hps, err := parseHintProviders(doc.Nodes[0])
+	if err != nil {
+		panic(fmt.Sprintf("parse default hint providers: %v", err))
+	}
+	return hps
+}
+
+// parseHintProviders parses the children of a hint-providers node.
+func parseHintProviders(node *document.Node) ([]*HintProviderConfig, error) {
+	hps := []*HintProviderConfig{}
+	for _, child := range node.Children {
+		if child.Name.ValueString() != "provider" {
+			return nil, fmt.Errorf("unknown hint-providers entry %q", child.Name.ValueString())
+		}
+		if len(child.Arguments) == 0 {
+			return nil, fmt.Errorf("provider missing name")
+		}
+		hp := &HintProviderConfig{Events: map[string]string{}}
+		hp.Name, _ = child.Arguments[0].Value.(string)
+		if hp.Name == "" {
+			return nil, fmt.Errorf("provider name must be a string")
+		}
+
+		for _, field := range child.Children {
+			fieldName := field.Name.ValueString()
+			switch fieldName {
+			case "url":
+				urls := stringArgs(field)
+				if len(urls) != 1 {
+					return nil, fmt.Errorf("provider %q: url must have a single string", hp.Name)
+				}
+				hp.URL = urls[0]
+			case "events":
+				// arguments name event types that are substituted unchanged,
+				// properties map an event type to the provider's own term
+				for _, s := range stringArgs(field) {
+					hp.Events[s] = s
+				}
+				for k, v := range field.Properties.Unordered() {
+					s, ok := v.Value.(string)
+					if !ok {
+						return nil, fmt.Errorf("provider %q: event %s must map to a string", hp.Name, k)
+					}
+					hp.Events[k] = s
+				}
+			case "from", "to", "span":
+				if len(field.Arguments) != 1 {
+					return nil, fmt.Errorf("provider %q: %s must have a single integer", hp.Name, fieldName)
+				}
+				n, ok := intValue(field.Arguments[0].Value)
+				if !ok || n < 0 {
+					return nil, fmt.Errorf("provider %q: %s must be a non-negative integer", hp.Name, fieldName)
+				}
+				switch fieldName {
+				case "from":
+					hp.From = n
+				case "to":
+					hp.To = n
+				case "span":
+					hp.Span = n
+				}
+			case "countries":
+				hp.Countries = append(hp.Countries, stringArgs(field)...)
+			case "variants":
+				hp.Variants = true
+			default:
+				return nil, fmt.Errorf("provider %q: unknown field %q", hp.Name, fieldName)
+			}
+		}
+
+		if hp.URL == "" {
+			return nil, fmt.Errorf("provider %q: missing url", hp.Name)
+		}
+		if len(hp.Events) == 0 {
+			return nil, fmt.Errorf("provider %q: missing events", hp.Name)
+		}
+		hps = append(hps, hp)
+	}
+	return hps, nil
+}
+
+// CoversYear reports whether yr is within the years covered by the provider.
+func (hp *HintProviderConfig) CoversYear(yr int) bool {
+	return coversYear(hp.From, hp.To, yr)
+}
+
+// CoversCountry reports whether the provider covers the nation or country
+// called name. A provider without countries covers everywhere.
+func (hp *HintProviderConfig) CoversCountry(name string) bool {
+	return coversCountry(hp.Countries, name)
+}
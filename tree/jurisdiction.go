@@ -0,0 +1,122 @@
+package tree
+
+import (
+	"strings"
+
+	"github.com/iand/genster/logging"
+	"github.com/iand/genster/model"
+)
+
+// ResolveJurisdictions finds the places named by id in dated parent
+// annotations. Jurisdictions naming unknown places are dropped.
+func (t *Tree) ResolveJurisdictions() {
+	for _, pl := range t.Places {
+		js := pl.Jurisdictions[:0]
+		for _, j := range pl.Jurisdictions {
+			if j.Parent == nil && j.ParentID != "" {
+				par, ok := t.Places[j.ParentID]
+				if !ok {
+					logging.Warn("place is annotated with an unknown parent", "id", pl.ID, "parent", j.ParentID)
+					continue
+				}
+				j.Parent = par
+			}
+			if j.Parent == nil || j.Parent == pl {
+				continue
+			}
+			js = append(js, j)
+		}
+		pl.Jurisdictions = js
+	}
+}
+
+// DatePlaceNames replaces the place of each event and occupation with the
+// place as it was named, and with the places that contained it, in the year
+// the event took place. The replacement shares the id of the original so it
+// links to the same page. Places whose names and jurisdictions did not change
+// are left alone.
+func (t *Tree) DatePlaceNames() {
+	seen := make(map[model.TimelineEvent]bool)
+	dateEvents := func(evs []model.TimelineEvent) {
+		for _, ev := range evs {
+			if seen[ev] {
+				continue
+			}
+			seen[ev] = true
+			yr, ok := ev.GetDate().Year()
+			if !ok {
+				continue
+			}
+			if pl := ev.GetPlace(); !pl.IsUnknown() {
+				if dpl := t.PlaceIn(pl, yr); dpl != pl {
+					ev.SetPlace(dpl)
+				}
+			}
+		}
+	}
+	for _, p := range t.People {
+		dateEvents(p.Timeline)
+		for _, occ := range p.Occupations {
+			if yr, ok := occ.Date.Year(); ok && !occ.Place.IsUnknown() {
+				occ.Place = t.PlaceIn(occ.Place, yr)
+			}
+		}
+	}
+	for _, f := range t.Families {
+		dateEvents(f.Timeline)
+	}
+	for _, pl := range t.Places {
+		dateEvents(pl.Timeline)
+	}
+}
+
+// PlaceIn returns pl as it was named, and with the places that contained it,
+// in the year yr. It returns pl itself if neither changed.
+func (t *Tree) PlaceIn(pl *model.Place, yr int) *model.Place {
+	if pl.IsUnknown() {
+		return pl
+	}
+
+	// the key identifies the names and hierarchy of the place in the year so
+	// that places with the same history share one dated copy
+	var key strings.Builder
+	changed := false
+	visited := make(map[*model.Place]bool)
+	for cur := pl; cur != nil; cur = cur.ParentIn(yr) {
+		if visited[cur] {
+			logging.Warn("place jurisdictions form a loop", "id", pl.ID, "year", yr)
+			return pl
+		}
+		visited[cur] = true
+		name := cur.NameIn(yr)
+		if name != cur.Name || cur.ParentIn(yr) != cur.Parent {
+			changed = true
+		}
+		key.WriteString(cur.ID + "=" + name + ";")
+	}
+	if !changed {
+		return pl
+	}
+
+	if t.datedPlaces == nil {
+		t.datedPlaces = make(map[string]*model.Place)
+	}
+	if dpl, ok := t.datedPlaces[key.String()]; ok {
+		return dpl
+	}
+
+	dpl := *pl
+	dpl.Name = pl.NameIn(yr)
+	dpl.Parent = t.PlaceIn(pl.ParentIn(yr), yr)
+	dpl.Country = nil
+	dpl.Region = nil
+	dpl.District = nil
+	dpl.ProseName = ""
+	dpl.DistrictContext = ""
+	dpl.RegionContext = ""
+	dpl.CountryContext = ""
+	t.RefinePlaceNames(&dpl)
+
+	t.datedPlaces[key.String()] = &dpl
+	return &dpl
+}
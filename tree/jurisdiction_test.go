@@ -0,0 +1,79 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/iand/genster/model"
+)
+
+func TestPlaceIn(t *testing.T) {
+	a := &Annotations{}
+	for _, ann := range []struct {
+		id, field string
+		value     any
+	}{
+		{id: "acton", field: "parent", value: []any{"middlesex", "-1964"}},
+		{id: "acton", field: "parent", value: []any{"london", "1965-"}},
+		{id: "middlesex", field: "altname", value: []any{"County of Middlesex", "-1888"}},
+		{id: "church", field: "parent", value: []any{"nowhere", "1900-"}},
+	} {
+		if err := a.Set("place", ann.id, ann.field, ann.value); err != nil {
+			t.Fatalf("Set %s %s: %v", ann.id, ann.field, err)
+		}
+	}
+
+	tr := NewTree("test", a, &SurnameGroups{})
+	england := &model.Place{ID: "england", Name: "England", PlaceType: model.PlaceTypeCountry}
+	middlesex := &model.Place{ID: "middlesex", Name: "Middlesex", PlaceType: model.PlaceTypeCounty, Parent: england}
+	london := &model.Place{ID: "london", Name: "Greater London", PlaceType: model.PlaceTypeCounty, Parent: england}
+	acton := &model.Place{ID: "acton", Name: "Acton", PlaceType: model.PlaceTypeParish, Parent: london}
+	church := &model.Place{ID: "church", Name: "St Mary's Church", PlaceType: model.PlaceTypeBuilding, Parent: acton}
+	for _, pl := range []*model.Place{england, middlesex, london, acton, church} {
+		tr.Places[pl.ID] = pl
+		a.ApplyPlace(pl)
+	}
+	tr.ResolveJurisdictions()
+	for _, pl := range tr.Places {
+		tr.RefinePlaceNames(pl)
+	}
+
+	if len(church.Jurisdictions) != 0 {
+		t.Errorf("unknown parent was not dropped")
+	}
+
+	testCases := []struct {
+		pl       *model.Place
+		year     int
+		fullName string
+		region   string
+	}{
+		{pl: church, year: 1850, fullName: "St Mary's Church, Acton, County of Middlesex, England", region: "middlesex"},
+		{pl: church, year: 1900, fullName: "St Mary's Church, Acton, Middlesex, England", region: "middlesex"},
+		{pl: church, year: 1970, fullName: "St Mary's Church, Acton, Greater London, England", region: "london"},
+		{pl: acton, year: 1900, fullName: "Acton, Middlesex, England", region: "middlesex"},
+		{pl: acton, year: 2000, fullName: "Acton, Greater London, England", region: "london"},
+		{pl: london, year: 1850, fullName: "Greater London, England", region: "london"},
+	}
+	for _, tc := range testCases {
+		got := tr.PlaceIn(tc.pl, tc.year)
+		if got.FullName != tc.fullName {
+			t.Errorf("%s in %d: got name %q, want %q", tc.pl.ID, tc.year, got.FullName, tc.fullName)
+		}
+		if got.ID != tc.pl.ID {
+			t.Errorf("%s in %d: got id %q", tc.pl.ID, tc.year, got.ID)
+		}
+		if got.Region == nil || got.Region.ID != tc.region {
+			t.Errorf("%s in %d: got wrong region, want %s", tc.pl.ID, tc.year, tc.region)
+		}
+	}
+
+	if tr.PlaceIn(acton, 2000) != acton {
+		t.Errorf("got a copy of a place that had not changed")
+	}
+	if tr.PlaceIn(church, 1900) != tr.PlaceIn(church, 1901) {
+		t.Errorf("places with the same history were not shared")
+	}
+	if acton.FullName != "Acton, Greater London, England" {
+		t.Errorf("original place was changed, got %q", acton.FullName)
+	}
+}
@@ -2,6 +2,8 @@ package tree
 
 import (
 	"fmt"
+
+	"github.com/iand/genster/occupation"
 )
 
 type Loader interface {
@@ -23,6 +25,14 @@ func LoadTree(cfg *Config, loader Loader) (*Tree, error) {
 	}
 
 	t := NewTree(id, a, sg)
+	t.Gazetteer = cfg.Gazetteer
+
+	// occupations in the tree's own dictionary take precedence over the
+	// built in ones
+	t.Occupations = occupation.Default()
+	for _, e := range cfg.Occupations.Entries() {
+		t.Occupations.Add(e)
+	}
 
 	if err := loader.Load(t); err != nil {
 		return nil, fmt.Errorf("load data: %w", err)
@@ -0,0 +1,112 @@
+package tree
+
+import (
+	"slices"
+
+	"github.com/iand/genster/logging"
+	"github.com/iand/genster/model"
+)
+
+// MergeSameAsPlaces merges each place that has been annotated as the same as
+// another into that place, so the two share one page and timeline. A place
+// may name a place that is itself merged into another.
+func (t *Tree) MergeSameAsPlaces() {
+	merges := make(map[*model.Place]*model.Place)
+	for _, pl := range t.Places {
+		if pl.SameAsID == "" {
+			continue
+		}
+		dst := t.sameAsTarget(pl)
+		if dst == nil {
+			continue
+		}
+		merges[pl] = dst
+	}
+	if len(merges) == 0 {
+		return
+	}
+
+	retarget := func(pl *model.Place) *model.Place {
+		if dst, ok := merges[pl]; ok {
+			return dst
+		}
+		return pl
+	}
+
+	seen := make(map[model.TimelineEvent]bool)
+	moveEvents := func(evs []model.TimelineEvent) {
+		for _, ev := range evs {
+			if seen[ev] {
+				continue
+			}
+			seen[ev] = true
+			if dst, ok := merges[ev.GetPlace()]; ok {
+				ev.SetPlace(dst)
+			}
+		}
+	}
+	for _, p := range t.People {
+		moveEvents(p.Timeline)
+		for _, occ := range p.Occupations {
+			occ.Place = retarget(occ.Place)
+		}
+	}
+	for _, f := range t.Families {
+		moveEvents(f.Timeline)
+	}
+	for _, pl := range t.Places {
+		moveEvents(pl.Timeline)
+		pl.Parent = retarget(pl.Parent)
+		for _, j := range pl.Jurisdictions {
+			j.Parent = retarget(j.Parent)
+		}
+	}
+
+	for src, dst := range merges {
+		logging.Debug("merging place", "id", src.ID, "name", src.Name, "into", dst.ID)
+		for _, ev := range src.Timeline {
+			if !slices.Contains(dst.Timeline, ev) {
+				dst.Timeline = append(dst.Timeline, ev)
+			}
+		}
+		for _, tag := range src.Tags {
+			if !slices.Contains(dst.Tags, tag) {
+				dst.Tags = append(dst.Tags, tag)
+			}
+		}
+		dst.Links = append(dst.Links, src.Links...)
+		dst.Gallery = append(dst.Gallery, src.Gallery...)
+		dst.ResearchNotes = append(dst.ResearchNotes, src.ResearchNotes...)
+		dst.Comments = append(dst.Comments, src.Comments...)
+		dst.AltNames = append(dst.AltNames, src.AltNames...)
+		if len(dst.Jurisdictions) == 0 {
+			dst.Jurisdictions = src.Jurisdictions
+		}
+		if dst.GeoLocation == nil {
+			dst.GeoLocation = src.GeoLocation
+		}
+		delete(t.Places, src.ID)
+	}
+}
+
+// sameAsTarget follows the sameas annotations from pl to the place it
+// should be merged into, or returns nil if the chain names an unknown place
+// or loops.
+func (t *Tree) sameAsTarget(pl *model.Place) *model.Place {
+	visited := map[*model.Place]bool{pl: true}
+	cur := pl
+	for cur.SameAsID != "" {
+		next, ok := t.Places[cur.SameAsID]
+		if !ok {
+			logging.Warn("place is annotated as the same as an unknown place", "id", cur.ID, "sameas", cur.SameAsID)
+			return nil
+		}
+		if visited[next] {
+			logging.Warn("place sameas annotations form a loop", "id", pl.ID)
+			return nil
+		}
+		visited[next] = true
+		cur = next
+	}
+	return cur
+}
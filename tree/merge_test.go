@@ -0,0 +1,69 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/iand/genster/model"
+)
+
+func TestMergeSameAsPlaces(t *testing.T) {
+	a := &Annotations{}
+	if err := a.Set("place", "pl2", "sameas", "pl1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Set("place", "pl3", "sameas", "pl2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Set("place", "pl4", "sameas", "missing"); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := NewTree("test", a, &SurnameGroups{})
+	pl1 := &model.Place{ID: "pl1", Name: "Shifnal, Shropshire, England"}
+	pl2 := &model.Place{ID: "pl2", Name: "Shifnal, Salop", Tags: []string{"market town"}, GeoLocation: &model.GeoLocation{Latitude: 52.669, Longitude: -2.368}}
+	pl3 := &model.Place{ID: "pl3", Name: "Shifnal"}
+	pl4 := &model.Place{ID: "pl4", Name: "Somewhere"}
+	farm := &model.Place{ID: "farm", Name: "Manor Farm", Parent: pl3}
+	for _, pl := range []*model.Place{pl1, pl2, pl3, pl4, farm} {
+		tr.Places[pl.ID] = pl
+	}
+
+	p := &model.Person{ID: "p1"}
+	birth := &model.BirthEvent{GeneralEvent: model.GeneralEvent{Place: pl2}, GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p}}
+	death := &model.DeathEvent{GeneralEvent: model.GeneralEvent{Place: pl3}, GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p}}
+	p.Timeline = []model.TimelineEvent{birth, death}
+	p.Occupations = []*model.Occupation{{Name: "farmer", Place: pl3}}
+	pl2.Timeline = []model.TimelineEvent{birth}
+	pl3.Timeline = []model.TimelineEvent{death}
+	tr.People[p.ID] = p
+
+	for _, pl := range tr.Places {
+		a.ApplyPlace(pl)
+	}
+	tr.MergeSameAsPlaces()
+
+	if _, ok := tr.Places["pl2"]; ok {
+		t.Errorf("pl2 was not removed")
+	}
+	if _, ok := tr.Places["pl3"]; ok {
+		t.Errorf("pl3 was not removed")
+	}
+	if _, ok := tr.Places["pl4"]; !ok {
+		t.Errorf("pl4 was removed but names an unknown place")
+	}
+	if birth.GetPlace() != pl1 || death.GetPlace() != pl1 {
+		t.Errorf("events were not moved to pl1")
+	}
+	if p.Occupations[0].Place != pl1 {
+		t.Errorf("occupation was not moved to pl1")
+	}
+	if farm.Parent != pl1 {
+		t.Errorf("farm parent was not moved to pl1")
+	}
+	if len(pl1.Timeline) != 2 {
+		t.Errorf("got %d events in pl1 timeline, want 2", len(pl1.Timeline))
+	}
+	if len(pl1.Tags) != 1 || pl1.GeoLocation == nil {
+		t.Errorf("tags and location were not merged into pl1")
+	}
+}
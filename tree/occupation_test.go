@@ -0,0 +1,46 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/occupation"
+)
+
+func TestRefinePersonOccupations(t *testing.T) {
+	tr := NewTree("test", &Annotations{}, &SurnameGroups{})
+	tr.Occupations = occupation.Default()
+
+	aglab := &model.Occupation{Name: "ag lab", Detail: "Ag Lab", Occurrences: 2}
+	hewer := &model.Occupation{Name: "coal hewer", Detail: "Coal Hewer", Occurrences: 3}
+	pauper := &model.Occupation{Name: "pauper", Detail: "Pauper", Occurrences: 1}
+	ev := &model.OccupationEvent{Occupation: model.Occupation{Name: "ag lab", Detail: "Ag Lab"}}
+	p := &model.Person{
+		ID:          "p1",
+		Occupations: []*model.Occupation{aglab, hewer, pauper},
+		Timeline:    []model.TimelineEvent{ev},
+	}
+
+	if err := tr.RefinePersonOccupations(p); err != nil {
+		t.Fatalf("RefinePersonOccupations: %v", err)
+	}
+
+	if aglab.Name != "agricultural labourer" || aglab.Code != "62105" || aglab.Class != model.SocialClassPartlySkilled {
+		t.Errorf("ag lab: got name %q, code %q, class %v", aglab.Name, aglab.Code, aglab.Class)
+	}
+	if aglab.Detail != "Ag Lab" {
+		t.Errorf("ag lab: recorded wording changed to %q", aglab.Detail)
+	}
+	if ev.Occupation.Name != "agricultural labourer" {
+		t.Errorf("occupation event: got name %q", ev.Occupation.Name)
+	}
+	if pauper.Name != "pauper" || pauper.Code != "" {
+		t.Errorf("pauper: got name %q, code %q, wanted it unchanged", pauper.Name, pauper.Code)
+	}
+	if p.OccupationGroup != model.OccupationGroupIndustrial {
+		t.Errorf("occupation group: got %q, wanted %q", p.OccupationGroup, model.OccupationGroupIndustrial)
+	}
+	if p.SocialClass != model.SocialClassSkilled {
+		t.Errorf("social class: got %v, wanted %v", p.SocialClass, model.SocialClassSkilled)
+	}
+}
@@ -0,0 +1,174 @@
+package tree
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	kdl "github.com/sblinch/kdl-go"
+	"github.com/sblinch/kdl-go/document"
+)
+
+// defaultRecordSets describes the record sets used when a tree config has no
+// record-sets section.
+//
+//go:embed recordsets.kdl
+var defaultRecordSets string
+
+// A RecordSet describes a collection of records, such as a census or a civil
+// registration index, and which people can be expected to appear in it. A
+// record set either records life events, in which case a record is expected
+// for each birth, marriage or death within its years and countries, or is a
+// snapshot taken in certain years, in which case a record is expected for
+// each of those years in which a person is known to have been alive.
+type RecordSet struct {
+	Name              string   // name of the record set, matched against the research log
+	Events            []string // kinds of life event recorded: birth, marriage or death
+	Years             []int    // years in which a snapshot record set, such as a census, was taken
+	From              int      // first year covered, zero if there is no limit
+	To                int      // last year covered, zero if there is no limit
+	Countries         []string // countries covered, matched against the nation or country of a place; empty for everywhere
+	Sources           []string // words or phrases in the titles of sources that belong to the record set
+	Census            bool     // true if citations of any census source belong to the record set
+	CivilRegistration bool     // true if citations of any civil registration source belong to the record set
+	Everyone          bool     // true if the record set should be sought for everyone, not only direct ancestors
+}
+
+// DefaultRecordSets returns the record sets used when a tree config does not
+// describe any: England and Wales civil registration from 1837, the censuses
+// from 1841 to 1921, the 1939 Register, the probate calendar from 1858 and
+// parish registers from 1538 until civil registration began.
+func DefaultRecordSets() []*RecordSet {
+	doc, err := kdl.Parse(strings.NewReader(defaultRecordSets))
+	if err != nil {
+		panic(fmt.Sprintf("parse default record sets: %v", err))
+	}
+	rss, err := parseRecordSets(doc.Nodes[0])
+	if err != nil {
+		panic(fmt.Sprintf("parse default record sets: %v", err))
+	}
+	return rss
+}
+
+// parseRecordSets parses the children of a record-sets node.
+func parseRecordSets(node *document.Node) ([]*RecordSet, error) {
+	rss := []*RecordSet{}
+	for _, child := range node.Children {
+		if child.Name.ValueString() != "record-set" {
+			return nil, fmt.Errorf("unknown record-sets entry %q", child.Name.ValueString())
+		}
+		if len(child.Arguments) == 0 {
+			return nil, fmt.Errorf("record-set missing name")
+		}
+		rs := &RecordSet{}
+		rs.Name, _ = child.Arguments[0].Value.(string)
+		if rs.Name == "" {
+			return nil, fmt.Errorf("record-set name must be a string")
+		}
+
+		for _, field := range child.Children {
+			fieldName := field.Name.ValueString()
+			switch fieldName {
+			case "events":
+				for _, s := range stringArgs(field) {
+					switch s {
+					case "birth", "marriage", "death":
+						rs.Events = append(rs.Events, s)
+					default:
+						return nil, fmt.Errorf("record-set %q: unknown event %q", rs.Name, s)
+					}
+				}
+			case "years":
+				for _, arg := range field.Arguments {
+					n, ok := intValue(arg.Value)
+					if !ok {
+						return nil, fmt.Errorf("record-set %q: years must be integers", rs.Name)
+					}
+					rs.Years = append(rs.Years, n)
+				}
+			case "from", "to":
+				if len(field.Arguments) != 1 {
+					return nil, fmt.Errorf("record-set %q: %s must have a single year", rs.Name, fieldName)
+				}
+				n, ok := intValue(field.Arguments[0].Value)
+				if !ok {
+					return nil, fmt.Errorf("record-set %q: %s must be an integer", rs.Name, fieldName)
+				}
+				if fieldName == "from" {
+					rs.From = n
+				} else {
+					rs.To = n
+				}
+			case "countries":
+				rs.Countries = append(rs.Countries, stringArgs(field)...)
+			case "sources":
+				rs.Sources = append(rs.Sources, stringArgs(field)...)
+			case "census":
+				rs.Census = true
+			case "civil-registration":
+				rs.CivilRegistration = true
+			case "everyone":
+				rs.Everyone = true
+			default:
+				return nil, fmt.Errorf("record-set %q: unknown field %q", rs.Name, fieldName)
+			}
+		}
+
+		if len(rs.Events) == 0 && len(rs.Years) == 0 {
+			return nil, fmt.Errorf("record-set %q: must have events or years", rs.Name)
+		}
+		if len(rs.Events) > 0 && len(rs.Years) > 0 {
+			return nil, fmt.Errorf("record-set %q: cannot have both events and years", rs.Name)
+		}
+		rss = append(rss, rs)
+	}
+	return rss, nil
+}
+
+// stringArgs returns the string arguments of node.
+func stringArgs(node *document.Node) []string {
+	var ss []string
+	for _, arg := range node.Arguments {
+		if s, ok := arg.Value.(string); ok {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
+
+// CoversYear reports whether yr is within the years covered by the record set.
+func (rs *RecordSet) CoversYear(yr int) bool {
+	return coversYear(rs.From, rs.To, yr)
+}
+
+// CoversCountry reports whether the record set covers the nation or country
+// called name. A record set without countries covers everywhere.
+func (rs *RecordSet) CoversCountry(name string) bool {
+	return coversCountry(rs.Countries, name)
+}
+
+// coversYear reports whether yr is between from and to inclusive, treating a
+// zero bound as no limit.
+func coversYear(from, to, yr int) bool {
+	if from != 0 && yr < from {
+		return false
+	}
+	if to != 0 && yr > to {
+		return false
+	}
+	return true
+}
+
+// coversCountry reports whether name is one of countries, compared without
+// regard to case. An empty list of countries covers everywhere.
+func coversCountry(countries []string, name string) bool {
+	if len(countries) == 0 {
+		return true
+	}
+	for _, c := range countries {
+		if strings.EqualFold(c, name) {
+			return true
+		}
+	}
+	return false
+}
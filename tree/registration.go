@@ -0,0 +1,148 @@
+package tree
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iand/gdate"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/place"
+)
+
+// civilRegistrationStart is the year civil registration of births, marriages
+// and deaths began in England and Wales.
+const civilRegistrationStart = 1837
+
+// birthRegistrationDays is the number of days allowed for registering a
+// birth, so a birth late in a quarter may be indexed in the next.
+const birthRegistrationDays = 42
+
+// ResolveRegistrations finds the registration district and index quarter of
+// each birth, marriage and death in the tree that cites a civil registration
+// source. The district is found from the gazetteer entry of the place of the
+// event, or failing that from a district named in the citation. Events whose
+// place lies in a different district to the one cited are recorded as
+// anomalies of the people involved.
+func (t *Tree) ResolveRegistrations() {
+	if t.Gazetteer == nil {
+		return
+	}
+	seen := make(map[model.TimelineEvent]bool)
+	for _, p := range t.People {
+		for _, ev := range p.Timeline {
+			if seen[ev] {
+				continue
+			}
+			seen[ev] = true
+			t.resolveRegistration(ev)
+		}
+	}
+}
+
+func (t *Tree) resolveRegistration(ev model.TimelineEvent) {
+	var people []*model.Person
+	switch tev := ev.(type) {
+	case *model.BirthEvent:
+		people = []*model.Person{tev.GetPrincipal()}
+	case *model.DeathEvent:
+		people = []*model.Person{tev.GetPrincipal()}
+	case *model.MarriageEvent:
+		people = []*model.Person{tev.GetHusband(), tev.GetWife()}
+	default:
+		return
+	}
+
+	var citations []*model.GeneralCitation
+	for _, c := range ev.GetCitations() {
+		if c.Source != nil && c.Source.IsCivilRegistration {
+			citations = append(citations, c)
+		}
+	}
+	if len(citations) == 0 {
+		return
+	}
+
+	yr, ok := ev.GetDate().Year()
+	if !ok || yr < civilRegistrationStart {
+		return
+	}
+
+	var cited *place.PlaceName
+	for _, c := range citations {
+		if rds := t.Gazetteer.RegistrationDistrictsIn(c.Detail, yr); len(rds) > 0 {
+			cited = rds[0]
+			break
+		}
+	}
+
+	pl := ev.GetPlace()
+	district, ok := placeRegistrationDistrict(pl, yr)
+	if !ok {
+		district = cited
+	} else if cited != nil && cited != district {
+		for _, p := range people {
+			if p.IsUnknown() {
+				continue
+			}
+			p.Anomalies = append(p.Anomalies, &model.Anomaly{
+				Category: model.AnomalyCategoryEvent,
+				Text:     fmt.Sprintf("%s was in the %s registration district in %d but the civil registration citation names %s", pl.Name, district.Name, yr, cited.Name),
+				Context:  ev.Type() + " event",
+			})
+		}
+	}
+	if district == nil {
+		return
+	}
+
+	reg := &model.Registration{
+		District: t.gazetteerPlace(district),
+		Year:     yr,
+	}
+	_, isBirth := ev.(*model.BirthEvent)
+	reg.Quarter, reg.Late = registrationQuarter(ev.GetDate(), isBirth)
+
+	switch tev := ev.(type) {
+	case *model.BirthEvent:
+		tev.Registration = reg
+	case *model.DeathEvent:
+		tev.Registration = reg
+	case *model.MarriageEvent:
+		tev.Registration = reg
+	}
+}
+
+// placeRegistrationDistrict returns the registration district that pl, or
+// the nearest place containing it that is in the gazetteer, belonged to in
+// the year yr.
+func placeRegistrationDistrict(pl *model.Place, yr int) (*place.PlaceName, bool) {
+	for ; !pl.IsUnknown(); pl = pl.Parent {
+		if pl.Gazetteer != nil {
+			return pl.Gazetteer.RegistrationDistrict(yr)
+		}
+	}
+	return nil, false
+}
+
+// registrationQuarter returns the index quarter of an event on the date dt
+// and whether, being a birth, it may have been registered in the following
+// quarter instead.
+func registrationQuarter(dt *model.Date, isBirth bool) (int, bool) {
+	if dt.IsUnknown() {
+		return 0, false
+	}
+	if yq, ok := dt.Date.(*gdate.YearQuarter); ok {
+		// already an index quarter
+		return yq.Q, false
+	}
+	if y, m, d, ok := dt.YMD(); ok {
+		q := (m-1)/3 + 1
+		end := time.Date(y, time.Month(q*3+1), 1, 0, 0, 0, 0, time.UTC)
+		late := isBirth && end.Sub(time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.UTC)) <= birthRegistrationDays*24*time.Hour
+		return q, late
+	}
+	if _, m, ok := dt.YM(); ok {
+		return (m-1)/3 + 1, isBirth && m%3 == 0
+	}
+	return 0, false
+}
@@ -0,0 +1,142 @@
+package tree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/iand/gdate"
+	"github.com/iand/genster/model"
+	"github.com/iand/genster/place"
+)
+
+func TestResolveRegistrations(t *testing.T) {
+	const gaz = `id,kind,name,parents,from,to,districts
+cam,county,Cambridgeshire,England,,,
+rd-linton,registration district,Linton,cam,1837,1934,
+rd-chesterton,registration district,Chesterton,cam,1837,1974,
+harston,parish,Harston,cam,,,rd-linton:-1851;rd-chesterton:1852-
+`
+	g, err := place.ReadGazetteer(strings.NewReader(gaz), ',')
+	if err != nil {
+		t.Fatalf("ReadGazetteer: %v", err)
+	}
+	gro := &model.Source{ID: "gro", Title: "GRO Index", IsCivilRegistration: true}
+	parish := &model.Source{ID: "pr", Title: "Harston parish register"}
+
+	testCases := []struct {
+		name        string
+		date        *model.Date
+		place       string
+		source      *model.Source
+		detail      string
+		wantDist    string
+		wantQuarter string
+		wantAnomaly bool
+	}{
+		{
+			name:        "district_from_place",
+			date:        model.PreciseDate(1845, 5, 10),
+			place:       "Harston, Cambridgeshire, England",
+			source:      gro,
+			wantDist:    "Linton",
+			wantQuarter: "June quarter of 1845",
+		},
+		{
+			name:        "district_changed",
+			date:        model.PreciseDate(1860, 2, 1),
+			place:       "Harston, Cambridgeshire, England",
+			source:      gro,
+			detail:      "Chesterton 3b 401",
+			wantDist:    "Chesterton",
+			wantQuarter: "March quarter of 1860",
+		},
+		{
+			name:        "late_in_quarter",
+			date:        model.PreciseDate(1860, 12, 20),
+			place:       "Harston, Cambridgeshire, England",
+			source:      gro,
+			wantDist:    "Chesterton",
+			wantQuarter: "December quarter of 1860 or March quarter of 1861",
+		},
+		{
+			name:        "index_quarter",
+			date:        &model.Date{Date: &gdate.YearQuarter{Y: 1860, Q: 3}},
+			place:       "Harston, Cambridgeshire, England",
+			source:      gro,
+			wantDist:    "Chesterton",
+			wantQuarter: "September quarter of 1860",
+		},
+		{
+			name:        "inconsistent_district",
+			date:        model.PreciseDate(1860, 2, 1),
+			place:       "Harston, Cambridgeshire, England",
+			source:      gro,
+			detail:      "Linton 3b 401",
+			wantDist:    "Chesterton",
+			wantQuarter: "March quarter of 1860",
+			wantAnomaly: true,
+		},
+		{
+			name:        "district_from_citation",
+			date:        model.PreciseDate(1860, 2, 1),
+			place:       "Somewhere, England",
+			source:      gro,
+			detail:      "Linton 3b 401",
+			wantDist:    "Linton",
+			wantQuarter: "March quarter of 1860",
+		},
+		{
+			name:   "not_civil_registration",
+			date:   model.PreciseDate(1860, 2, 1),
+			place:  "Harston, Cambridgeshire, England",
+			source: parish,
+		},
+		{
+			name:   "before_civil_registration",
+			date:   model.PreciseDate(1830, 2, 1),
+			place:  "Harston, Cambridgeshire, England",
+			source: gro,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := NewTree("test", &Annotations{}, &SurnameGroups{})
+			tr.Gazetteer = g
+
+			p := &model.Person{ID: "p1"}
+			ev := &model.BirthEvent{
+				GeneralEvent: model.GeneralEvent{
+					Date:      tc.date,
+					Place:     tr.FindPlaceUnstructured(tc.place),
+					Citations: []*model.GeneralCitation{{ID: "c1", Source: tc.source, Detail: tc.detail}},
+				},
+				GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+			}
+			p.Timeline = []model.TimelineEvent{ev}
+			tr.People[p.ID] = p
+
+			tr.ResolveRegistrations()
+
+			reg := ev.GetRegistration()
+			if tc.wantDist == "" {
+				if reg != nil {
+					t.Fatalf("got registration in %s, wanted none", reg.District.Name)
+				}
+				return
+			}
+			if reg == nil {
+				t.Fatalf("got no registration, wanted %s", tc.wantDist)
+			}
+			if reg.District.Name != tc.wantDist || reg.District.PlaceType != model.PlaceTypeRegistrationDistrict {
+				t.Errorf("got district %s (%s), want %s", reg.District.Name, reg.District.PlaceType, tc.wantDist)
+			}
+			if got := reg.QuarterName(); got != tc.wantQuarter {
+				t.Errorf("got quarter %q, want %q", got, tc.wantQuarter)
+			}
+			if gotAnomaly := len(p.Anomalies) > 0; gotAnomaly != tc.wantAnomaly {
+				t.Errorf("got anomaly %v, want %v", gotAnomaly, tc.wantAnomaly)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/iand/genster/infer"
 	"github.com/iand/genster/logging"
 	"github.com/iand/genster/model"
+	"github.com/iand/genster/occupation"
 	"github.com/iand/genster/place"
 	"github.com/iand/genster/text"
 )
@@ -20,6 +21,8 @@ type Tree struct {
 	Description   string
 	Annotations   *Annotations
 	SurnameGroups *SurnameGroups
+	Gazetteer     *place.Gazetteer       // used to classify unstructured place names, may be nil
+	Occupations   *occupation.Dictionary // used to normalise recorded occupations, may be nil
 	People        map[string]*model.Person
 	Citations     map[string]*model.GeneralCitation
 	Sources       map[string]*model.Source
@@ -28,6 +31,9 @@ type Tree struct {
 	Families      map[string]*model.Family
 	MediaObjects  map[string]*model.MediaObject
 	KeyPerson     *model.Person
+	ShowNewStyle  bool // whether dates recorded under the Julian calendar are followed by their Gregorian equivalent
+
+	datedPlaces map[string]*model.Place // places as they were named at particular dates, see PlaceIn
 }
 
 func NewTree(id string, a *Annotations, sg *SurnameGroups) *Tree {
@@ -137,8 +143,13 @@ func (t *Tree) FindPlaceUnstructured(name string, hints ...place.Hint) *model.Pl
 	id := t.CanonicalID("unstructured", name)
 	p, ok := t.Places[id]
 	if !ok {
+		match, rest, inGazetteer := t.Gazetteer.Resolve(name)
+		if inGazetteer && len(rest) == 0 {
+			// the name is wholly described by the gazetteer
+			return t.gazetteerPlace(match)
+		}
 
-		pn := place.ClassifyName(name, hints...)
+		pn := t.Gazetteer.ClassifyName(name, hints...)
 		cleanName := pn.Name
 
 		p = &model.Place{
@@ -162,6 +173,14 @@ func (t *Tree) FindPlaceUnstructured(name string, hints ...place.Hint) *model.Pl
 			p.UKNationName = c
 		}
 
+		if inGazetteer {
+			// the more specific parts of the name are a place within one
+			// described by the gazetteer
+			p.Name = place.Clean(strings.Join(rest, ", "))
+			p.Parent = t.gazetteerPlace(match)
+			setHierarchyNames(p)
+		}
+
 		logging.Debug("adding place", "name", name, "id", id, "country", p.CountryName.Name)
 
 		t.Places[id] = p
@@ -170,6 +189,93 @@ func (t *Tree) FindPlaceUnstructured(name string, hints ...place.Hint) *model.Pl
 	return p
 }
 
+// gazetteerPlace returns the place described by the gazetteer entry pn,
+// creating it and the places that contain it if needed.
+func (t *Tree) gazetteerPlace(pn *place.PlaceName) *model.Place {
+	key := pn.ID
+	if key == "" {
+		// a built in country or nation
+		key = pn.Name
+	}
+	id := t.CanonicalID("gazetteer", key)
+	if p, ok := t.Places[id]; ok {
+		return p
+	}
+
+	p := &model.Place{
+		ID:                id,
+		OriginalText:      pn.Name,
+		Name:              pn.Name,
+		FullName:          pn.Name,
+		PreferredSortName: pn.Name,
+		Adjective:         pn.Adjective,
+		PlaceType:         gazetteerPlaceType(pn.Kind),
+		CountryName:       place.UnknownPlaceName(),
+		UKNationName:      place.UnknownPlaceName(),
+		Gazetteer:         pn,
+	}
+	if pn.Located {
+		p.GeoLocation = &model.GeoLocation{Latitude: pn.Latitude, Longitude: pn.Longitude}
+	}
+	if c, ok := pn.FindContainerKind(place.PlaceKindCountry); ok {
+		p.CountryName = c
+	}
+	if c, ok := pn.FindContainerKind(place.PlaceKindUKNation); ok {
+		p.UKNationName = c
+	}
+	t.Places[id] = p
+
+	// the hierarchy of built in places stops at the first country or nation
+	// so that it matches the way names are usually written
+	if pn.ID != "" && len(pn.PartOf) > 0 {
+		p.Parent = t.gazetteerPlace(pn.PartOf[0])
+		if p.GeoLocation == nil {
+			p.GeoLocation = p.Parent.GeoLocation
+		}
+		setHierarchyNames(p)
+	}
+	logging.Debug("adding gazetteer place", "name", pn.Name, "id", id, "type", p.PlaceType)
+
+	return p
+}
+
+// setHierarchyNames sets the full and sort names of p from its own name and
+// those of its parent, which must already have been set.
+func setHierarchyNames(p *model.Place) {
+	p.FullName = p.Name
+	p.PreferredSortName = p.Name
+	if p.Parent != nil {
+		p.FullName += ", " + p.Parent.FullName
+		p.PreferredSortName = p.Parent.PreferredSortName + ", " + p.Name
+	}
+}
+
+// gazetteerPlaceType returns the type of place corresponding to kind.
+func gazetteerPlaceType(kind place.PlaceKind) model.PlaceType {
+	switch kind {
+	case place.PlaceKindCountry:
+		return model.PlaceTypeCountry
+	case place.PlaceKindUKNation:
+		return model.PlaceTypeNation
+	case place.PlaceKindCounty:
+		return model.PlaceTypeCounty
+	case place.PlaceKindRegistrationDistrict:
+		return model.PlaceTypeRegistrationDistrict
+	case place.PlaceKindParish:
+		return model.PlaceTypeParish
+	case place.PlaceKindCity:
+		return model.PlaceTypeCity
+	case place.PlaceKindTown:
+		return model.PlaceTypeTown
+	case place.PlaceKindVillage:
+		return model.PlaceTypeVillage
+	case place.PlaceKindHamlet:
+		return model.PlaceTypeHamlet
+	default:
+		return model.PlaceTypeUnknown
+	}
+}
+
 func (t *Tree) FindFamily(scope string, sid string) *model.Family {
 	id := t.CanonicalID(scope, sid)
 	f, ok := t.Families[id]
@@ -261,6 +367,8 @@ func (t *Tree) Generate(redactLiving bool) error {
 			t.Annotations.ApplySource(p)
 		}
 	}
+	t.ResolveJurisdictions()
+	t.MergeSameAsPlaces()
 
 	// Add data to each person
 	for _, p := range t.People {
@@ -283,6 +391,8 @@ func (t *Tree) Generate(redactLiving bool) error {
 		t.ExpandPersonTimeline(p)
 	}
 
+	t.ResolveRegistrations()
+
 	// Fill in gaps with inferences
 	for _, p := range t.People {
 		// infer.InferPersonBirthEventDate(p)
@@ -337,6 +447,10 @@ func (t *Tree) Generate(redactLiving bool) error {
 		t.AddPlaceAdjectives(p)
 		t.TrimPlaceTimeline(p)
 	}
+	t.DatePlaceNames()
+	if t.ShowNewStyle {
+		t.ShowNewStyleDates()
+	}
 	for _, s := range t.Sources {
 		t.TrimSourceTimeline(s)
 	}
@@ -477,10 +591,13 @@ func (t *Tree) SelectPersonBestBirthDeathEvents(p *model.Person) error {
 	}
 
 	var startYear, endYear int
+	var startText, endText string // shown with a dual year for Old Style dates
 
 	if p.BestBirthlikeEvent != nil {
-		if year, ok := p.BestBirthlikeEvent.GetDate().Year(); ok {
+		dt := p.BestBirthlikeEvent.GetDate()
+		if year, ok := dt.Year(); ok {
 			startYear = year
+			startText, _ = dt.DisplayYear()
 		}
 	}
 
@@ -491,22 +608,24 @@ func (t *Tree) SelectPersonBestBirthDeathEvents(p *model.Person) error {
 
 	if p.BestDeathlikeEvent != nil {
 		p.PossiblyAlive = false
-		if year, ok := p.BestDeathlikeEvent.GetDate().Year(); ok {
+		dt := p.BestDeathlikeEvent.GetDate()
+		if year, ok := dt.Year(); ok {
 			endYear = year
+			endText, _ = dt.DisplayYear()
 		}
 	}
 
 	if startYear == 0 && endYear == 0 {
 		p.VitalYears = model.UnknownDateRangePlaceholder
 	} else if startYear != 0 && endYear == 0 {
-		p.VitalYears = fmt.Sprintf("%d–", startYear)
+		p.VitalYears = startText + "–"
 		if !p.PossiblyAlive {
 			p.VitalYears += "?"
 		}
 	} else if startYear == 0 && endYear != 0 {
-		p.VitalYears = fmt.Sprintf("?–%d", endYear)
+		p.VitalYears = "?–" + endText
 	} else {
-		p.VitalYears = fmt.Sprintf("%d–%d", startYear, endYear)
+		p.VitalYears = startText + "–" + endText
 	}
 
 	if p.PossiblyAlive {
@@ -699,30 +818,90 @@ func (t *Tree) RefinePersonNames(p *model.Person) error {
 	return nil
 }
 
+// RefinePersonOccupations replaces the recorded wording of each of the
+// person's occupations with its normalised title, code and social class from
+// the occupation dictionary, leaving the wording in the occupation's detail.
+// It then sets the person's main occupation group and social class to those
+// recorded most often.
 func (t *Tree) RefinePersonOccupations(p *model.Person) error {
-	// TODO: move from gedcom import to here
-	// NOTE: PrimaryOccupation is set from Gedcom FACT type Occupation
-	// if len(p.Occupations) == 0 {
-	// 	return nil
-	// }
-	// if len(p.Occupations) == 1 {
-	// 	logging.Warn("setting primary occupation", "id", p.ID, "primary", p.Occupations[0].Detail)
-	// 	p.PrimaryOccupation = p.Occupations[0].Detail
-
-	// 	return nil
-	// }
-	// if len(p.Occupations) > 1 {
-	// 	var occs []string
-	// 	for i := range p.Occupations {
-	// 		occs = append(occs, p.Occupations[i].Detail)
-	// 	}
-	// 	logging.Warn("not setting primary occupation", "id", p.ID, "occupations", strings.Join(occs, "|"))
+	for _, occ := range p.Occupations {
+		t.normaliseOccupation(occ)
+	}
+	for _, ev := range p.Timeline {
+		if oev, ok := ev.(*model.OccupationEvent); ok {
+			t.normaliseOccupation(&oev.Occupation)
+		}
+	}
 
-	// }
+	var groups []model.OccupationGroup
+	var classes []model.SocialClass
+	for _, occ := range p.Occupations {
+		for range max(occ.Occurrences, 1) {
+			if occ.Group != model.OccupationGroupUnknown {
+				groups = append(groups, occ.Group)
+			}
+			if occ.Class != model.SocialClassUnknown {
+				classes = append(classes, occ.Class)
+			}
+		}
+	}
+	if len(groups) > 0 {
+		p.OccupationGroup = mostFrequent(groups)
+	}
+	if len(classes) > 0 {
+		p.SocialClass = mostFrequent(classes)
+	}
 
 	return nil
 }
 
+// normaliseOccupation looks up the occupation by its name, or by its
+// recorded wording if the name is not in the dictionary, as it was used in
+// the year it was recorded. An occupation that is not found is left
+// unchanged.
+func (t *Tree) normaliseOccupation(occ *model.Occupation) {
+	if t.Occupations == nil {
+		return
+	}
+	yr, ok := occ.Date.Year()
+	if !ok {
+		yr, _ = occ.StartDate.Year()
+	}
+	e, ok := t.Occupations.Lookup(occ.Name, yr)
+	if !ok {
+		e, ok = t.Occupations.Lookup(occ.Detail, yr)
+	}
+	if !ok {
+		logging.Debug("occupation not in dictionary", "name", occ.Name, "detail", occ.Detail)
+		return
+	}
+	if occ.Detail == "" {
+		occ.Detail = occ.Name
+	}
+	occ.Name = e.Title
+	occ.Code = e.Code
+	occ.Class = e.Class
+	if e.Group != model.OccupationGroupUnknown {
+		occ.Group = e.Group
+	}
+}
+
+// mostFrequent returns the value that occurs most often in vs, preferring
+// the earliest when several occur equally often. vs must not be empty.
+func mostFrequent[V comparable](vs []V) V {
+	counts := make(map[V]int)
+	for _, v := range vs {
+		counts[v]++
+	}
+	best := vs[0]
+	for _, v := range vs {
+		if counts[v] > counts[best] {
+			best = v
+		}
+	}
+	return best
+}
+
 func (t *Tree) BuildOlb(p *model.Person) error {
 	return nil
 }
@@ -1100,6 +1279,33 @@ func (t *Tree) RefinePlaceNames(pl *model.Place) error {
 	return nil
 }
 
+// ShowNewStyleDates marks the date of each event and occupation in the tree
+// so that dates recorded under the Julian calendar are shown with their
+// Gregorian equivalent.
+func (t *Tree) ShowNewStyleDates() {
+	mark := func(evs []model.TimelineEvent) {
+		for _, ev := range evs {
+			if dt := ev.GetDate(); dt.IsOldStyle() {
+				dt.ShowNewStyle = true
+			}
+		}
+	}
+	for _, p := range t.People {
+		mark(p.Timeline)
+		for _, occ := range p.Occupations {
+			if occ.Date.IsOldStyle() {
+				occ.Date.ShowNewStyle = true
+			}
+		}
+	}
+	for _, f := range t.Families {
+		mark(f.Timeline)
+	}
+	for _, pl := range t.Places {
+		mark(pl.Timeline)
+	}
+}
+
 func (t *Tree) AddPlaceAdjectives(pl *model.Place) error {
 	if pl.PlaceType != model.PlaceTypeCountry {
 		return nil
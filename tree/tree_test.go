@@ -0,0 +1,30 @@
+package tree
+
+import (
+	"testing"
+
+	"github.com/iand/gdate"
+	"github.com/iand/genster/model"
+)
+
+func TestSelectPersonBestBirthDeathEventsVitalYears(t *testing.T) {
+	tr := NewTree("test", &Annotations{}, &SurnameGroups{})
+
+	p := &model.Person{ID: "p1"}
+	birth := &model.BirthEvent{
+		GeneralEvent:           model.GeneralEvent{Date: &model.Date{Date: &gdate.Precise{C: gdate.Julian25Mar, Y: 1720, M: 2, D: 10}}},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+	}
+	death := &model.DeathEvent{
+		GeneralEvent:           model.GeneralEvent{Date: &model.Date{Date: &gdate.Precise{C: gdate.Gregorian, Y: 1790, M: 6, D: 1}}},
+		GeneralIndividualEvent: model.GeneralIndividualEvent{Principal: p},
+	}
+	p.Timeline = []model.TimelineEvent{birth, death}
+
+	if err := tr.SelectPersonBestBirthDeathEvents(p); err != nil {
+		t.Fatalf("SelectPersonBestBirthDeathEvents: %v", err)
+	}
+	if want := "1720/21–1790"; p.VitalYears != want {
+		t.Errorf("got vital years %q, wanted %q", p.VitalYears, want)
+	}
+}